@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -55,6 +57,23 @@ func parseStringList(str string) []string {
 	return res
 }
 
+// 콤마로 구분된 정수 리스트를 파싱합니다.
+// 빈 값은 무시하며, 정수가 아닌 값은 에러 로그를 남기고 무시합니다.
+func parseInt64List(str string) []int64 {
+	res := []int64{}
+	for _, v := range parseStringList(str) {
+		if v == "" {
+			continue
+		}
+		if iv, err := strconv.ParseInt(v, 10, 64); err != nil {
+			rnlog.Error("Envfile parsing error - %v: %v", v, err)
+		} else {
+			res = append(res, iv)
+		}
+	}
+	return res
+}
+
 // 환경변수를 불러옵니다.
 // Init("$(PWD)/default.env", "[$(PWD)/native.env | $(PWD)/test.env | $(PWD)/prod.env]") 처럼 넣으면 됩니다.
 // 메인에서 최초 한 번만 호출되어야 합니다.
@@ -65,15 +84,30 @@ func Init(env ...string) {
 
 	config = &Config{}
 
+	config.Environment = getEnv("ENVIRONMENT")
 	config.LogFilePath = getEnv("LOG_FILE_PATH")
 	config.LogFile = getEnv("LOG_FILE")
+	config.LogFormat = getEnv("LOG_FORMAT")
+	if config.LogFormat == "" {
+		config.LogFormat = "text"
+	}
 	config.Port = getEnvInt("PORT")
 	config.Domain = getEnv("DOMAIN")
 	config.MaxAge = getEnvInt("MAX_AGE")
+	config.MetricsToken = getEnv("METRICS_TOKEN")
+	config.ShutdownTimeout = time.Duration(getEnvInt("SHUTDOWN_TIMEOUT")) * time.Second
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = 20 * time.Second
+	}
 	config.CorsOrigin = parseStringList(getEnv("CORS_ORIGIN"))
 	config.Cookies.PublicSessionName = getEnv("PUBLIC_SESSION_NAME")
 	config.Cookies.SessionName = getEnv("SESSION_NAME")
 	config.Cookies.SessionTimeout = time.Hour * 24 * 7
+	config.Cookies.GuestSessionName = getEnv("GUEST_SESSION_NAME")
+	if config.Cookies.GuestSessionName == "" {
+		config.Cookies.GuestSessionName = "jgc_guest"
+	}
+	config.Cookies.GuestSessionTimeout = time.Hour * 24 * 30
 	config.Jwt.SecretKey = getEnv("JWT_SECRET_KEY")
 	config.DB.JGCSchema = getEnv("DB_SCHEMA")
 	config.DB.PoolSize = 10
@@ -84,6 +118,52 @@ func Init(env ...string) {
 	config.DB.Port = getEnvInt("DB_PORT")
 	config.DB.Lifecycle = time.Hour * 7
 	config.DB.MaxRetry = getEnvInt("DB_MAX_RETRY")
+	config.Pbv.MaxDataSize = 32 * 1024
+	config.Product.MaxDescriptionSize = 32 * 1024
+	config.Cart.ShippingFee = 3000
+	config.Cart.FreeShippingThreshold = 50000
+	config.Admin.UserIds = parseInt64List(getEnv("ADMIN_USER_IDS"))
+	config.Media.Driver = getEnv("MEDIA_DRIVER")
+	if config.Media.Driver == "" {
+		config.Media.Driver = "local"
+	}
+	config.Media.LocalRoot = getEnv("MEDIA_LOCAL_ROOT")
+	if config.Media.LocalRoot == "" {
+		config.Media.LocalRoot = "./uploads"
+	}
+	config.Media.PublicUrl = getEnv("MEDIA_PUBLIC_URL")
+	config.Media.S3Endpoint = getEnv("MEDIA_S3_ENDPOINT")
+	config.Media.S3Region = getEnv("MEDIA_S3_REGION")
+	config.Media.S3Bucket = getEnv("MEDIA_S3_BUCKET")
+	config.Media.S3AccessKey = getEnv("MEDIA_S3_ACCESS_KEY")
+	config.Media.S3SecretKey = getEnv("MEDIA_S3_SECRET_KEY")
+	config.Media.MaxUploadSize = 5 * 1024 * 1024
+	config.Media.MaxImagePixels = 40000000
+	config.Media.ThumbnailSize = 320
+	config.Media.MaxImagesPerProduct = 10
+	config.Tracing.Exporter = getEnv("OTEL_TRACES_EXPORTER")
+	if config.Tracing.Exporter == "" {
+		config.Tracing.Exporter = "none"
+	}
+	config.Tracing.Endpoint = getEnv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	config.Tracing.Headers = map[string]string{}
+	for _, v := range parseStringList(getEnv("OTEL_EXPORTER_OTLP_HEADERS")) {
+		if kv := strings.SplitN(v, "=", 2); len(kv) == 2 {
+			config.Tracing.Headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+	config.Tracing.ServiceName = getEnv("OTEL_SERVICE_NAME")
+	if config.Tracing.ServiceName == "" {
+		config.Tracing.ServiceName = "jgc-api"
+	}
+	config.Tracing.SampleRatio = 1
+	if ratio := getEnv("OTEL_TRACES_SAMPLER_ARG"); ratio != "" {
+		if v, err := strconv.ParseFloat(ratio, 64); err != nil {
+			rnlog.Error("Envfile parsing error - %v: %v", "OTEL_TRACES_SAMPLER_ARG", err)
+		} else {
+			config.Tracing.SampleRatio = v
+		}
+	}
 }
 
 // config 정보를 담을 객체입니다.
@@ -91,12 +171,20 @@ func Init(env ...string) {
 // 추가해야하는 정보가 생긴다면 struct에 추가한 뒤 default_config에 무조건 추가한 뒤,
 // 다른 환경에 데이터에 값을 추가하거나, 추가하지 않으셔도 됩니다.
 type Config struct {
+	// 서버를 실행하는 환경입니다. (native, test, product)
+	// 데모 데이터처럼 운영 디비에 실행하면 안 되는 명령을 막을 때 사용하므로, 환경마다 .env 파일에 꼭 적어주세요.
+	Environment string
+
 	// 로그 파일의 경로입니다.
 	LogFilePath string
 
 	// 로그 파일의 이름입니다.
 	LogFile string
 
+	// 서버 로그의 출력 형식입니다. text 또는 json을 사용할 수 있으며, 없다면 text를 사용합니다.
+	// 로그 수집기를 사용한다면 한 줄에 JSON 객체 하나씩 출력하는 json을 사용해주세요.
+	LogFormat string
+
 	// 돌아가고 있는 서버의 포트와 도메인입니다.
 	Port   int
 	Domain string
@@ -104,6 +192,14 @@ type Config struct {
 	// 캐싱에 사용할 max age 입니다. 초 단위로 동작합니다.
 	MaxAge int
 
+	// /metrics 엔드포인트를 호출할 때 필요한 토큰입니다.
+	// 비워두면 누구나 메트릭을 볼 수 있으므로, 외부에 열린 서버라면 꼭 설정해주세요.
+	MetricsToken string
+
+	// 종료 신호를 받은 뒤 처리 중인 요청을 기다릴 최대 시간입니다.
+	// .env 파일에 초 단위로 입력하며, 없다면 20초를 사용합니다.
+	ShutdownTimeout time.Duration
+
 	// 서버에서 허용알 CORS origin입니다.
 	// 여러 origin을 허용하려면 .env 파일에 콤마로 구분하여 입력해주세요.
 	CorsOrigin []string
@@ -118,6 +214,13 @@ type Config struct {
 
 		// 두 쿠키의 유지 시간입니다.
 		SessionTimeout time.Duration
+
+		// 로그인하지 않은 사용자의 장바구니를 구분하는 게스트 쿠키입니다.
+		// 환경변수가 없다면 jgc_guest를 사용합니다.
+		GuestSessionName string
+
+		// 게스트 쿠키의 유지 시간입니다.
+		GuestSessionTimeout time.Duration
 	}
 
 	// jwt 관련 데이터입니다.
@@ -157,6 +260,146 @@ type Config struct {
 		// 쿼리에 실패했을 때 자동으로 재 시도할 횟수입니다.
 		MaxRetry int
 	}
+
+	// PBV 옵션 관련 데이터입니다.
+	Pbv struct {
+		// 옵션 데이터(JSON 문자열)의 최대 크기입니다. 바이트 단위로 동작합니다.
+		MaxDataSize int
+	}
+
+	// 상품 관련 데이터입니다.
+	Product struct {
+		// 상품 상세 설명의 최대 크기입니다. 바이트 단위로 동작합니다.
+		MaxDescriptionSize int
+	}
+
+	// 장바구니 관련 데이터입니다.
+	Cart struct {
+		// 브랜드마다 붙는 배송비입니다.
+		ShippingFee int64
+
+		// 브랜드별 합계가 이 금액 이상이면 배송비가 붙지 않습니다.
+		FreeShippingThreshold int64
+	}
+
+	// 관리자 관련 데이터입니다.
+	Admin struct {
+		// 쿠폰 생성처럼 관리자 권한이 필요한 기능을 사용할 수 있는 유저 아이디입니다.
+		// .env 파일에 콤마로 구분하여 입력해주세요.
+		UserIds []int64
+	}
+
+	// 상품 이미지 같은 미디어 파일 관련 데이터입니다.
+	Media struct {
+		// 파일을 저장할 저장소입니다. local 또는 s3를 사용할 수 있으며, 환경변수가 없다면 local을 사용합니다.
+		Driver string
+
+		// local 저장소에서 파일을 저장할 폴더입니다. 환경변수가 없다면 ./uploads를 사용합니다.
+		LocalRoot string
+
+		// 저장된 파일에 접근할 때 앞에 붙일 주소입니다. (CDN 주소 등)
+		// s3 저장소에서 비워두면 S3Endpoint/S3Bucket을 사용합니다.
+		PublicUrl string
+
+		// S3 호환 저장소의 주소, 리전, 버킷, 인증 키입니다.
+		S3Endpoint  string
+		S3Region    string
+		S3Bucket    string
+		S3AccessKey string
+		S3SecretKey string
+
+		// 업로드할 수 있는 파일의 최대 크기입니다. 바이트 단위로 동작합니다.
+		MaxUploadSize int
+
+		// 업로드할 수 있는 이미지의 최대 픽셀 수(가로 * 세로)입니다.
+		MaxImagePixels int
+
+		// 썸네일의 긴 변 길이입니다. 픽셀 단위로 동작합니다.
+		ThumbnailSize int
+
+		// 상품 하나에 등록할 수 있는 최대 이미지 개수입니다.
+		MaxImagesPerProduct int64
+	}
+
+	// 요청 추적(OpenTelemetry) 관련 데이터입니다.
+	// 환경변수 이름은 OpenTelemetry SDK와 같습니다.
+	Tracing struct {
+		// 스팬을 내보낼 방법입니다. none 또는 otlp를 사용할 수 있으며, 없다면 none으로 아무것도 기록하지 않습니다.
+		Exporter string
+
+		// OTLP/HTTP 수집기의 주소입니다. (예: http://otel-collector:4318)
+		Endpoint string
+
+		// 수집기로 보낼 때 함께 보낼 헤더입니다.
+		// .env 파일에 key=value를 콤마로 구분하여 입력해주세요.
+		Headers map[string]string
+
+		// 트레이스에 남길 서비스 이름입니다. 없다면 jgc-api를 사용합니다.
+		ServiceName string
+
+		// 새로 시작하는 트레이스 중 기록할 비율입니다. 0부터 1까지 입력할 수 있으며, 없다면 1을 사용합니다.
+		SampleRatio float64
+	}
+}
+
+// 관리자 유저인지 확인합니다.
+func (c *Config) IsAdmin(userId int64) bool {
+	for _, v := range c.Admin.UserIds {
+		if v == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// 데이터를 지울 수 있는 개발용 명령을 실행해도 되는 환경인지 확인합니다.
+// ENVIRONMENT가 native나 test로 적혀 있어야 하며, 비어있다면 운영 환경일 수도 있으므로 false를 반환합니다.
+func (c *Config) IsDevelopment() bool {
+	return c.Environment == "native" || c.Environment == "test"
+}
+
+// 요청 바디의 최대 크기입니다. 바이트 단위로 동작합니다.
+// 가장 큰 요청인 상품 이미지 업로드는 이미지를 base64로 담으므로, 업로드할 수 있는 파일 최대 크기의 4/3에
+// 다른 JSON 필드가 들어갈 64KB를 더합니다.
+func (c *Config) MaxBodySize() int64 {
+	return int64(c.Media.MaxUploadSize)*4/3 + 64*1024
+}
+
+// 서버를 실행하는 데 꼭 필요한 설정이 모두 올바른지 확인합니다.
+// 잘못된 설정을 모두 모아서 하나의 에러로 반환하며, 문제가 없다면 nil을 반환합니다.
+func (c *Config) Validate() error {
+	problems := []string{}
+	if c.Port <= 0 || c.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT is out of range: %d", c.Port))
+	}
+	if c.Jwt.SecretKey == "" {
+		problems = append(problems, "JWT_SECRET_KEY is empty")
+	}
+	if c.Cookies.SessionName == "" || c.Cookies.PublicSessionName == "" {
+		problems = append(problems, "SESSION_NAME and PUBLIC_SESSION_NAME are required")
+	}
+	if c.DB.JGCSchema == "" || c.DB.Host == "" {
+		problems = append(problems, "DB_SCHEMA and DB_HOST are required")
+	}
+	if c.LogFormat != "text" && c.LogFormat != "json" {
+		problems = append(problems, fmt.Sprintf("LOG_FORMAT must be text or json: %s", c.LogFormat))
+	}
+	if c.Media.Driver != "local" && c.Media.Driver != "s3" {
+		problems = append(problems, fmt.Sprintf("MEDIA_DRIVER must be local or s3: %s", c.Media.Driver))
+	}
+	if c.Tracing.Exporter != "none" && c.Tracing.Exporter != "otlp" {
+		problems = append(problems, fmt.Sprintf("OTEL_TRACES_EXPORTER must be none or otlp: %s", c.Tracing.Exporter))
+	}
+	if c.Tracing.Exporter == "otlp" && c.Tracing.Endpoint == "" {
+		problems = append(problems, "OTEL_EXPORTER_OTLP_ENDPOINT is required when OTEL_TRACES_EXPORTER is otlp")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("OTEL_TRACES_SAMPLER_ARG must be between 0 and 1: %v", c.Tracing.SampleRatio))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, "; "))
 }
 
 // Init함수로 초기화해준 Config 객체를 반환합니다.
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thak1411/gorn"
+)
+
+// 데모 데이터를 관리할 때 사용하는 디비의 인터페이스 입니다.
+// 테이블 이름과 컬럼 이름은 dbmodel에 등록된 이름만 넣어야 합니다.
+type DemoDatabase interface {
+	GetIds(ctx context.Context, tableName, columnName string) (map[int64]bool, error)
+	DeleteAll(ctx context.Context, tableName string) error
+}
+
+// 데모 데이터 디비의 구현체입니다.
+type DemoDB struct {
+	*gorn.DB
+}
+
+// 테이블에 이미 들어있는 아이디를 모두 가져옵니다.
+func (h *DemoDB) GetIds(ctx context.Context, tableName, columnName string) (map[int64]bool, error) {
+	type Id struct {
+		Id int64 `rnsql:"id"`
+	}
+	ids := []*Id{}
+	sql := gorn.NewSql().
+		AddPlainQuery(fmt.Sprintf("SELECT `%s` AS id", columnName)).
+		From(fmt.Sprintf("`%s`", tableName))
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &ids); err != nil {
+		return nil, err
+	}
+	result := make(map[int64]bool, len(ids))
+	for _, v := range ids {
+		result[v.Id] = true
+	}
+	return result, nil
+}
+
+// 테이블의 모든 데이터를 삭제합니다.
+func (h *DemoDB) DeleteAll(ctx context.Context, tableName string) error {
+	sql := gorn.NewSql().
+		DeleteFrom(tableName)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 새로운 디비 객체를 연결합니다.
+func NewDemo(db *gorn.DB) DemoDatabase {
+	return &DemoDB{
+		DB: db,
+	}
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/thak1411/gorn"
+)
+
+// 서버 상태를 확인할 때 사용하는 디비의 인터페이스 입니다.
+type HealthDatabase interface {
+	Ping(ctx context.Context) error
+	GetMigrationVersion(ctx context.Context) (int64, error)
+}
+
+// 서버 상태 확인 디비의 구현체입니다.
+type HealthDB struct {
+	*gorn.DB
+}
+
+// 커넥션 풀에서 연결을 하나 가져와 간단한 쿼리를 실행해봅니다.
+func (h *HealthDB) Ping(ctx context.Context) error {
+	type Pong struct {
+		One int64 `rnsql:"1"`
+	}
+	result := &Pong{}
+	sql := gorn.NewSql().
+		Select(result)
+	row := h.QueryRow(ctx, sql)
+	return h.ScanRow(row, result)
+}
+
+// 디비에 적용한 마지막 마이그레이션 번호를 가져옵니다.
+// 적용한 마이그레이션이 없다면 0을, 기록 테이블이 없다면 에러를 반환합니다.
+func (h *HealthDB) GetMigrationVersion(ctx context.Context) (int64, error) {
+	type Version struct {
+		Version int64 `rnsql:"COALESCE(MAX(version), 0)"`
+	}
+	result := &Version{}
+	sql := gorn.NewSql().
+		Select(result).
+		From(dbmodel.SchemaMigrationsTable)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Version, nil
+}
+
+// 새로운 디비 객체를 연결합니다.
+func NewHealth(db *gorn.DB) HealthDatabase {
+	return &HealthDB{
+		DB: db,
+	}
+}
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/thak1411/gorn"
+)
+
+// gorn 디비 객체가 사용하는 database/sql 커넥션 풀을 가져옵니다.
+// 커넥션 풀 상태(sql.DBStats)처럼 gorn이 제공하지 않는 기능에만 사용해야 하며,
+// 디비에 연결하기 전이라면 에러를 반환합니다.
+func SqlDB(db *gorn.DB) (*sql.DB, error) {
+	sqlDB := db.SqlDB()
+	if sqlDB == nil {
+		return nil, errors.New("gorn.DB is not opened")
+	}
+	return sqlDB, nil
+}
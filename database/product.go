@@ -3,11 +3,12 @@ package database
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/logger"
 	"github.com/thak1411/gorn"
-	"github.com/thak1411/rnlog"
 )
 
 // 상품 디비의 인터페이스 입니다.
@@ -15,10 +16,13 @@ type ProductDatabase interface {
 	ExecTx(ctx context.Context, fn func(txdb ProductDatabase) error) error
 	AddProduct(ctx context.Context, product *dbmodel.Product) (int64, error)
 	DeleteAllProducts(ctx context.Context) error
-	GetPublicProduct(ctx context.Context, productId int64) (*dbmodel.PublicProduct, error)
-	GetProducts(ctx context.Context, page, pagesize, categoryId int64) ([]*dbmodel.PublicProduct, error)
-	GetProductsCount(ctx context.Context, categoryId int64) (int64, error)
+	GetPublicProduct(ctx context.Context, productId, userId int64) (*dbmodel.PublicProduct, error)
+	GetProducts(ctx context.Context, page, pagesize, categoryId, vehicleId int64, keyword string, userId int64) ([]*dbmodel.PublicProduct, error)
+	GetProductsCount(ctx context.Context, categoryId, vehicleId int64, keyword string) (int64, error)
 	CheckProductExists(ctx context.Context, productId int64) (bool, error)
+	GetProductIds(ctx context.Context) ([]int64, error)
+	GetProduct(ctx context.Context, productId int64) (*dbmodel.Product, error)
+	GetProductsByIds(ctx context.Context, productIds []int64) ([]*dbmodel.Product, error)
 	AddBrand(ctx context.Context, brand *dbmodel.Brand) (int64, error)
 	DeleteAllBrands(ctx context.Context) error
 	AddCategory(ctx context.Context, category *dbmodel.Category) (int64, error)
@@ -27,30 +31,130 @@ type ProductDatabase interface {
 	DeleteAllProductCategoryMap(ctx context.Context) error
 	GetCartProducts(ctx context.Context, userId int64) ([]*dbmodel.PublicCart, error)
 	AddCart(ctx context.Context, cart *dbmodel.Cart) error
-	CheckCartHasProduct(ctx context.Context, userId, productId int64) (bool, error)
-	GetCartProduct(ctx context.Context, userId, productId int64) (*dbmodel.Cart, error)
+	CheckCartHasProduct(ctx context.Context, userId, productId, skuId int64) (bool, error)
+	GetCartProduct(ctx context.Context, userId, productId, skuId int64) (*dbmodel.Cart, error)
 	UpdateCart(ctx context.Context, cart *dbmodel.Cart) error
-	DeleteCartProduct(ctx context.Context, userId, productId int64) error
+	DeleteCartProduct(ctx context.Context, userId, productId, skuId int64) error
+	DeleteCartProducts(ctx context.Context, userId int64, items []*dbmodel.CartItem) error
+	DeleteCart(ctx context.Context, userId int64) error
+	GetGuestCartProducts(ctx context.Context, guestId string) ([]*dbmodel.PublicCart, error)
+	AddGuestCart(ctx context.Context, cart *dbmodel.GuestCart) error
+	CheckGuestCartHasProduct(ctx context.Context, guestId string, productId, skuId int64) (bool, error)
+	GetGuestCartProduct(ctx context.Context, guestId string, productId, skuId int64) (*dbmodel.GuestCart, error)
+	GetGuestCarts(ctx context.Context, guestId string) ([]*dbmodel.GuestCart, error)
+	UpdateGuestCart(ctx context.Context, cart *dbmodel.GuestCart) error
+	DeleteGuestCartProduct(ctx context.Context, guestId string, productId, skuId int64) error
+	DeleteGuestCartProducts(ctx context.Context, guestId string, items []*dbmodel.CartItem) error
+	DeleteGuestCart(ctx context.Context, guestId string) error
 	AddReview(ctx context.Context, review *dbmodel.Review) (int64, error)
 	CheckReviewExists(ctx context.Context, reviewId int64) (bool, error)
 	GetReviewList(ctx context.Context, productId int64) ([]*dbmodel.PublicReview, error)
 	DeleteAllReviews(ctx context.Context) error
+	GetReviewScoreHistogram(ctx context.Context, productId int64) ([]*dbmodel.ReviewScoreCount, error)
+	GetReviewScoreSummary(ctx context.Context, productId int64) (*dbmodel.ReviewScoreSummary, error)
+	GetReviewScoreSummaryBetween(ctx context.Context, productId int64, from, to time.Time) (*dbmodel.ReviewScoreSummary, error)
 	AddProductStatistics(ctx context.Context, productStat *dbmodel.ProductStatistics) error
+	CheckProductStatisticsExists(ctx context.Context, productId int64) (bool, error)
 	GetProductStatistics(ctx context.Context, productId int64) (*dbmodel.ProductStatistics, error)
 	UpdateProductStatistics(ctx context.Context, productStat *dbmodel.ProductStatistics) error
 	DeleteAllProductStatistics(ctx context.Context) error
+	GetWishlist(ctx context.Context, userId int64) ([]*dbmodel.PublicWishlist, error)
+	AddWishlist(ctx context.Context, wishlist *dbmodel.Wishlist) error
+	CheckWishlistHasProduct(ctx context.Context, userId, productId int64) (bool, error)
+	DeleteWishlistProduct(ctx context.Context, userId, productId int64) error
+	GetWishlistCount(ctx context.Context, productId int64) (int64, error)
 	GetAllCategories(ctx context.Context) ([]*dbmodel.Category, error)
 	AddPbvOption(ctx context.Context, pbvOption *dbmodel.PbvOption) (int64, error)
-	CheckPbvOptionExists(ctx context.Context, userId int64) (bool, error)
-	GetPbvOption(ctx context.Context, userId int64) (*dbmodel.PbvOption, error)
+	CheckPbvOptionExists(ctx context.Context, userId, optionId int64) (bool, error)
+	GetPbvOptionsCount(ctx context.Context, userId int64) (int64, error)
+	GetLatestPbvOptionId(ctx context.Context, userId int64) (int64, error)
+	GetPbvOption(ctx context.Context, userId, optionId int64) (*dbmodel.PbvOption, error)
+	GetPbvOptions(ctx context.Context, userId int64) ([]*dbmodel.PublicPbvOption, error)
 	UpdatePbvOption(ctx context.Context, pbvOption *dbmodel.PbvOption) error
-	DeletePbvOption(ctx context.Context, userId int64) error
+	DeletePbvOption(ctx context.Context, optionId int64) error
+	AddPbvOptionVersion(ctx context.Context, version *dbmodel.PbvOptionVersion) (int64, error)
+	CheckPbvOptionVersionExists(ctx context.Context, optionId, version int64) (bool, error)
+	GetPbvOptionVersion(ctx context.Context, optionId, version int64) (*dbmodel.PbvOptionVersion, error)
+	GetPbvOptionVersions(ctx context.Context, optionId int64) ([]*dbmodel.PublicPbvOptionVersion, error)
+	DeletePbvOptionVersions(ctx context.Context, optionId int64) error
+	GetPbvOptionById(ctx context.Context, optionId int64) (*dbmodel.PbvOption, error)
+	AddPbvShare(ctx context.Context, share *dbmodel.PbvShare) (int64, error)
+	CheckPbvShareExists(ctx context.Context, optionId int64) (bool, error)
+	GetPbvShare(ctx context.Context, optionId int64) (*dbmodel.PbvShare, error)
+	CheckPbvShareTokenExists(ctx context.Context, token string) (bool, error)
+	GetPbvShareByToken(ctx context.Context, token string) (*dbmodel.PbvShare, error)
+	IncreasePbvShareViewCount(ctx context.Context, token string) error
+	DeletePbvShare(ctx context.Context, optionId int64) error
 	GetBrandsByUser(ctx context.Context, userId int64) ([]*dbmodel.Brand, error)
+	CheckBrandExists(ctx context.Context, brandId int64) (bool, error)
+	CheckBrandOwner(ctx context.Context, userId, brandId int64) (bool, error)
+	CheckCategoryExists(ctx context.Context, categoryId int64) (bool, error)
+	AddCoupon(ctx context.Context, coupon *dbmodel.Coupon) (int64, error)
+	CheckCouponCodeExists(ctx context.Context, code string) (bool, error)
+	GetCouponByCode(ctx context.Context, code string) (*dbmodel.Coupon, error)
+	GetCouponByCodeForUpdate(ctx context.Context, code string) (*dbmodel.Coupon, error)
+	IncreaseCouponUsedCount(ctx context.Context, couponId int64) (bool, error)
+	GetCouponRedemptionCount(ctx context.Context, couponId, userId int64) (int64, error)
+	GetCouponRedemptionCountForUpdate(ctx context.Context, couponId, userId int64) (int64, error)
+	AddCouponRedemption(ctx context.Context, redemption *dbmodel.CouponRedemption) error
+	AddProductDiscount(ctx context.Context, discount *dbmodel.ProductDiscount) (int64, error)
+	GetActiveProductDiscounts(ctx context.Context, productIds []int64, at time.Time) ([]*dbmodel.ProductDiscount, error)
+	DecreaseProductAmount(ctx context.Context, productId, amount int64) (bool, error)
+	AddOrder(ctx context.Context, order *dbmodel.Order) (int64, error)
+	AddOrderItem(ctx context.Context, item *dbmodel.OrderItem) error
+	GetOrders(ctx context.Context, userId int64) ([]*dbmodel.PublicOrder, error)
+	GetSoldQuantity(ctx context.Context, productId int64) (int64, error)
+	AddProductVariant(ctx context.Context, variant *dbmodel.ProductVariant) (int64, error)
+	AddProductVariantValue(ctx context.Context, value *dbmodel.ProductVariantValue) (int64, error)
+	GetProductVariants(ctx context.Context, productId int64) ([]*dbmodel.ProductVariant, error)
+	GetProductVariantValues(ctx context.Context, productId int64) ([]*dbmodel.ProductVariantValue, error)
+	CheckProductVariantExists(ctx context.Context, productId int64, name string) (bool, error)
+	AddProductSku(ctx context.Context, sku *dbmodel.ProductSku) (int64, error)
+	AddProductSkuValue(ctx context.Context, skuValue *dbmodel.ProductSkuValue) error
+	CheckProductSkuExists(ctx context.Context, productId, skuId int64) (bool, error)
+	CheckProductSkuCodeExists(ctx context.Context, code string) (bool, error)
+	GetProductSku(ctx context.Context, skuId int64) (*dbmodel.ProductSku, error)
+	GetProductSkus(ctx context.Context, productId int64) ([]*dbmodel.ProductSku, error)
+	GetProductSkuValues(ctx context.Context, productId int64) ([]*dbmodel.ProductSkuValue, error)
+	GetProductSkuCount(ctx context.Context, productId int64) (int64, error)
+	GetProductIdsWithSkus(ctx context.Context, productIds []int64) ([]int64, error)
+	UpdateProductSku(ctx context.Context, sku *dbmodel.ProductSku) error
+	DecreaseProductSkuAmount(ctx context.Context, skuId, amount int64) (bool, error)
+	AddVehicleModel(ctx context.Context, vehicle *dbmodel.VehicleModel) (int64, error)
+	CheckVehicleModelExists(ctx context.Context, vehicleId int64) (bool, error)
+	CheckVehicleModelNameExists(ctx context.Context, maker, model string, yearFrom int64) (bool, error)
+	GetVehicleModels(ctx context.Context, maker string) ([]*dbmodel.PublicVehicleModel, error)
+	GetProductVehicles(ctx context.Context, productId int64) ([]*dbmodel.PublicVehicleModel, error)
+	CheckProductVehicleExists(ctx context.Context, productId, vehicleId int64) (bool, error)
+	AddProductVehicle(ctx context.Context, productVehicleMap *dbmodel.ProductVehicleMap) error
+	DeleteProductVehicle(ctx context.Context, productId, vehicleId int64) error
+	GetUserVehicles(ctx context.Context, userId int64) ([]*dbmodel.PublicUserVehicle, error)
+	GetUserVehicleCount(ctx context.Context, userId int64) (int64, error)
+	CheckUserVehicleExists(ctx context.Context, userId, vehicleId int64) (bool, error)
+	AddUserVehicle(ctx context.Context, userVehicle *dbmodel.UserVehicle) error
+	DeleteUserVehicle(ctx context.Context, userId, vehicleId int64) error
+	GetProductIdsFittingUser(ctx context.Context, productIds []int64, userId int64) ([]int64, error)
+	AddProductImage(ctx context.Context, image *dbmodel.ProductImage) (int64, error)
+	CheckProductImageExists(ctx context.Context, productId, imageId int64) (bool, error)
+	GetProductImage(ctx context.Context, imageId int64) (*dbmodel.ProductImage, error)
+	GetProductImages(ctx context.Context, productId int64) ([]*dbmodel.ProductImage, error)
+	GetProductImageCount(ctx context.Context, productId int64) (int64, error)
+	UpdateProductImagePosition(ctx context.Context, imageId, position int64) error
+	DeleteProductImage(ctx context.Context, imageId int64) error
+	UpdateProductTitleImage(ctx context.Context, productId int64, titleImage string) error
+	CheckProductDescriptionExists(ctx context.Context, productId int64) (bool, error)
+	GetProductDescription(ctx context.Context, productId int64) (*dbmodel.ProductDescription, error)
+	AddProductDescription(ctx context.Context, description *dbmodel.ProductDescription) error
+	UpdateProductDescription(ctx context.Context, description *dbmodel.ProductDescription) error
+	AddProductDescriptionRevision(ctx context.Context, revision *dbmodel.ProductDescriptionRevision) error
+	CheckProductDescriptionRevisionExists(ctx context.Context, productId, revision int64) (bool, error)
+	GetProductDescriptionRevision(ctx context.Context, productId, revision int64) (*dbmodel.ProductDescriptionRevision, error)
+	GetProductDescriptionRevisions(ctx context.Context, productId int64) ([]*dbmodel.PublicProductDescriptionRevision, error)
 }
 
 // 상품 디비의 구현체입니다.
 type ProductDB struct {
-	*gorn.DB
+	*tracedDB
 }
 
 // 넘겨받은 함수로 트랜잭션을 실행합니다.
@@ -60,12 +164,12 @@ func (h *ProductDB) ExecTx(ctx context.Context, fn func(txdb ProductDatabase) er
 		return err
 	}
 	newHandler := &ProductDB{
-		DB: txdb,
+		tracedDB: &tracedDB{txdb},
 	}
 	err = fn(newHandler)
 	if err != nil {
 		if rbErr := txdb.RollbackTx(); rbErr != nil {
-			rnlog.Error("Rollback error: %v", rbErr)
+			logger.Error(ctx, "Rollback error: %v", rbErr)
 			return rbErr
 		}
 		return err
@@ -98,7 +202,8 @@ func (h *ProductDB) DeleteAllProducts(ctx context.Context) error {
 }
 
 // 개별 상품 정보를 가져옵니다.
-func (h *ProductDB) GetPublicProduct(ctx context.Context, productId int64) (*dbmodel.PublicProduct, error) {
+// userId로 찜 여부를 확인하며, 게스트라면 어떤 찜과도 맞지 않는 아이디를 넘겨주면 됩니다.
+func (h *ProductDB) GetPublicProduct(ctx context.Context, productId, userId int64) (*dbmodel.PublicProduct, error) {
 	result := &dbmodel.PublicProduct{}
 	sql := gorn.NewSql().
 		Select(result).
@@ -109,6 +214,10 @@ func (h *ProductDB) GetPublicProduct(ctx context.Context, productId int64) (*dbm
 		On("PRODUCT_CATEGORY_MAP.product_id = PRODUCT.id").
 		InnerJoin("CATEGORY").
 		On("PRODUCT_CATEGORY_MAP.category_id = CATEGORY.id").
+		LeftJoin("PRODUCT_STATISTICS").
+		On("PRODUCT_STATISTICS.product_id = PRODUCT.id").
+		LeftJoin("WISHLIST").
+		On("WISHLIST.product_id = PRODUCT.id AND WISHLIST.user_id = ?", userId).
 		Where("PRODUCT.id = ?", productId).
 		AddPlainQuery("GROUP BY PRODUCT.id")
 	row := h.QueryRow(ctx, sql)
@@ -118,8 +227,30 @@ func (h *ProductDB) GetPublicProduct(ctx context.Context, productId int64) (*dbm
 	return result, nil
 }
 
+// 상품 목록의 차종, 검색어 조건을 WHERE 절에 들어갈 조건과 파라미터로 만들어줍니다.
+// 검색어는 상품 이름과 상세 설명의 검색용 글자에서 찾습니다.
+// 조건이 없다면 빈 문자열을 반환합니다.
+func makeProductListCondition(vehicleId int64, keyword string) (string, []interface{}) {
+	conditions := []string{}
+	params := []interface{}{}
+	if vehicleId != 0 {
+		conditions = append(conditions, "PRODUCT.id IN (SELECT product_id FROM PRODUCT_VEHICLE_MAP WHERE vehicle_id = ?)")
+		params = append(params, vehicleId)
+	}
+	if keyword != "" {
+		// LIKE의 와일드카드 문자는 검색어 그대로 찾도록 이스케이프합니다.
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(keyword) + "%"
+		conditions = append(conditions, "(PRODUCT.name LIKE ? OR PRODUCT.id IN (SELECT product_id FROM PRODUCT_DESCRIPTION WHERE search_text LIKE ?))")
+		params = append(params, pattern, pattern)
+	}
+	return strings.Join(conditions, " AND "), params
+}
+
 // 상품 목록을 가져옵니다.
-func (h *ProductDB) GetProducts(ctx context.Context, page, pagesize, categoryId int64) ([]*dbmodel.PublicProduct, error) {
+// userId로 찜 여부를 확인합니다.
+// vehicleId가 0이 아니라면 해당 차종에 장착할 수 있는 상품만 가져옵니다.
+// keyword가 비어있지 않다면 이름이나 상세 설명에 검색어가 들어간 상품만 가져옵니다.
+func (h *ProductDB) GetProducts(ctx context.Context, page, pagesize, categoryId, vehicleId int64, keyword string, userId int64) ([]*dbmodel.PublicProduct, error) {
 	result := []*dbmodel.PublicProduct{}
 	sql := gorn.NewSql().
 		Select(&dbmodel.PublicProduct{}).
@@ -130,7 +261,14 @@ func (h *ProductDB) GetProducts(ctx context.Context, page, pagesize, categoryId
 		On("PRODUCT_CATEGORY_MAP.product_id = PRODUCT.id").
 		InnerJoin("CATEGORY").
 		On("PRODUCT_CATEGORY_MAP.category_id = CATEGORY.id").
-		GroupBy("PRODUCT.id")
+		LeftJoin("PRODUCT_STATISTICS").
+		On("PRODUCT_STATISTICS.product_id = PRODUCT.id").
+		LeftJoin("WISHLIST").
+		On("WISHLIST.product_id = PRODUCT.id AND WISHLIST.user_id = ?", userId)
+	if condition, params := makeProductListCondition(vehicleId, keyword); condition != "" {
+		sql.Where(condition, params...)
+	}
+	sql.GroupBy("PRODUCT.id")
 	if categoryId != 0 {
 		sql.Having("GROUP_CONCAT(CATEGORY.id) LIKE ?", fmt.Sprintf("%%%d%%", categoryId))
 	}
@@ -149,7 +287,8 @@ func (h *ProductDB) GetProducts(ctx context.Context, page, pagesize, categoryId
 }
 
 // 상품 개수를 가져옵니다.
-func (h *ProductDB) GetProductsCount(ctx context.Context, categoryId int64) (int64, error) {
+// GetProducts와 같은 조건으로 셉니다.
+func (h *ProductDB) GetProductsCount(ctx context.Context, categoryId, vehicleId int64, keyword string) (int64, error) {
 	type ProductsCount struct {
 		Count int64 `rnsql:"COUNT(t.id)"`
 	}
@@ -161,8 +300,13 @@ func (h *ProductDB) GetProductsCount(ctx context.Context, categoryId int64) (int
 		sql.InnerJoin("PRODUCT_CATEGORY_MAP").
 			On("PRODUCT_CATEGORY_MAP.product_id = PRODUCT.id").
 			InnerJoin("CATEGORY").
-			On("PRODUCT_CATEGORY_MAP.category_id = CATEGORY.id").
-			GroupBy("PRODUCT.id").
+			On("PRODUCT_CATEGORY_MAP.category_id = CATEGORY.id")
+	}
+	if condition, params := makeProductListCondition(vehicleId, keyword); condition != "" {
+		sql.Where(condition, params...)
+	}
+	if categoryId != 0 {
+		sql.GroupBy("PRODUCT.id").
 			Having("GROUP_CONCAT(CATEGORY.id) LIKE ?", fmt.Sprintf("%%%d%%", categoryId))
 	}
 	tsql := gorn.NewSql().
@@ -192,6 +336,88 @@ func (h *ProductDB) CheckProductExists(ctx context.Context, productId int64) (bo
 	return result.Count > 0, nil
 }
 
+// 모든 상품의 아이디를 가져옵니다.
+func (h *ProductDB) GetProductIds(ctx context.Context) ([]int64, error) {
+	type ProductId struct {
+		Id int64 `rnsql:"id"`
+	}
+	ids := []*ProductId{}
+	sql := gorn.NewSql().
+		Select(&ProductId{}).
+		From("PRODUCT").
+		OrderBy("id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &ids); err != nil {
+		return nil, err
+	}
+	result := make([]int64, 0, len(ids))
+	for _, v := range ids {
+		result = append(result, v.Id)
+	}
+	return result, nil
+}
+
+// 아이디 목록으로 IN 절에 들어갈 "(?, ?, ...)"와 파라미터를 만들어줍니다.
+// 빈 목록은 올바른 쿼리가 아니므로 호출하기 전에 걸러야 합니다.
+func makeInClause(ids []int64) (string, []interface{}) {
+	params := make([]interface{}, 0, len(ids))
+	for _, v := range ids {
+		params = append(params, v)
+	}
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + ")", params
+}
+
+// 장바구니 줄 목록으로 "((?, ?), (?, ?), ...)" 형식의 IN 절과 파라미터를 만들어줍니다.
+// (product_id, sku_id) IN 뒤에 붙여서 사용합니다.
+func makeCartItemInClause(items []*dbmodel.CartItem) (string, []interface{}) {
+	params := make([]interface{}, 0, len(items)*2)
+	for _, v := range items {
+		params = append(params, v.ProductId, v.SkuId)
+	}
+	return "(" + strings.TrimSuffix(strings.Repeat("(?, ?), ", len(items)), ", ") + ")", params
+}
+
+// 상품 정보를 가져옵니다.
+func (h *ProductDB) GetProduct(ctx context.Context, productId int64) (*dbmodel.Product, error) {
+	result := &dbmodel.Product{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT").
+		Where("id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 아이디 목록에 해당하는 상품들을 가져옵니다.
+// 존재하지 않는 아이디는 결과에 포함되지 않습니다.
+func (h *ProductDB) GetProductsByIds(ctx context.Context, productIds []int64) ([]*dbmodel.Product, error) {
+	result := []*dbmodel.Product{}
+	if len(productIds) == 0 {
+		return result, nil
+	}
+	in, params := makeInClause(productIds)
+	sql := gorn.NewSql().
+		Select(&dbmodel.Product{}).
+		From("PRODUCT").
+		Where("id IN "+in, params...)
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // 새로운 브랜드를 추가합니다.
 // 이후 추가된 브랜드 아이디를 반환합니다.
 func (h *ProductDB) AddBrand(ctx context.Context, brand *dbmodel.Brand) (int64, error) {
@@ -273,6 +499,8 @@ func (h *ProductDB) GetCartProducts(ctx context.Context, userId int64) ([]*dbmod
 		From("CART").
 		InnerJoin("PRODUCT").
 		On("CART.product_id = PRODUCT.id").
+		LeftJoin("PRODUCT_SKU").
+		On("CART.sku_id = PRODUCT_SKU.id").
 		InnerJoin("BRAND").
 		On("PRODUCT.brand_id = BRAND.id").
 		InnerJoin("PRODUCT_CATEGORY_MAP").
@@ -280,7 +508,7 @@ func (h *ProductDB) GetCartProducts(ctx context.Context, userId int64) ([]*dbmod
 		InnerJoin("CATEGORY").
 		On("PRODUCT_CATEGORY_MAP.category_id = CATEGORY.id").
 		Where("CART.user_id = ?", userId).
-		AddPlainQuery("GROUP BY PRODUCT.id").
+		AddPlainQuery("GROUP BY CART.id").
 		OrderBy("CART.id").ASC()
 	rows, err := h.Query(ctx, sql)
 	if err != nil {
@@ -293,8 +521,9 @@ func (h *ProductDB) GetCartProducts(ctx context.Context, userId int64) ([]*dbmod
 	return result, nil
 }
 
-// 장바구니에 상품이 담겨있는지 확인합니다.
-func (h *ProductDB) CheckCartHasProduct(ctx context.Context, userId, productId int64) (bool, error) {
+// 장바구니에 상품(SKU)이 담겨있는지 확인합니다.
+// SKU가 없는 상품이라면 skuId는 0입니다.
+func (h *ProductDB) CheckCartHasProduct(ctx context.Context, userId, productId, skuId int64) (bool, error) {
 	type CartCount struct {
 		Count int `rnsql:"COUNT(*)"`
 	}
@@ -303,7 +532,8 @@ func (h *ProductDB) CheckCartHasProduct(ctx context.Context, userId, productId i
 		Select(result).
 		From("CART").
 		Where("user_id = ?", userId).
-		And("product_id = ?", productId)
+		And("product_id = ?", productId).
+		And("sku_id = ?", skuId)
 	row := h.QueryRow(ctx, sql)
 	if err := h.ScanRow(row, result); err != nil {
 		return false, err
@@ -311,14 +541,15 @@ func (h *ProductDB) CheckCartHasProduct(ctx context.Context, userId, productId i
 	return result.Count > 0, nil
 }
 
-// 장바구니에 담긴 단일 상품 정보를 가져옵니다.
-func (h *ProductDB) GetCartProduct(ctx context.Context, userId, productId int64) (*dbmodel.Cart, error) {
+// 장바구니에 담긴 단일 상품(SKU) 정보를 가져옵니다.
+func (h *ProductDB) GetCartProduct(ctx context.Context, userId, productId, skuId int64) (*dbmodel.Cart, error) {
 	result := &dbmodel.Cart{}
 	sql := gorn.NewSql().
 		Select(result).
 		From("CART").
 		Where("user_id = ?", userId).
-		And("product_id = ?", productId)
+		And("product_id = ?", productId).
+		And("sku_id = ?", skuId)
 	row := h.QueryRow(ctx, sql)
 	if err := h.ScanRow(row, result); err != nil {
 		return nil, err
@@ -333,7 +564,8 @@ func (h *ProductDB) UpdateCart(ctx context.Context, cart *dbmodel.Cart) error {
 	sql := gorn.NewSql().
 		Update("CART", cart).
 		Where("user_id = ?", cart.UserId).
-		And("product_id = ?", cart.ProductId)
+		And("product_id = ?", cart.ProductId).
+		And("sku_id = ?", cart.SkuId)
 	res, err := h.Exec(ctx, sql)
 	if err != nil {
 		return err
@@ -344,12 +576,215 @@ func (h *ProductDB) UpdateCart(ctx context.Context, cart *dbmodel.Cart) error {
 	return nil
 }
 
-// 장바구니에서 상품을 삭제합니다.
-func (h *ProductDB) DeleteCartProduct(ctx context.Context, userId, productId int64) error {
+// 장바구니에서 상품(SKU)을 삭제합니다.
+func (h *ProductDB) DeleteCartProduct(ctx context.Context, userId, productId, skuId int64) error {
 	sql := gorn.NewSql().
 		DeleteFrom("CART").
 		Where("user_id = ?", userId).
-		And("product_id = ?", productId)
+		And("product_id = ?", productId).
+		And("sku_id = ?", skuId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 장바구니에서 여러 줄을 한 번에 삭제합니다.
+// 상품 아이디와 SKU 아이디가 모두 같은 줄만 삭제합니다.
+func (h *ProductDB) DeleteCartProducts(ctx context.Context, userId int64, items []*dbmodel.CartItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	in, params := makeCartItemInClause(items)
+	sql := gorn.NewSql().
+		DeleteFrom("CART").
+		Where("user_id = ?", userId).
+		And("(product_id, sku_id) IN "+in, params...)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 장바구니를 모두 비웁니다.
+func (h *ProductDB) DeleteCart(ctx context.Context, userId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("CART").
+		Where("user_id = ?", userId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 게스트 장바구니에 담긴 상품 리스트를 가져옵니다.
+// 유저 장바구니와 같은 형태로 보여주기 위해 GUEST_CART를 CART라는 이름으로 조회합니다.
+func (h *ProductDB) GetGuestCartProducts(ctx context.Context, guestId string) ([]*dbmodel.PublicCart, error) {
+	result := []*dbmodel.PublicCart{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicCart{}).
+		From("GUEST_CART").As("CART").
+		InnerJoin("PRODUCT").
+		On("CART.product_id = PRODUCT.id").
+		LeftJoin("PRODUCT_SKU").
+		On("CART.sku_id = PRODUCT_SKU.id").
+		InnerJoin("BRAND").
+		On("PRODUCT.brand_id = BRAND.id").
+		InnerJoin("PRODUCT_CATEGORY_MAP").
+		On("PRODUCT_CATEGORY_MAP.product_id = PRODUCT.id").
+		InnerJoin("CATEGORY").
+		On("PRODUCT_CATEGORY_MAP.category_id = CATEGORY.id").
+		Where("CART.guest_id = ?", guestId).
+		AddPlainQuery("GROUP BY CART.id").
+		OrderBy("CART.id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 게스트 장바구니에 상품을 추가합니다.
+func (h *ProductDB) AddGuestCart(ctx context.Context, cart *dbmodel.GuestCart) error {
+	ntime := time.Now()
+	cart.CreatedTime = ntime
+	cart.UpdatedTime = ntime
+	return h.Insert(ctx, "GUEST_CART", cart)
+}
+
+// 게스트 장바구니에 상품(SKU)이 담겨있는지 확인합니다.
+// SKU가 없는 상품이라면 skuId는 0입니다.
+func (h *ProductDB) CheckGuestCartHasProduct(ctx context.Context, guestId string, productId, skuId int64) (bool, error) {
+	type CartCount struct {
+		Count int `rnsql:"COUNT(*)"`
+	}
+	result := &CartCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("GUEST_CART").
+		Where("guest_id = ?", guestId).
+		And("product_id = ?", productId).
+		And("sku_id = ?", skuId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 게스트 장바구니에 담긴 단일 상품(SKU) 정보를 가져옵니다.
+func (h *ProductDB) GetGuestCartProduct(ctx context.Context, guestId string, productId, skuId int64) (*dbmodel.GuestCart, error) {
+	result := &dbmodel.GuestCart{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("GUEST_CART").
+		Where("guest_id = ?", guestId).
+		And("product_id = ?", productId).
+		And("sku_id = ?", skuId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 게스트 장바구니에 담긴 상품들을 담은 순서대로 가져옵니다.
+func (h *ProductDB) GetGuestCarts(ctx context.Context, guestId string) ([]*dbmodel.GuestCart, error) {
+	result := []*dbmodel.GuestCart{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.GuestCart{}).
+		From("GUEST_CART").
+		Where("guest_id = ?", guestId).
+		OrderBy("id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 게스트 장바구니 정보를 업데이트합니다.
+func (h *ProductDB) UpdateGuestCart(ctx context.Context, cart *dbmodel.GuestCart) error {
+	ntime := time.Now()
+	cart.UpdatedTime = ntime
+	sql := gorn.NewSql().
+		Update("GUEST_CART", cart).
+		Where("guest_id = ?", cart.GuestId).
+		And("product_id = ?", cart.ProductId).
+		And("sku_id = ?", cart.SkuId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 게스트 장바구니에서 상품(SKU)을 삭제합니다.
+func (h *ProductDB) DeleteGuestCartProduct(ctx context.Context, guestId string, productId, skuId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("GUEST_CART").
+		Where("guest_id = ?", guestId).
+		And("product_id = ?", productId).
+		And("sku_id = ?", skuId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 게스트 장바구니에서 여러 줄을 한 번에 삭제합니다.
+// 상품 아이디와 SKU 아이디가 모두 같은 줄만 삭제합니다.
+func (h *ProductDB) DeleteGuestCartProducts(ctx context.Context, guestId string, items []*dbmodel.CartItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	in, params := makeCartItemInClause(items)
+	sql := gorn.NewSql().
+		DeleteFrom("GUEST_CART").
+		Where("guest_id = ?", guestId).
+		And("(product_id, sku_id) IN "+in, params...)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 게스트 장바구니를 모두 비웁니다.
+func (h *ProductDB) DeleteGuestCart(ctx context.Context, guestId string) error {
+	sql := gorn.NewSql().
+		DeleteFrom("GUEST_CART").
+		Where("guest_id = ?", guestId)
 	res, err := h.Exec(ctx, sql)
 	if err != nil {
 		return err
@@ -423,11 +858,79 @@ func (h *ProductDB) DeleteAllReviews(ctx context.Context) error {
 	return nil
 }
 
+// 상품에 작성된 리뷰의 별점 분포를 가져옵니다.
+// 리뷰가 없는 별점은 결과에 포함되지 않습니다.
+func (h *ProductDB) GetReviewScoreHistogram(ctx context.Context, productId int64) ([]*dbmodel.ReviewScoreCount, error) {
+	result := []*dbmodel.ReviewScoreCount{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.ReviewScoreCount{}).
+		From("REVIEW").
+		Where("REVIEW.product_id = ?", productId).
+		GroupBy("REVIEW.score").
+		OrderBy("REVIEW.score").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품에 작성된 모든 리뷰의 개수와 별점 합을 REVIEW 테이블에서 직접 계산합니다.
+func (h *ProductDB) GetReviewScoreSummary(ctx context.Context, productId int64) (*dbmodel.ReviewScoreSummary, error) {
+	result := &dbmodel.ReviewScoreSummary{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("REVIEW").
+		Where("REVIEW.product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// [from, to) 기간 동안 상품에 작성된 리뷰의 개수와 별점 합을 가져옵니다.
+func (h *ProductDB) GetReviewScoreSummaryBetween(ctx context.Context, productId int64, from, to time.Time) (*dbmodel.ReviewScoreSummary, error) {
+	result := &dbmodel.ReviewScoreSummary{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("REVIEW").
+		Where("REVIEW.product_id = ?", productId).
+		And("REVIEW.created_time >= ?", from).
+		And("REVIEW.created_time < ?", to)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // 새로운 상품 통계를 등록합니다.
 func (h *ProductDB) AddProductStatistics(ctx context.Context, productStat *dbmodel.ProductStatistics) error {
 	return h.Insert(ctx, "PRODUCT_STATISTICS", productStat)
 }
 
+// 상품 통계가 존재하는지 확인합니다.
+func (h *ProductDB) CheckProductStatisticsExists(ctx context.Context, productId int64) (bool, error) {
+	type StatisticsCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &StatisticsCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_STATISTICS").
+		Where("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
 // 상품 통계 정보를 가져옵니다.
 func (h *ProductDB) GetProductStatistics(ctx context.Context, productId int64) (*dbmodel.ProductStatistics, error) {
 	result := &dbmodel.ProductStatistics{}
@@ -472,33 +975,113 @@ func (h *ProductDB) DeleteAllProductStatistics(ctx context.Context) error {
 	return nil
 }
 
-// 모든 카테고리를 가져옵니다.
-func (h *ProductDB) GetAllCategories(ctx context.Context) ([]*dbmodel.Category, error) {
-	result := []*dbmodel.Category{}
+// 유저가 찜한 상품 리스트를 최근에 찜한 순서로 가져옵니다.
+func (h *ProductDB) GetWishlist(ctx context.Context, userId int64) ([]*dbmodel.PublicWishlist, error) {
+	result := []*dbmodel.PublicWishlist{}
 	sql := gorn.NewSql().
-		Select(&dbmodel.Category{}).
-		From("CATEGORY")
+		Select(&dbmodel.PublicWishlist{}).
+		From("WISHLIST").
+		InnerJoin("PRODUCT").
+		On("WISHLIST.product_id = PRODUCT.id").
+		InnerJoin("BRAND").
+		On("PRODUCT.brand_id = BRAND.id").
+		Where("WISHLIST.user_id = ?", userId).
+		OrderBy("WISHLIST.id").DESC()
 	rows, err := h.Query(ctx, sql)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	if err := h.ScanRows(rows, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-// 새로운 pbv 옵션을 등록합니다.
-// 이후 등록된 옵션 아이디를 반환합니다.
-func (h *ProductDB) AddPbvOption(ctx context.Context, pbvOption *dbmodel.PbvOption) (int64, error) {
-	ntime := time.Now()
-	pbvOption.CreatedTime = ntime
-	pbvOption.UpdatedTime = ntime
-	return h.InsertWithLastId(ctx, "PBV_OPTION", pbvOption)
+// 찜 목록에 상품을 추가합니다.
+func (h *ProductDB) AddWishlist(ctx context.Context, wishlist *dbmodel.Wishlist) error {
+	wishlist.CreatedTime = time.Now()
+	return h.Insert(ctx, "WISHLIST", wishlist)
 }
 
-// 유저가 pvb 옵션을 가지고 있는지 확인합니다.
-func (h *ProductDB) CheckPbvOptionExists(ctx context.Context, userId int64) (bool, error) {
+// 유저가 상품을 찜했는지 확인합니다.
+func (h *ProductDB) CheckWishlistHasProduct(ctx context.Context, userId, productId int64) (bool, error) {
+	type WishlistCount struct {
+		Count int `rnsql:"COUNT(*)"`
+	}
+	result := &WishlistCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("WISHLIST").
+		Where("user_id = ?", userId).
+		And("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 찜 목록에서 상품을 삭제합니다.
+func (h *ProductDB) DeleteWishlistProduct(ctx context.Context, userId, productId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("WISHLIST").
+		Where("user_id = ?", userId).
+		And("product_id = ?", productId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 상품을 찜한 유저 수를 WISHLIST 테이블에서 직접 셉니다.
+func (h *ProductDB) GetWishlistCount(ctx context.Context, productId int64) (int64, error) {
+	type WishlistCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &WishlistCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("WISHLIST").
+		Where("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// 모든 카테고리를 가져옵니다.
+func (h *ProductDB) GetAllCategories(ctx context.Context) ([]*dbmodel.Category, error) {
+	result := []*dbmodel.Category{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.Category{}).
+		From("CATEGORY")
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 새로운 pbv 옵션을 등록합니다.
+// 이후 등록된 옵션 아이디를 반환합니다.
+func (h *ProductDB) AddPbvOption(ctx context.Context, pbvOption *dbmodel.PbvOption) (int64, error) {
+	ntime := time.Now()
+	pbvOption.CreatedTime = ntime
+	pbvOption.UpdatedTime = ntime
+	return h.InsertWithLastId(ctx, "PBV_OPTION", pbvOption)
+}
+
+// 유저가 해당 pbv 옵션을 가지고 있는지 확인합니다.
+func (h *ProductDB) CheckPbvOptionExists(ctx context.Context, userId, optionId int64) (bool, error) {
 	type PbvOptionCount struct {
 		Count int64 `rnsql:"COUNT(*)"`
 	}
@@ -506,7 +1089,8 @@ func (h *ProductDB) CheckPbvOptionExists(ctx context.Context, userId int64) (boo
 	sql := gorn.NewSql().
 		Select(result).
 		From("PBV_OPTION").
-		Where("user_id = ?", userId)
+		Where("id = ?", optionId).
+		And("user_id = ?", userId)
 	row := h.QueryRow(ctx, sql)
 	if err := h.ScanRow(row, result); err != nil {
 		return false, err
@@ -514,13 +1098,59 @@ func (h *ProductDB) CheckPbvOptionExists(ctx context.Context, userId int64) (boo
 	return result.Count > 0, nil
 }
 
+// 유저가 가지고 있는 pbv 옵션의 개수를 가져옵니다.
+func (h *ProductDB) GetPbvOptionsCount(ctx context.Context, userId int64) (int64, error) {
+	type PbvOptionCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &PbvOptionCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_OPTION").
+		Where("user_id = ?", userId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// 유저가 가장 최근에 수정한 pbv 옵션의 아이디를 가져옵니다.
+// 옵션이 없다면 0을 반환합니다.
+func (h *ProductDB) GetLatestPbvOptionId(ctx context.Context, userId int64) (int64, error) {
+	type PbvOptionId struct {
+		Id int64 `rnsql:"id"`
+	}
+	result := []*PbvOptionId{}
+	sql := gorn.NewSql().
+		Select(&PbvOptionId{}).
+		From("PBV_OPTION").
+		Where("user_id = ?", userId).
+		OrderBy("updated_time").DESC().
+		Comma().AddPlainQuery("id").DESC().
+		Limit(1)
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Id, nil
+}
+
 // 유저가 가지고 있는 PBV 옵션을 가져옵니다.
-func (h *ProductDB) GetPbvOption(ctx context.Context, userId int64) (*dbmodel.PbvOption, error) {
+func (h *ProductDB) GetPbvOption(ctx context.Context, userId, optionId int64) (*dbmodel.PbvOption, error) {
 	result := &dbmodel.PbvOption{}
 	sql := gorn.NewSql().
 		Select(result).
 		From("PBV_OPTION").
-		Where("user_id = ?", userId)
+		Where("id = ?", optionId).
+		And("user_id = ?", userId)
 	row := h.QueryRow(ctx, sql)
 	if err := h.ScanRow(row, result); err != nil {
 		return nil, err
@@ -528,12 +1158,33 @@ func (h *ProductDB) GetPbvOption(ctx context.Context, userId int64) (*dbmodel.Pb
 	return result, nil
 }
 
+// 유저가 가지고 있는 PBV 옵션 리스트를 최근에 수정한 순서로 가져옵니다.
+func (h *ProductDB) GetPbvOptions(ctx context.Context, userId int64) ([]*dbmodel.PublicPbvOption, error) {
+	result := []*dbmodel.PublicPbvOption{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicPbvOption{}).
+		From("PBV_OPTION").
+		LeftJoin("PBV_SHARE").On("PBV_SHARE.pbv_option_id = PBV_OPTION.id").
+		Where("PBV_OPTION.user_id = ?", userId).
+		OrderBy("PBV_OPTION.updated_time").DESC().
+		Comma().AddPlainQuery("PBV_OPTION.id").DESC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // pbv 옵션을 업데이트합니다.
 func (h *ProductDB) UpdatePbvOption(ctx context.Context, pbvOption *dbmodel.PbvOption) error {
 	pbvOption.UpdatedTime = time.Now()
 	sql := gorn.NewSql().
 		Update("PBV_OPTION", pbvOption).
-		Where("user_id = ?", pbvOption.UserId)
+		Where("id = ?", pbvOption.Id)
 	res, err := h.Exec(ctx, sql)
 	if err != nil {
 		return err
@@ -545,10 +1196,201 @@ func (h *ProductDB) UpdatePbvOption(ctx context.Context, pbvOption *dbmodel.PbvO
 }
 
 // pbv 옵션을 삭제합니다.
-func (h *ProductDB) DeletePbvOption(ctx context.Context, userId int64) error {
+// 옵션에 연결된 버전 기록을 먼저 삭제해야 합니다.
+func (h *ProductDB) DeletePbvOption(ctx context.Context, optionId int64) error {
 	sql := gorn.NewSql().
 		DeleteFrom("PBV_OPTION").
-		Where("user_id = ?", userId)
+		Where("id = ?", optionId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// pbv 옵션의 새로운 버전을 기록합니다.
+// 이후 기록된 버전의 아이디를 반환합니다.
+func (h *ProductDB) AddPbvOptionVersion(ctx context.Context, version *dbmodel.PbvOptionVersion) (int64, error) {
+	version.CreatedTime = time.Now()
+	return h.InsertWithLastId(ctx, "PBV_OPTION_VERSION", version)
+}
+
+// pbv 옵션에 해당 버전이 기록되어 있는지 확인합니다.
+func (h *ProductDB) CheckPbvOptionVersionExists(ctx context.Context, optionId, version int64) (bool, error) {
+	type VersionCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &VersionCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_OPTION_VERSION").
+		Where("pbv_option_id = ?", optionId).
+		And("version = ?", version)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// pbv 옵션의 특정 버전을 가져옵니다.
+func (h *ProductDB) GetPbvOptionVersion(ctx context.Context, optionId, version int64) (*dbmodel.PbvOptionVersion, error) {
+	result := &dbmodel.PbvOptionVersion{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_OPTION_VERSION").
+		Where("pbv_option_id = ?", optionId).
+		And("version = ?", version)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// pbv 옵션의 버전 기록을 최신 버전부터 가져옵니다.
+func (h *ProductDB) GetPbvOptionVersions(ctx context.Context, optionId int64) ([]*dbmodel.PublicPbvOptionVersion, error) {
+	result := []*dbmodel.PublicPbvOptionVersion{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicPbvOptionVersion{}).
+		From("PBV_OPTION_VERSION").
+		Where("PBV_OPTION_VERSION.pbv_option_id = ?", optionId).
+		OrderBy("PBV_OPTION_VERSION.version").DESC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// pbv 옵션의 모든 버전 기록을 삭제합니다.
+func (h *ProductDB) DeletePbvOptionVersions(ctx context.Context, optionId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("PBV_OPTION_VERSION").
+		Where("pbv_option_id = ?", optionId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 주인을 확인하지 않고 pbv 옵션을 가져옵니다.
+// 공유 링크처럼 주인이 아닌 사람이 옵션을 볼 때만 사용해야 합니다.
+func (h *ProductDB) GetPbvOptionById(ctx context.Context, optionId int64) (*dbmodel.PbvOption, error) {
+	result := &dbmodel.PbvOption{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_OPTION").
+		Where("id = ?", optionId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// pbv 옵션의 공유 정보를 등록합니다.
+// 이후 등록된 공유 정보의 아이디를 반환합니다.
+func (h *ProductDB) AddPbvShare(ctx context.Context, share *dbmodel.PbvShare) (int64, error) {
+	share.CreatedTime = time.Now()
+	return h.InsertWithLastId(ctx, "PBV_SHARE", share)
+}
+
+// pbv 옵션이 공유되어 있는지 확인합니다.
+func (h *ProductDB) CheckPbvShareExists(ctx context.Context, optionId int64) (bool, error) {
+	type ShareCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &ShareCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_SHARE").
+		Where("pbv_option_id = ?", optionId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// pbv 옵션의 공유 정보를 가져옵니다.
+func (h *ProductDB) GetPbvShare(ctx context.Context, optionId int64) (*dbmodel.PbvShare, error) {
+	result := &dbmodel.PbvShare{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_SHARE").
+		Where("pbv_option_id = ?", optionId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 공유 토큰이 존재하는지 확인합니다.
+func (h *ProductDB) CheckPbvShareTokenExists(ctx context.Context, token string) (bool, error) {
+	type ShareCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &ShareCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_SHARE").
+		Where("token = ?", token)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 공유 토큰으로 공유 정보를 가져옵니다.
+func (h *ProductDB) GetPbvShareByToken(ctx context.Context, token string) (*dbmodel.PbvShare, error) {
+	result := &dbmodel.PbvShare{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PBV_SHARE").
+		Where("token = ?", token)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 공유 링크의 조회수를 1 올립니다.
+// 동시에 조회되어도 잃어버리지 않도록 디비에서 직접 더해줍니다.
+func (h *ProductDB) IncreasePbvShareViewCount(ctx context.Context, token string) error {
+	sql := gorn.NewSql().
+		AddPlainQuery("UPDATE `PBV_SHARE`").
+		Set("view_count = view_count + 1").
+		Where("token = ?", token)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// pbv 옵션의 공유 정보를 삭제합니다.
+func (h *ProductDB) DeletePbvShare(ctx context.Context, optionId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("PBV_SHARE").
+		Where("pbv_option_id = ?", optionId)
 	res, err := h.Exec(ctx, sql)
 	if err != nil {
 		return err
@@ -576,9 +1418,1013 @@ func (h *ProductDB) GetBrandsByUser(ctx context.Context, userId int64) ([]*dbmod
 	return result, nil
 }
 
+// 브랜드가 존재하는지 확인합니다.
+func (h *ProductDB) CheckBrandExists(ctx context.Context, brandId int64) (bool, error) {
+	type BrandCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &BrandCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("BRAND").
+		Where("id = ?", brandId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 유저가 브랜드의 주인인지 확인합니다.
+func (h *ProductDB) CheckBrandOwner(ctx context.Context, userId, brandId int64) (bool, error) {
+	type BrandCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &BrandCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("BRAND").
+		Where("id = ?", brandId).
+		And("user_id = ?", userId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 카테고리가 존재하는지 확인합니다.
+func (h *ProductDB) CheckCategoryExists(ctx context.Context, categoryId int64) (bool, error) {
+	type CategoryCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &CategoryCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("CATEGORY").
+		Where("id = ?", categoryId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 새로운 쿠폰을 추가합니다.
+// 이후 추가된 쿠폰 아이디를 반환합니다.
+func (h *ProductDB) AddCoupon(ctx context.Context, coupon *dbmodel.Coupon) (int64, error) {
+	ntime := time.Now()
+	coupon.CreatedTime = ntime
+	coupon.UpdatedTime = ntime
+	return h.InsertWithLastId(ctx, "COUPON", coupon)
+}
+
+// 쿠폰 코드가 존재하는지 확인합니다.
+func (h *ProductDB) CheckCouponCodeExists(ctx context.Context, code string) (bool, error) {
+	type CouponCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &CouponCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("COUPON").
+		Where("code = ?", code)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 쿠폰 코드로 쿠폰 정보를 가져옵니다.
+func (h *ProductDB) GetCouponByCode(ctx context.Context, code string) (*dbmodel.Coupon, error) {
+	result := &dbmodel.Coupon{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("COUPON").
+		Where("code = ?", code)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 쿠폰 코드로 쿠폰 정보를 가져오며, 트랜잭션이 끝날 때까지 쿠폰을 잠급니다.
+// 같은 쿠폰으로 동시에 결제하면 먼저 잠근 트랜잭션이 끝날 때까지 기다리므로, 쿠폰을 검사하고 사용하는 과정이 하나씩 실행됩니다.
+func (h *ProductDB) GetCouponByCodeForUpdate(ctx context.Context, code string) (*dbmodel.Coupon, error) {
+	result := &dbmodel.Coupon{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("COUPON").
+		Where("code = ?", code).
+		AddPlainQuery("FOR UPDATE")
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 쿠폰의 사용 횟수를 1 올립니다.
+// 동시에 결제되어도 전체 사용 횟수 제한을 넘지 않도록 디비에서 조건을 걸고 직접 더해줍니다.
+// 제한에 걸려 올리지 못했다면 false를 반환합니다.
+func (h *ProductDB) IncreaseCouponUsedCount(ctx context.Context, couponId int64) (bool, error) {
+	sql := gorn.NewSql().
+		AddPlainQuery("UPDATE `COUPON`").
+		Set("used_count = used_count + 1").
+		Where("id = ?", couponId).
+		And("(usage_limit = 0 OR used_count < usage_limit)")
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// 유저가 쿠폰을 사용한 횟수를 가져옵니다.
+func (h *ProductDB) GetCouponRedemptionCount(ctx context.Context, couponId, userId int64) (int64, error) {
+	type RedemptionCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &RedemptionCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("COUPON_REDEMPTION").
+		Where("coupon_id = ?", couponId).
+		And("user_id = ?", userId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// 유저가 쿠폰을 사용한 횟수를 잠금 읽기로 가져옵니다.
+// 트랜잭션의 스냅샷이 아닌 가장 최근에 커밋된 기록을 세므로, 먼저 끝난 결제에서 추가한 사용 기록도 셉니다.
+func (h *ProductDB) GetCouponRedemptionCountForUpdate(ctx context.Context, couponId, userId int64) (int64, error) {
+	type RedemptionCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &RedemptionCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("COUPON_REDEMPTION").
+		Where("coupon_id = ?", couponId).
+		And("user_id = ?", userId).
+		AddPlainQuery("FOR UPDATE")
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// 쿠폰 사용 기록을 추가합니다.
+func (h *ProductDB) AddCouponRedemption(ctx context.Context, redemption *dbmodel.CouponRedemption) error {
+	redemption.CreatedTime = time.Now()
+	return h.Insert(ctx, "COUPON_REDEMPTION", redemption)
+}
+
+// 새로운 상품 할인을 추가합니다.
+// 이후 추가된 상품 할인 아이디를 반환합니다.
+func (h *ProductDB) AddProductDiscount(ctx context.Context, discount *dbmodel.ProductDiscount) (int64, error) {
+	discount.CreatedTime = time.Now()
+	return h.InsertWithLastId(ctx, "PRODUCT_DISCOUNT", discount)
+}
+
+// 상품 목록에 대해 at 시점에 진행 중인 상품 할인을 모두 가져옵니다.
+func (h *ProductDB) GetActiveProductDiscounts(ctx context.Context, productIds []int64, at time.Time) ([]*dbmodel.ProductDiscount, error) {
+	result := []*dbmodel.ProductDiscount{}
+	if len(productIds) == 0 {
+		return result, nil
+	}
+	in, params := makeInClause(productIds)
+	sql := gorn.NewSql().
+		Select(&dbmodel.ProductDiscount{}).
+		From("PRODUCT_DISCOUNT").
+		Where("product_id IN "+in, params...).
+		And("start_time <= ?", at).
+		And("end_time > ?", at)
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 재고를 amount만큼 줄입니다.
+// 동시에 결제되어도 재고가 음수가 되지 않도록 디비에서 조건을 걸고 직접 빼줍니다.
+// 재고가 부족해 줄이지 못했다면 false를 반환합니다.
+func (h *ProductDB) DecreaseProductAmount(ctx context.Context, productId, amount int64) (bool, error) {
+	sql := gorn.NewSql().
+		AddPlainQuery("UPDATE `PRODUCT`").
+		Set("amount = amount - ?").
+		AddParams(amount).
+		Where("id = ?", productId).
+		And("amount >= ?", amount)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// 새로운 주문을 추가합니다.
+// 이후 추가된 주문 아이디를 반환합니다.
+func (h *ProductDB) AddOrder(ctx context.Context, order *dbmodel.Order) (int64, error) {
+	ntime := time.Now()
+	order.CreatedTime = ntime
+	order.UpdatedTime = ntime
+	return h.InsertWithLastId(ctx, "ORDERS", order)
+}
+
+// 주문에 상품을 추가합니다.
+func (h *ProductDB) AddOrderItem(ctx context.Context, item *dbmodel.OrderItem) error {
+	item.CreatedTime = time.Now()
+	return h.Insert(ctx, "ORDER_ITEM", item)
+}
+
+// 유저의 주문 리스트를 최신순으로 가져옵니다.
+func (h *ProductDB) GetOrders(ctx context.Context, userId int64) ([]*dbmodel.PublicOrder, error) {
+	result := []*dbmodel.PublicOrder{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicOrder{}).
+		From("ORDERS").
+		Where("ORDERS.user_id = ?", userId).
+		OrderBy("ORDERS.id").DESC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품이 팔린 개수를 ORDER_ITEM 테이블에서 직접 셉니다.
+func (h *ProductDB) GetSoldQuantity(ctx context.Context, productId int64) (int64, error) {
+	type SoldQuantity struct {
+		Sum int64 `rnsql:"IFNULL(SUM(amount), 0)"`
+	}
+	result := &SoldQuantity{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("ORDER_ITEM").
+		Where("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Sum, nil
+}
+
+// 상품에 새로운 옵션을 추가합니다.
+// 이후 추가된 옵션 아이디를 반환합니다.
+func (h *ProductDB) AddProductVariant(ctx context.Context, variant *dbmodel.ProductVariant) (int64, error) {
+	variant.CreatedTime = time.Now()
+	return h.InsertWithLastId(ctx, "PRODUCT_VARIANT", variant)
+}
+
+// 상품 옵션에 고를 수 있는 값을 추가합니다.
+// 이후 추가된 옵션 값 아이디를 반환합니다.
+func (h *ProductDB) AddProductVariantValue(ctx context.Context, value *dbmodel.ProductVariantValue) (int64, error) {
+	return h.InsertWithLastId(ctx, "PRODUCT_VARIANT_VALUE", value)
+}
+
+// 상품의 옵션 리스트를 보여줄 순서대로 가져옵니다.
+func (h *ProductDB) GetProductVariants(ctx context.Context, productId int64) ([]*dbmodel.ProductVariant, error) {
+	result := []*dbmodel.ProductVariant{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.ProductVariant{}).
+		From("PRODUCT_VARIANT").
+		Where("product_id = ?", productId).
+		OrderBy("position").ASC().
+		Comma().AddPlainQuery("id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 모든 옵션 값을 보여줄 순서대로 가져옵니다.
+func (h *ProductDB) GetProductVariantValues(ctx context.Context, productId int64) ([]*dbmodel.ProductVariantValue, error) {
+	result := []*dbmodel.ProductVariantValue{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.ProductVariantValue{}).
+		From("PRODUCT_VARIANT_VALUE").
+		Where("product_id = ?", productId).
+		OrderBy("position").ASC().
+		Comma().AddPlainQuery("id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품에 같은 이름의 옵션이 있는지 확인합니다.
+func (h *ProductDB) CheckProductVariantExists(ctx context.Context, productId int64, name string) (bool, error) {
+	type VariantCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &VariantCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_VARIANT").
+		Where("product_id = ?", productId).
+		And("name = ?", name)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 상품에 새로운 SKU를 추가합니다.
+// 이후 추가된 SKU 아이디를 반환합니다.
+func (h *ProductDB) AddProductSku(ctx context.Context, sku *dbmodel.ProductSku) (int64, error) {
+	ntime := time.Now()
+	sku.CreatedTime = ntime
+	sku.UpdatedTime = ntime
+	return h.InsertWithLastId(ctx, "PRODUCT_SKU", sku)
+}
+
+// SKU가 어떤 옵션 값으로 이루어져 있는지 기록합니다.
+func (h *ProductDB) AddProductSkuValue(ctx context.Context, skuValue *dbmodel.ProductSkuValue) error {
+	return h.Insert(ctx, "PRODUCT_SKU_VALUE", skuValue)
+}
+
+// 상품에 해당 SKU가 존재하는지 확인합니다.
+func (h *ProductDB) CheckProductSkuExists(ctx context.Context, productId, skuId int64) (bool, error) {
+	type SkuCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &SkuCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_SKU").
+		Where("id = ?", skuId).
+		And("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// SKU 코드가 존재하는지 확인합니다.
+func (h *ProductDB) CheckProductSkuCodeExists(ctx context.Context, code string) (bool, error) {
+	type SkuCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &SkuCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_SKU").
+		Where("code = ?", code)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// SKU 정보를 가져옵니다.
+func (h *ProductDB) GetProductSku(ctx context.Context, skuId int64) (*dbmodel.ProductSku, error) {
+	result := &dbmodel.ProductSku{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_SKU").
+		Where("id = ?", skuId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 모든 SKU를 추가된 순서대로 가져옵니다.
+func (h *ProductDB) GetProductSkus(ctx context.Context, productId int64) ([]*dbmodel.ProductSku, error) {
+	result := []*dbmodel.ProductSku{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.ProductSku{}).
+		From("PRODUCT_SKU").
+		Where("product_id = ?", productId).
+		OrderBy("id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 모든 SKU가 어떤 옵션 값으로 이루어져 있는지 가져옵니다.
+func (h *ProductDB) GetProductSkuValues(ctx context.Context, productId int64) ([]*dbmodel.ProductSkuValue, error) {
+	result := []*dbmodel.ProductSkuValue{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.ProductSkuValue{}).
+		From("PRODUCT_SKU_VALUE").
+		Where("product_id = ?", productId)
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 SKU 개수를 가져옵니다.
+func (h *ProductDB) GetProductSkuCount(ctx context.Context, productId int64) (int64, error) {
+	type SkuCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &SkuCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_SKU").
+		Where("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// 상품 목록 중 SKU가 있는 상품 아이디만 가져옵니다.
+func (h *ProductDB) GetProductIdsWithSkus(ctx context.Context, productIds []int64) ([]int64, error) {
+	type SkuProduct struct {
+		ProductId int64 `rnsql:"DISTINCT product_id"`
+	}
+	result := []int64{}
+	if len(productIds) == 0 {
+		return result, nil
+	}
+	in, params := makeInClause(productIds)
+	sql := gorn.NewSql().
+		Select(&SkuProduct{}).
+		From("PRODUCT_SKU").
+		Where("product_id IN "+in, params...)
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	products := []*SkuProduct{}
+	if err := h.ScanRows(rows, &products); err != nil {
+		return nil, err
+	}
+	for _, v := range products {
+		result = append(result, v.ProductId)
+	}
+	return result, nil
+}
+
+// SKU 정보를 업데이트합니다.
+func (h *ProductDB) UpdateProductSku(ctx context.Context, sku *dbmodel.ProductSku) error {
+	sku.UpdatedTime = time.Now()
+	sql := gorn.NewSql().
+		Update("PRODUCT_SKU", sku).
+		Where("id = ?", sku.Id)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SKU의 재고를 amount만큼 줄입니다.
+// DecreaseProductAmount와 마찬가지로 재고가 부족해 줄이지 못했다면 false를 반환합니다.
+func (h *ProductDB) DecreaseProductSkuAmount(ctx context.Context, skuId, amount int64) (bool, error) {
+	sql := gorn.NewSql().
+		AddPlainQuery("UPDATE `PRODUCT_SKU`").
+		Set("amount = amount - ?").
+		AddParams(amount).
+		Where("id = ?", skuId).
+		And("amount >= ?", amount)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// 새로운 차종을 추가합니다.
+// 이후 추가된 차종 아이디를 반환합니다.
+func (h *ProductDB) AddVehicleModel(ctx context.Context, vehicle *dbmodel.VehicleModel) (int64, error) {
+	vehicle.CreatedTime = time.Now()
+	return h.InsertWithLastId(ctx, "VEHICLE_MODEL", vehicle)
+}
+
+// 존재하는 차종인지 확인합니다.
+func (h *ProductDB) CheckVehicleModelExists(ctx context.Context, vehicleId int64) (bool, error) {
+	type VehicleCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &VehicleCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("VEHICLE_MODEL").
+		Where("id = ?", vehicleId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 같은 제조사, 모델, 시작 연식의 차종이 이미 있는지 확인합니다.
+func (h *ProductDB) CheckVehicleModelNameExists(ctx context.Context, maker, model string, yearFrom int64) (bool, error) {
+	type VehicleCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &VehicleCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("VEHICLE_MODEL").
+		Where("maker = ?", maker).
+		And("model = ?", model).
+		And("year_from = ?", yearFrom)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 차종 리스트를 가져옵니다.
+// maker가 빈 문자열이 아니라면 해당 제조사의 차종만 가져옵니다.
+func (h *ProductDB) GetVehicleModels(ctx context.Context, maker string) ([]*dbmodel.PublicVehicleModel, error) {
+	result := []*dbmodel.PublicVehicleModel{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicVehicleModel{}).
+		From("VEHICLE_MODEL")
+	if maker != "" {
+		sql.Where("VEHICLE_MODEL.maker = ?", maker)
+	}
+	sql.OrderBy("VEHICLE_MODEL.maker").ASC().
+		Comma().AddPlainQuery("VEHICLE_MODEL.model").ASC().
+		Comma().AddPlainQuery("VEHICLE_MODEL.year_from").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품을 장착할 수 있는 차종 리스트를 가져옵니다.
+func (h *ProductDB) GetProductVehicles(ctx context.Context, productId int64) ([]*dbmodel.PublicVehicleModel, error) {
+	result := []*dbmodel.PublicVehicleModel{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicVehicleModel{}).
+		From("PRODUCT_VEHICLE_MAP").
+		InnerJoin("VEHICLE_MODEL").
+		On("PRODUCT_VEHICLE_MAP.vehicle_id = VEHICLE_MODEL.id").
+		Where("PRODUCT_VEHICLE_MAP.product_id = ?", productId).
+		OrderBy("VEHICLE_MODEL.maker").ASC().
+		Comma().AddPlainQuery("VEHICLE_MODEL.model").ASC().
+		Comma().AddPlainQuery("VEHICLE_MODEL.year_from").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품이 해당 차종에 장착할 수 있다고 등록되어 있는지 확인합니다.
+func (h *ProductDB) CheckProductVehicleExists(ctx context.Context, productId, vehicleId int64) (bool, error) {
+	type FitmentCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &FitmentCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_VEHICLE_MAP").
+		Where("product_id = ?", productId).
+		And("vehicle_id = ?", vehicleId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 상품에 장착할 수 있는 차종을 추가합니다.
+func (h *ProductDB) AddProductVehicle(ctx context.Context, productVehicleMap *dbmodel.ProductVehicleMap) error {
+	return h.Insert(ctx, "PRODUCT_VEHICLE_MAP", productVehicleMap)
+}
+
+// 상품에서 장착할 수 있는 차종을 삭제합니다.
+func (h *ProductDB) DeleteProductVehicle(ctx context.Context, productId, vehicleId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("PRODUCT_VEHICLE_MAP").
+		Where("product_id = ?", productId).
+		And("vehicle_id = ?", vehicleId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 유저가 내 차고에 등록한 차량 리스트를 가져옵니다.
+func (h *ProductDB) GetUserVehicles(ctx context.Context, userId int64) ([]*dbmodel.PublicUserVehicle, error) {
+	result := []*dbmodel.PublicUserVehicle{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicUserVehicle{}).
+		From("USER_VEHICLE").
+		InnerJoin("VEHICLE_MODEL").
+		On("USER_VEHICLE.vehicle_id = VEHICLE_MODEL.id").
+		Where("USER_VEHICLE.user_id = ?", userId).
+		OrderBy("USER_VEHICLE.id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 유저가 내 차고에 등록한 차량 개수를 가져옵니다.
+func (h *ProductDB) GetUserVehicleCount(ctx context.Context, userId int64) (int64, error) {
+	type VehicleCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &VehicleCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("USER_VEHICLE").
+		Where("user_id = ?", userId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// 유저가 해당 차종을 내 차고에 등록했는지 확인합니다.
+func (h *ProductDB) CheckUserVehicleExists(ctx context.Context, userId, vehicleId int64) (bool, error) {
+	type VehicleCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &VehicleCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("USER_VEHICLE").
+		Where("user_id = ?", userId).
+		And("vehicle_id = ?", vehicleId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 내 차고에 차량을 추가합니다.
+func (h *ProductDB) AddUserVehicle(ctx context.Context, userVehicle *dbmodel.UserVehicle) error {
+	userVehicle.CreatedTime = time.Now()
+	return h.Insert(ctx, "USER_VEHICLE", userVehicle)
+}
+
+// 내 차고에서 차량을 삭제합니다.
+func (h *ProductDB) DeleteUserVehicle(ctx context.Context, userId, vehicleId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("USER_VEHICLE").
+		Where("user_id = ?", userId).
+		And("vehicle_id = ?", vehicleId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 상품 아이디 목록 중 유저가 내 차고에 등록한 차량에 장착할 수 있는 상품 아이디만 가져옵니다.
+func (h *ProductDB) GetProductIdsFittingUser(ctx context.Context, productIds []int64, userId int64) ([]int64, error) {
+	type FittingProduct struct {
+		ProductId int64 `rnsql:"DISTINCT PRODUCT_VEHICLE_MAP.product_id"`
+	}
+	result := []int64{}
+	if len(productIds) == 0 {
+		return result, nil
+	}
+	in, params := makeInClause(productIds)
+	sql := gorn.NewSql().
+		Select(&FittingProduct{}).
+		From("PRODUCT_VEHICLE_MAP").
+		InnerJoin("USER_VEHICLE").
+		On("USER_VEHICLE.vehicle_id = PRODUCT_VEHICLE_MAP.vehicle_id").
+		Where("USER_VEHICLE.user_id = ?", userId).
+		And("PRODUCT_VEHICLE_MAP.product_id IN "+in, params...)
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	products := []*FittingProduct{}
+	if err := h.ScanRows(rows, &products); err != nil {
+		return nil, err
+	}
+	for _, v := range products {
+		result = append(result, v.ProductId)
+	}
+	return result, nil
+}
+
+// 상품에 새로운 이미지를 추가합니다.
+// 이후 추가된 이미지 아이디를 반환합니다.
+func (h *ProductDB) AddProductImage(ctx context.Context, image *dbmodel.ProductImage) (int64, error) {
+	image.CreatedTime = time.Now()
+	return h.InsertWithLastId(ctx, "PRODUCT_IMAGE", image)
+}
+
+// 상품에 해당 이미지가 존재하는지 확인합니다.
+func (h *ProductDB) CheckProductImageExists(ctx context.Context, productId, imageId int64) (bool, error) {
+	type ImageCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &ImageCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_IMAGE").
+		Where("id = ?", imageId).
+		And("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 상품 이미지 정보를 가져옵니다.
+func (h *ProductDB) GetProductImage(ctx context.Context, imageId int64) (*dbmodel.ProductImage, error) {
+	result := &dbmodel.ProductImage{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_IMAGE").
+		Where("id = ?", imageId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 이미지 리스트를 보여줄 순서대로 가져옵니다.
+func (h *ProductDB) GetProductImages(ctx context.Context, productId int64) ([]*dbmodel.ProductImage, error) {
+	result := []*dbmodel.ProductImage{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.ProductImage{}).
+		From("PRODUCT_IMAGE").
+		Where("product_id = ?", productId).
+		OrderBy("position").ASC().
+		Comma().AddPlainQuery("id").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 이미지 개수를 가져옵니다.
+func (h *ProductDB) GetProductImageCount(ctx context.Context, productId int64) (int64, error) {
+	type ImageCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &ImageCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_IMAGE").
+		Where("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// 상품 이미지의 순서를 변경합니다.
+func (h *ProductDB) UpdateProductImagePosition(ctx context.Context, imageId, position int64) error {
+	sql := gorn.NewSql().
+		AddPlainQuery("UPDATE `PRODUCT_IMAGE`").
+		Set("position = ?").
+		AddParams(position).
+		Where("id = ?", imageId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 상품 이미지를 삭제합니다.
+func (h *ProductDB) DeleteProductImage(ctx context.Context, imageId int64) error {
+	sql := gorn.NewSql().
+		DeleteFrom("PRODUCT_IMAGE").
+		Where("id = ?", imageId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 상품의 대표 이미지 주소를 변경합니다.
+func (h *ProductDB) UpdateProductTitleImage(ctx context.Context, productId int64, titleImage string) error {
+	sql := gorn.NewSql().
+		AddPlainQuery("UPDATE `PRODUCT`").
+		Set("title_image_s3 = ?, updated_time = ?").
+		AddParams(titleImage, time.Now()).
+		Where("id = ?", productId)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 상품에 상세 설명이 작성되어 있는지 확인합니다.
+func (h *ProductDB) CheckProductDescriptionExists(ctx context.Context, productId int64) (bool, error) {
+	type DescriptionCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &DescriptionCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_DESCRIPTION").
+		Where("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 상품의 현재 상세 설명을 가져옵니다.
+func (h *ProductDB) GetProductDescription(ctx context.Context, productId int64) (*dbmodel.ProductDescription, error) {
+	result := &dbmodel.ProductDescription{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_DESCRIPTION").
+		Where("product_id = ?", productId)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품의 상세 설명을 처음으로 추가합니다.
+func (h *ProductDB) AddProductDescription(ctx context.Context, description *dbmodel.ProductDescription) error {
+	description.CreatedTime = time.Now()
+	description.UpdatedTime = description.CreatedTime
+	return h.Insert(ctx, "PRODUCT_DESCRIPTION", description)
+}
+
+// 상품의 상세 설명을 업데이트합니다.
+func (h *ProductDB) UpdateProductDescription(ctx context.Context, description *dbmodel.ProductDescription) error {
+	description.UpdatedTime = time.Now()
+	sql := gorn.NewSql().
+		Update("PRODUCT_DESCRIPTION", description).
+		Where("id = ?", description.Id)
+	res, err := h.Exec(ctx, sql)
+	if err != nil {
+		return err
+	}
+	if _, err := res.RowsAffected(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 상품 상세 설명의 새로운 수정 기록을 추가합니다.
+func (h *ProductDB) AddProductDescriptionRevision(ctx context.Context, revision *dbmodel.ProductDescriptionRevision) error {
+	revision.CreatedTime = time.Now()
+	return h.Insert(ctx, "PRODUCT_DESCRIPTION_REVISION", revision)
+}
+
+// 상품 상세 설명에 해당 수정 기록이 있는지 확인합니다.
+func (h *ProductDB) CheckProductDescriptionRevisionExists(ctx context.Context, productId, revision int64) (bool, error) {
+	type RevisionCount struct {
+		Count int64 `rnsql:"COUNT(*)"`
+	}
+	result := &RevisionCount{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_DESCRIPTION_REVISION").
+		Where("product_id = ?", productId).
+		And("revision = ?", revision)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return false, err
+	}
+	return result.Count > 0, nil
+}
+
+// 상품 상세 설명의 특정 수정 기록을 가져옵니다.
+func (h *ProductDB) GetProductDescriptionRevision(ctx context.Context, productId, revision int64) (*dbmodel.ProductDescriptionRevision, error) {
+	result := &dbmodel.ProductDescriptionRevision{}
+	sql := gorn.NewSql().
+		Select(result).
+		From("PRODUCT_DESCRIPTION_REVISION").
+		Where("product_id = ?", productId).
+		And("revision = ?", revision)
+	row := h.QueryRow(ctx, sql)
+	if err := h.ScanRow(row, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 상품 상세 설명의 수정 기록을 최신 기록부터 가져옵니다.
+func (h *ProductDB) GetProductDescriptionRevisions(ctx context.Context, productId int64) ([]*dbmodel.PublicProductDescriptionRevision, error) {
+	result := []*dbmodel.PublicProductDescriptionRevision{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.PublicProductDescriptionRevision{}).
+		From("PRODUCT_DESCRIPTION_REVISION").
+		Where("PRODUCT_DESCRIPTION_REVISION.product_id = ?", productId).
+		OrderBy("PRODUCT_DESCRIPTION_REVISION.revision").DESC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // 새로운 디비 객체를 연결합니다.
 func NewProduct(db *gorn.DB) ProductDatabase {
 	return &ProductDB{
-		DB: db,
+		tracedDB: &tracedDB{db},
 	}
 }
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/thak1411/gorn"
+)
+
+// 디비 스키마를 확인할 때 사용하는 디비의 인터페이스 입니다.
+// 모두 현재 연결한 스키마(DATABASE())만 조회합니다.
+type SchemaDatabase interface {
+	GetSchemaTables(ctx context.Context) ([]string, error)
+	GetSchemaColumns(ctx context.Context) ([]*dbmodel.SchemaColumn, error)
+	GetSchemaIndexColumns(ctx context.Context) ([]*dbmodel.SchemaIndexColumn, error)
+	GetSchemaForeignKeys(ctx context.Context) ([]*dbmodel.SchemaForeignKey, error)
+}
+
+// 스키마 확인 디비의 구현체입니다.
+type SchemaDB struct {
+	*gorn.DB
+}
+
+// 현재 스키마에 만들어져 있는 테이블 이름을 모두 가져옵니다.
+func (h *SchemaDB) GetSchemaTables(ctx context.Context) ([]string, error) {
+	type Table struct {
+		Name string `rnsql:"TABLE_NAME"`
+	}
+	tables := []*Table{}
+	sql := gorn.NewSql().
+		Select(&Table{}).
+		From("INFORMATION_SCHEMA.TABLES").
+		Where("TABLE_SCHEMA = DATABASE()").
+		And("TABLE_TYPE = ?", "BASE TABLE").
+		OrderBy("TABLE_NAME").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &tables); err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(tables))
+	for _, v := range tables {
+		result = append(result, v.Name)
+	}
+	return result, nil
+}
+
+// 현재 스키마의 모든 컬럼을 테이블, 컬럼 순서대로 가져옵니다.
+func (h *SchemaDB) GetSchemaColumns(ctx context.Context) ([]*dbmodel.SchemaColumn, error) {
+	result := []*dbmodel.SchemaColumn{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.SchemaColumn{}).
+		From("INFORMATION_SCHEMA.COLUMNS").
+		Where("TABLE_SCHEMA = DATABASE()").
+		OrderBy("TABLE_NAME").ASC().
+		Comma().AddPlainQuery("ORDINAL_POSITION").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 현재 스키마의 모든 인덱스 컬럼을 테이블, 인덱스, 컬럼 순서대로 가져옵니다.
+func (h *SchemaDB) GetSchemaIndexColumns(ctx context.Context) ([]*dbmodel.SchemaIndexColumn, error) {
+	result := []*dbmodel.SchemaIndexColumn{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.SchemaIndexColumn{}).
+		From("INFORMATION_SCHEMA.STATISTICS").
+		Where("TABLE_SCHEMA = DATABASE()").
+		OrderBy("TABLE_NAME").ASC().
+		Comma().AddPlainQuery("INDEX_NAME").ASC().
+		Comma().AddPlainQuery("SEQ_IN_INDEX").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 현재 스키마의 모든 외래 키 컬럼을 가져옵니다.
+func (h *SchemaDB) GetSchemaForeignKeys(ctx context.Context) ([]*dbmodel.SchemaForeignKey, error) {
+	result := []*dbmodel.SchemaForeignKey{}
+	sql := gorn.NewSql().
+		Select(&dbmodel.SchemaForeignKey{}).
+		From("INFORMATION_SCHEMA.KEY_COLUMN_USAGE").
+		Where("TABLE_SCHEMA = DATABASE()").
+		And("REFERENCED_TABLE_NAME IS NOT NULL").
+		OrderBy("TABLE_NAME").ASC().
+		Comma().AddPlainQuery("CONSTRAINT_NAME").ASC().
+		Comma().AddPlainQuery("ORDINAL_POSITION").ASC()
+	rows, err := h.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if err := h.ScanRows(rows, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 새로운 디비 객체를 연결합니다.
+func NewSchema(db *gorn.DB) SchemaDatabase {
+	return &SchemaDB{
+		DB: db,
+	}
+}
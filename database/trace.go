@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/JongGeonClass/JGC-API/tracing"
+	"github.com/thak1411/gorn"
+)
+
+// 쿼리에서 대상 테이블을 찾는 정규식입니다.
+var sqlTablePattern = regexp.MustCompile("(?i)\\b(?:FROM|INTO|UPDATE)\\s+`?([A-Za-z0-9_]+)")
+
+// 쿼리마다 스팬을 남기도록 gorn 디비 객체를 감싼 타입입니다.
+// ProductDB, UserDB가 임베딩하므로 각 메소드에서 호출하는 Exec, Query 같은 메소드가 이쪽으로 연결되며,
+// 스팬에는 쿼리 종류와 테이블, 쿼리를 실행한 디비 메소드 이름을 남깁니다.
+// 쿼리 결과를 읽는 ScanRow, ScanRows 같은 메소드는 gorn의 것을 그대로 사용합니다.
+type tracedDB struct {
+	*gorn.DB
+}
+
+// 쿼리 스팬을 시작합니다.
+// 스팬 이름은 OpenTelemetry 규칙에 따라 "쿼리 종류 테이블"(예: SELECT PRODUCT)입니다.
+// tracedDB의 메소드에서 직접 호출해야 디비 메소드 이름을 올바르게 찾을 수 있습니다.
+func (d *tracedDB) startQuery(ctx context.Context, query string) (context.Context, *tracing.Span) {
+	if !tracing.Enabled() {
+		return ctx, nil
+	}
+	operation := strings.ToUpper(strings.SplitN(strings.TrimSpace(query), " ", 2)[0])
+	table := ""
+	if m := sqlTablePattern.FindStringSubmatch(query); m != nil {
+		table = m[1]
+	}
+	name := strings.TrimSpace(operation + " " + table)
+	return tracing.Start(ctx, name, tracing.KindClient,
+		tracing.String("db.system", "mysql"),
+		tracing.String("db.operation", operation),
+		tracing.String("db.sql.table", table),
+		tracing.String("db.statement", query),
+		tracing.String("code.function", callerMethod()),
+	)
+}
+
+// 쿼리를 실행한 디비 메소드 이름을 가져옵니다. (예: ProductDB.CheckProductExists)
+func callerMethod() string {
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return ""
+	}
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.NewReplacer("database.", "", "(*", "", ")", "").Replace(name)
+}
+
+// 쿼리 스팬을 끝냅니다.
+func endQuery(span *tracing.Span, err error) {
+	span.RecordError(err)
+	span.End()
+}
+
+func (d *tracedDB) Exec(ctx context.Context, tsql *gorn.Sql) (sql.Result, error) {
+	ctx, span := d.startQuery(ctx, tsql.Query())
+	res, err := d.DB.Exec(ctx, tsql)
+	endQuery(span, err)
+	return res, err
+}
+
+func (d *tracedDB) Query(ctx context.Context, tsql *gorn.Sql) (*sql.Rows, error) {
+	ctx, span := d.startQuery(ctx, tsql.Query())
+	rows, err := d.DB.Query(ctx, tsql)
+	endQuery(span, err)
+	return rows, err
+}
+
+func (d *tracedDB) QueryRow(ctx context.Context, tsql *gorn.Sql) *sql.Row {
+	ctx, span := d.startQuery(ctx, tsql.Query())
+	row := d.DB.QueryRow(ctx, tsql)
+	if err := row.Err(); err != sql.ErrNoRows {
+		span.RecordError(err)
+	}
+	span.End()
+	return row
+}
+
+func (d *tracedDB) Insert(ctx context.Context, tableName string, table interface{}) error {
+	// 트레이싱을 사용하지 않을 때는 스팬 이름을 위해 쿼리를 한 번 더 만들지 않습니다.
+	if !tracing.Enabled() {
+		return d.DB.Insert(ctx, tableName, table)
+	}
+	ctx, span := d.startQuery(ctx, gorn.NewSql().Insert(tableName, table).Query())
+	err := d.DB.Insert(ctx, tableName, table)
+	endQuery(span, err)
+	return err
+}
+
+func (d *tracedDB) InsertWithLastId(ctx context.Context, tableName string, table interface{}) (int64, error) {
+	if !tracing.Enabled() {
+		return d.DB.InsertWithLastId(ctx, tableName, table)
+	}
+	ctx, span := d.startQuery(ctx, gorn.NewSql().Insert(tableName, table).Query())
+	id, err := d.DB.InsertWithLastId(ctx, tableName, table)
+	endQuery(span, err)
+	return id, err
+}
+
+func (d *tracedDB) Select(ctx context.Context, tableName string, table interface{}, dest interface{}) error {
+	if !tracing.Enabled() {
+		return d.DB.Select(ctx, tableName, table, dest)
+	}
+	ctx, span := d.startQuery(ctx, gorn.NewSql().Select(table).From(tableName).Query())
+	err := d.DB.Select(ctx, tableName, table, dest)
+	endQuery(span, err)
+	return err
+}
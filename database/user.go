@@ -5,8 +5,8 @@ import (
 	"time"
 
 	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/logger"
 	"github.com/thak1411/gorn"
-	"github.com/thak1411/rnlog"
 )
 
 // 유저 디비의 인터페이스 입니다.
@@ -25,7 +25,7 @@ type UserDatabase interface {
 
 // 유저 디비의 구현체입니다.
 type UserDB struct {
-	*gorn.DB
+	*tracedDB
 }
 
 // 넘겨받은 함수로 트랜잭션을 실행합니다.
@@ -35,12 +35,12 @@ func (h *UserDB) ExecTx(ctx context.Context, fn func(txdb UserDatabase) error) e
 		return err
 	}
 	newHandler := &UserDB{
-		DB: txdb,
+		tracedDB: &tracedDB{txdb},
 	}
 	err = fn(newHandler)
 	if err != nil {
 		if rbErr := txdb.RollbackTx(); rbErr != nil {
-			rnlog.Error("Rollback error: %v", rbErr)
+			logger.Error(ctx, "Rollback error: %v", rbErr)
 			return rbErr
 		}
 		return err
@@ -193,6 +193,6 @@ func (h *UserDB) GetUserByNickname(ctx context.Context, nickname string) (*dbmod
 // 새로운 디비 객체를 연결합니다.
 func NewUser(db *gorn.DB) UserDatabase {
 	return &UserDB{
-		DB: db,
+		tracedDB: &tracedDB{db},
 	}
 }
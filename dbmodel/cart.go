@@ -7,28 +7,99 @@ import (
 )
 
 // 유저에게 보여줄 장바구니 리스트에 들어갈 정보를 담은 테이블입니다.
+// Subtotal, PriceChanged, StockShortage는 디비에서 가져오지 않고 유즈케이스에서 계산합니다.
+// PriceAtAdd가 0이라면 담은 가격이 기록되기 전에 담긴 상품입니다.
+// SKU를 골라 담았다면 가격은 상품 가격에 SKU 추가 금액을 더한 값이고, 재고는 SKU 재고입니다.
 type PublicCart struct {
-	Id           int64        `rnsql:"CART.id"  json:"id"`
-	BrandId      int64        `rnsql:"BRAND.id"  json:"brand_id"`
-	BrandName    string       `rnsql:"BRAND.name"  json:"brand_name"`
-	Categories   CategoryList `rnsql:"CONCAT('[', GROUP_CONCAT('{\"id\":', CATEGORY.id, ',\"name\":\"', CATEGORY.name, '\",\"description\":\"', CATEGORY.description, '\"}'), ']')"  json:"categories"`
-	ProductName  string       `rnsql:"PRODUCT.name"  json:"product_name"`
-	ProductPrice int64        `rnsql:"PRODUCT.price"  json:"product_price"`
-	Amount       int64        `rnsql:"CART.amount"  json:"amount"`
-	TitleImageS3 string       `rnsql:"PRODUCT.title_image_s3"  json:"title_image_s3"`
-	CreatedTime  string       `rnsql:"PRODUCT.created_time"  json:"created_time"`
+	Id            int64        `rnsql:"CART.id"  json:"id"`
+	ProductId     int64        `rnsql:"PRODUCT.id"  json:"product_id"`
+	SkuId         int64        `rnsql:"CART.sku_id"  json:"sku_id"`
+	SkuCode       string       `rnsql:"IFNULL(PRODUCT_SKU.code, '')"  json:"sku_code"`
+	BrandId       int64        `rnsql:"BRAND.id"  json:"brand_id"`
+	BrandName     string       `rnsql:"BRAND.name"  json:"brand_name"`
+	Categories    CategoryList `rnsql:"CONCAT('[', GROUP_CONCAT('{\"id\":', CATEGORY.id, ',\"name\":\"', CATEGORY.name, '\",\"description\":\"', CATEGORY.description, '\"}'), ']')"  json:"categories"`
+	ProductName   string       `rnsql:"PRODUCT.name"  json:"product_name"`
+	ProductPrice  int64        `rnsql:"PRODUCT.price + IFNULL(PRODUCT_SKU.price_delta, 0)"  json:"product_price"`
+	PriceAtAdd    int64        `rnsql:"CART.price_at_add"  json:"price_at_add"`
+	Stock         int64        `rnsql:"IF(CART.sku_id = 0, PRODUCT.amount, IFNULL(PRODUCT_SKU.amount, 0))"  json:"stock"`
+	Amount        int64        `rnsql:"CART.amount"  json:"amount"`
+	TitleImageS3  string       `rnsql:"PRODUCT.title_image_s3"  json:"title_image_s3"`
+	CreatedTime   string       `rnsql:"PRODUCT.created_time"  json:"created_time"`
+	Subtotal      int64        `json:"subtotal"`
+	PriceChanged  bool         `json:"price_changed"`
+	StockShortage bool         `json:"stock_shortage"`
+}
+
+// 장바구니를 브랜드별로 묶은 배송 그룹입니다.
+// 배송비는 브랜드마다 따로 붙고, 브랜드 합계가 무료 배송 기준을 넘으면 붙지 않습니다.
+type CartShippingGroup struct {
+	BrandId     int64         `json:"brand_id"`
+	BrandName   string        `json:"brand_name"`
+	Carts       []*PublicCart `json:"carts"`
+	Subtotal    int64         `json:"subtotal"`
+	ShippingFee int64         `json:"shipping_fee"`
+}
+
+// 장바구니 전체의 금액을 계산한 결과입니다.
+// 가격이나 재고가 바뀐 상품이 하나라도 있다면 HasChanges가 true입니다.
+type CartSummary struct {
+	Carts       []*PublicCart        `json:"carts"`
+	Groups      []*CartShippingGroup `json:"groups"`
+	Subtotal    int64                `json:"subtotal"`
+	ShippingFee int64                `json:"shipping_fee"`
+	Total       int64                `json:"total"`
+	HasChanges  bool                 `json:"has_changes"`
+}
+
+// 장바구니의 한 줄을 가리키는 객체입니다. 여러 줄을 한 번에 삭제할 때 사용합니다.
+// SKU가 없는 상품이라면 SkuId는 0입니다.
+type CartItem struct {
+	ProductId int64 `json:"product_id"`
+	SkuId     int64 `json:"sku_id"`
+}
+
+// 장바구니의 여러 상품 개수를 한 번에 바꿀 때 사용하는 객체입니다.
+// SKU가 없는 상품이라면 SkuId는 0입니다.
+type CartAmount struct {
+	ProductId int64 `json:"product_id"`
+	SkuId     int64 `json:"sku_id"`
+	Amount    int64 `json:"amount"`
 }
 
 // 유저가 상품(프로덕트)을 담아놓은 장바구니 정보를 담은 N:M 맵입니다.
+// PriceAtAdd는 마지막으로 담거나 개수를 바꿨을 때의 상품 가격입니다.
+// 같은 상품이라도 SKU가 다르면 다른 줄에 담기며, SKU가 없는 상품이라면 SkuId는 0입니다.
 type Cart struct {
 	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN AI"  json:"id"`
 	UserId      int64     `rnsql:"user_id"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"user_id"`
 	ProductId   int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	SkuId       int64     `rnsql:"sku_id"  rntype:"INT"  rnopt:"NN"  json:"sku_id"`
 	Amount      int64     `rnsql:"amount"  rntype:"BIGINT"  rnopt:"NN"  json:"amount"`
+	PriceAtAdd  int64     `rnsql:"price_at_add"  rntype:"BIGINT"  rnopt:"NN"  json:"price_at_add"`
 	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
 	UpdatedTime time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
 }
 
+// 로그인하지 않은 사용자가 담아놓은 장바구니 정보를 담은 테이블입니다.
+// GuestId는 게스트 쿠키에 담긴 값이며, 로그인하면 유저의 장바구니로 합쳐집니다.
+type GuestCart struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN AI"  json:"id"`
+	GuestId     string    `rnsql:"guest_id"  rntype:"VARCHAR(64)"  rnopt:"NN"  json:"guest_id"`
+	ProductId   int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	SkuId       int64     `rnsql:"sku_id"  rntype:"INT"  rnopt:"NN"  json:"sku_id"`
+	Amount      int64     `rnsql:"amount"  rntype:"BIGINT"  rnopt:"NN"  json:"amount"`
+	PriceAtAdd  int64     `rnsql:"price_at_add"  rntype:"BIGINT"  rnopt:"NN"  json:"price_at_add"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+	UpdatedTime time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
+}
+
+// 게스트 장바구니를 유저 장바구니로 합친 결과입니다.
+// 재고가 모자라 개수를 줄이거나 담지 못한 상품은 AdjustedProductIds에 담깁니다.
+type CartMergeResult struct {
+	MergedCount        int64   `json:"merged_count"`
+	AdjustedProductIds []int64 `json:"adjusted_product_ids"`
+}
+
 func init() {
 	AddTable("CART", &Cart{})
 	AddIndex(&gorn.DBIndex{
@@ -38,6 +109,19 @@ func init() {
 		Columns: []*gorn.DBIndexColumn{
 			{ColumnName: "user_id", ASC: true},
 			{ColumnName: "product_id", ASC: true},
+			{ColumnName: "sku_id", ASC: true},
+		},
+	})
+
+	AddTable("GUEST_CART", &GuestCart{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "GUEST_CART",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "guest_id", ASC: true},
+			{ColumnName: "product_id", ASC: true},
+			{ColumnName: "sku_id", ASC: true},
 		},
 	})
 }
@@ -0,0 +1,162 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/thak1411/gorn"
+)
+
+// 쿠폰과 상품 할인의 할인 방식입니다.
+// 정액 할인은 DiscountValue 원을, 정률 할인은 DiscountValue 퍼센트를 깎아줍니다.
+const (
+	DiscountTypeFixed   = "fixed"
+	DiscountTypePercent = "percent"
+)
+
+// 유저에게 보여줄 쿠폰 정보입니다.
+// 사용 횟수나 만든 사람처럼 운영에만 필요한 정보는 담지 않습니다.
+type PublicCoupon struct {
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	DiscountType  string    `json:"discount_type"`
+	DiscountValue int64     `json:"discount_value"`
+	MaxDiscount   int64     `json:"max_discount"`
+	MinSpend      int64     `json:"min_spend"`
+	BrandId       int64     `json:"brand_id"`
+	CategoryId    int64     `json:"category_id"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+}
+
+// 쿠폰 정보를 담은 테이블입니다.
+// BrandId, CategoryId가 0이 아니라면 해당 브랜드나 카테고리 상품에만 적용됩니다.
+// MaxDiscount는 정률 할인의 최대 할인 금액이며 0이라면 제한이 없습니다.
+// UsageLimit, PerUserLimit이 0이라면 사용 횟수에 제한이 없습니다.
+// UsedCount는 결제에 사용된 횟수이며, 결제할 때 UsageLimit을 넘지 않도록 디비에서 직접 올립니다.
+type Coupon struct {
+	Id            int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	Code          string    `rnsql:"code"  rntype:"VARCHAR(50)"  rnopt:"NN"  json:"code"`
+	Name          string    `rnsql:"name"  rntype:"VARCHAR(100)"  rnopt:"NN"  json:"name"`
+	DiscountType  string    `rnsql:"discount_type"  rntype:"VARCHAR(10)"  rnopt:"NN"  json:"discount_type"`
+	DiscountValue int64     `rnsql:"discount_value"  rntype:"BIGINT"  rnopt:"NN"  json:"discount_value"`
+	MaxDiscount   int64     `rnsql:"max_discount"  rntype:"BIGINT"  rnopt:"NN"  json:"max_discount"`
+	MinSpend      int64     `rnsql:"min_spend"  rntype:"BIGINT"  rnopt:"NN"  json:"min_spend"`
+	BrandId       int64     `rnsql:"brand_id"  rntype:"INT"  rnopt:"NN"  json:"brand_id"`
+	CategoryId    int64     `rnsql:"category_id"  rntype:"INT"  rnopt:"NN"  json:"category_id"`
+	StartTime     time.Time `rnsql:"start_time"  rntype:"DATETIME"  rnopt:"NN"  json:"start_time"`
+	EndTime       time.Time `rnsql:"end_time"  rntype:"DATETIME"  rnopt:"NN"  json:"end_time"`
+	UsageLimit    int64     `rnsql:"usage_limit"  rntype:"INT"  rnopt:"NN"  json:"usage_limit"`
+	PerUserLimit  int64     `rnsql:"per_user_limit"  rntype:"INT"  rnopt:"NN"  json:"per_user_limit"`
+	UsedCount     int64     `rnsql:"used_count"  rntype:"INT"  rnopt:"NN"  json:"used_count"`
+	CreatedBy     int64     `rnsql:"created_by"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"created_by"`
+	CreatedTime   time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+	UpdatedTime   time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
+}
+
+// 쿠폰을 결제에 사용한 기록을 담은 테이블입니다.
+// 유저별 사용 횟수는 이 테이블에서 셉니다.
+type CouponRedemption struct {
+	Id             int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	CouponId       int64     `rnsql:"coupon_id"  rntype:"INT"  rnopt:"NN"  FK:"COUPON.id"  json:"coupon_id"`
+	UserId         int64     `rnsql:"user_id"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"user_id"`
+	OrderId        int64     `rnsql:"order_id"  rntype:"INT"  rnopt:"NN"  FK:"ORDERS.id"  json:"order_id"`
+	DiscountAmount int64     `rnsql:"discount_amount"  rntype:"BIGINT"  rnopt:"NN"  json:"discount_amount"`
+	CreatedTime    time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+// 기간 동안 상품 가격을 깎아주는 상품 할인 정보를 담은 테이블입니다.
+// 같은 기간에 여러 할인이 겹친다면 가장 많이 깎아주는 할인 하나만 적용됩니다.
+type ProductDiscount struct {
+	Id            int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	ProductId     int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	DiscountType  string    `rnsql:"discount_type"  rntype:"VARCHAR(10)"  rnopt:"NN"  json:"discount_type"`
+	DiscountValue int64     `rnsql:"discount_value"  rntype:"BIGINT"  rnopt:"NN"  json:"discount_value"`
+	StartTime     time.Time `rnsql:"start_time"  rntype:"DATETIME"  rnopt:"NN"  json:"start_time"`
+	EndTime       time.Time `rnsql:"end_time"  rntype:"DATETIME"  rnopt:"NN"  json:"end_time"`
+	CreatedBy     int64     `rnsql:"created_by"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"created_by"`
+	CreatedTime   time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+// 상품 할인과 쿠폰을 적용해 계산한 장바구니 상품 한 줄의 가격입니다.
+// UnitPrice는 상품 할인이 적용된 개당 가격이며, 쿠폰 할인은 CouponDiscount에 따로 담깁니다.
+type PricedCartLine struct {
+	ProductId      int64  `json:"product_id"`
+	SkuId          int64  `json:"sku_id"`
+	ProductName    string `json:"product_name"`
+	BrandId        int64  `json:"brand_id"`
+	OriginalPrice  int64  `json:"original_price"`
+	UnitPrice      int64  `json:"unit_price"`
+	Amount         int64  `json:"amount"`
+	Subtotal       int64  `json:"subtotal"`
+	CouponEligible bool   `json:"coupon_eligible"`
+}
+
+// 상품 할인과 쿠폰을 적용해 계산한 장바구니 전체 가격입니다.
+// Subtotal은 상품 할인이 적용된 합계이며, Total은 여기서 쿠폰 할인을 빼고 배송비를 더한 금액입니다.
+type PricedCart struct {
+	Lines           []*PricedCartLine `json:"lines"`
+	Coupon          *PublicCoupon     `json:"coupon"`
+	Subtotal        int64             `json:"subtotal"`
+	ProductDiscount int64             `json:"product_discount"`
+	CouponDiscount  int64             `json:"coupon_discount"`
+	ShippingFee     int64             `json:"shipping_fee"`
+	Total           int64             `json:"total"`
+}
+
+func init() {
+	AddTable("COUPON", &Coupon{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "COUPON",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "COUPON",
+		IndexName: "code_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "code", ASC: true},
+		},
+	})
+
+	AddTable("COUPON_REDEMPTION", &CouponRedemption{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "COUPON_REDEMPTION",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "COUPON_REDEMPTION",
+		IndexName: "coupon_user_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "coupon_id", ASC: true},
+			{ColumnName: "user_id", ASC: true},
+		},
+	})
+
+	AddTable("PRODUCT_DISCOUNT", &ProductDiscount{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_DISCOUNT",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_DISCOUNT",
+		IndexName: "product_time_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+			{ColumnName: "start_time", ASC: true},
+		},
+	})
+}
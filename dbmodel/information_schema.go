@@ -0,0 +1,35 @@
+package dbmodel
+
+import "database/sql"
+
+// 디비에 만들어진 컬럼 하나의 정보입니다. (INFORMATION_SCHEMA.COLUMNS)
+type SchemaColumn struct {
+	TableName  string `rnsql:"TABLE_NAME"`
+	ColumnName string `rnsql:"COLUMN_NAME"`
+	ColumnType string `rnsql:"COLUMN_TYPE"`
+	IsNullable string `rnsql:"IS_NULLABLE"`
+	ColumnKey  string `rnsql:"COLUMN_KEY"`
+	Extra      string `rnsql:"EXTRA"`
+}
+
+// 디비에 만들어진 인덱스의 컬럼 하나의 정보입니다. (INFORMATION_SCHEMA.STATISTICS)
+// 여러 컬럼으로 만든 인덱스는 SeqInIndex 순서대로 여러 행으로 나뉩니다.
+// Collation은 A(오름차순), D(내림차순) 중 하나이며, 정렬을 지원하지 않는 인덱스라면 NULL입니다.
+type SchemaIndexColumn struct {
+	TableName  string         `rnsql:"TABLE_NAME"`
+	IndexName  string         `rnsql:"INDEX_NAME"`
+	NonUnique  int64          `rnsql:"NON_UNIQUE"`
+	SeqInIndex int64          `rnsql:"SEQ_IN_INDEX"`
+	ColumnName string         `rnsql:"COLUMN_NAME"`
+	SubPart    sql.NullInt64  `rnsql:"SUB_PART"`
+	Collation  sql.NullString `rnsql:"COLLATION"`
+}
+
+// 디비에 만들어진 외래 키 컬럼 하나의 정보입니다. (INFORMATION_SCHEMA.KEY_COLUMN_USAGE)
+type SchemaForeignKey struct {
+	TableName            string `rnsql:"TABLE_NAME"`
+	ConstraintName       string `rnsql:"CONSTRAINT_NAME"`
+	ColumnName           string `rnsql:"COLUMN_NAME"`
+	ReferencedTableName  string `rnsql:"REFERENCED_TABLE_NAME"`
+	ReferencedColumnName string `rnsql:"REFERENCED_COLUMN_NAME"`
+}
@@ -0,0 +1,100 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/thak1411/gorn"
+)
+
+// 결제가 끝난 주문의 상태입니다.
+const OrderStatusPaid = "paid"
+
+// 유저에게 보여줄 주문 리스트에 들어갈 정보를 담은 테이블입니다.
+type PublicOrder struct {
+	Id              int64  `rnsql:"ORDERS.id"  json:"id"`
+	Subtotal        int64  `rnsql:"ORDERS.subtotal"  json:"subtotal"`
+	ProductDiscount int64  `rnsql:"ORDERS.product_discount"  json:"product_discount"`
+	CouponDiscount  int64  `rnsql:"ORDERS.coupon_discount"  json:"coupon_discount"`
+	ShippingFee     int64  `rnsql:"ORDERS.shipping_fee"  json:"shipping_fee"`
+	Total           int64  `rnsql:"ORDERS.total"  json:"total"`
+	Status          string `rnsql:"ORDERS.status"  json:"status"`
+	ItemCount       int64  `rnsql:"(SELECT COUNT(*) FROM ORDER_ITEM WHERE ORDER_ITEM.order_id = ORDERS.id)"  json:"item_count"`
+	CreatedTime     string `rnsql:"ORDERS.created_time"  json:"created_time"`
+}
+
+// 결제한 주문 정보를 담은 테이블입니다.
+// ORDER는 SQL 예약어이므로 테이블 이름을 ORDERS로 사용합니다.
+// CouponId가 0이라면 쿠폰을 사용하지 않은 주문입니다.
+type Order struct {
+	Id              int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	UserId          int64     `rnsql:"user_id"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"user_id"`
+	CouponId        int64     `rnsql:"coupon_id"  rntype:"INT"  rnopt:"NN"  json:"coupon_id"`
+	Subtotal        int64     `rnsql:"subtotal"  rntype:"BIGINT"  rnopt:"NN"  json:"subtotal"`
+	ProductDiscount int64     `rnsql:"product_discount"  rntype:"BIGINT"  rnopt:"NN"  json:"product_discount"`
+	CouponDiscount  int64     `rnsql:"coupon_discount"  rntype:"BIGINT"  rnopt:"NN"  json:"coupon_discount"`
+	ShippingFee     int64     `rnsql:"shipping_fee"  rntype:"BIGINT"  rnopt:"NN"  json:"shipping_fee"`
+	Total           int64     `rnsql:"total"  rntype:"BIGINT"  rnopt:"NN"  json:"total"`
+	Status          string    `rnsql:"status"  rntype:"VARCHAR(20)"  rnopt:"NN"  json:"status"`
+	CreatedTime     time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+	UpdatedTime     time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
+}
+
+// 주문에 담긴 상품 정보를 담은 테이블입니다.
+// 상품 정보가 바뀌어도 주문 내역이 바뀌지 않도록 결제 당시의 이름과 가격을 복사해둡니다.
+type OrderItem struct {
+	Id            int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	OrderId       int64     `rnsql:"order_id"  rntype:"INT"  rnopt:"NN"  FK:"ORDERS.id"  json:"order_id"`
+	ProductId     int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	SkuId         int64     `rnsql:"sku_id"  rntype:"INT"  rnopt:"NN"  json:"sku_id"`
+	ProductName   string    `rnsql:"product_name"  rntype:"VARCHAR(200)"  rnopt:"NN"  json:"product_name"`
+	OriginalPrice int64     `rnsql:"original_price"  rntype:"BIGINT"  rnopt:"NN"  json:"original_price"`
+	UnitPrice     int64     `rnsql:"unit_price"  rntype:"BIGINT"  rnopt:"NN"  json:"unit_price"`
+	Amount        int64     `rnsql:"amount"  rntype:"BIGINT"  rnopt:"NN"  json:"amount"`
+	CreatedTime   time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+func init() {
+	AddTable("ORDERS", &Order{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "ORDERS",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "ORDERS",
+		IndexName: "user_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "user_id", ASC: true},
+		},
+	})
+
+	AddTable("ORDER_ITEM", &OrderItem{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "ORDER_ITEM",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "ORDER_ITEM",
+		IndexName: "order_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "order_id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "ORDER_ITEM",
+		IndexName: "product_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+		},
+	})
+}
@@ -8,18 +8,95 @@ import (
 	"github.com/thak1411/gorn"
 )
 
+// 유저에게 보여줄 PBV 옵션(프리셋) 리스트에 들어갈 정보를 담은 테이블입니다.
+// 공유하지 않은 옵션이라면 ShareToken이 비어있습니다.
+type PublicPbvOption struct {
+	Id          int64  `rnsql:"PBV_OPTION.id"  json:"id"`
+	Name        string `rnsql:"PBV_OPTION.name"  json:"name"`
+	Version     int64  `rnsql:"PBV_OPTION.version"  json:"version"`
+	ShareToken  string `rnsql:"IFNULL(PBV_SHARE.token, '')"  json:"share_token"`
+	ViewCount   int64  `rnsql:"IFNULL(PBV_SHARE.view_count, 0)"  json:"view_count"`
+	CreatedTime string `rnsql:"PBV_OPTION.created_time"  json:"created_time"`
+	UpdatedTime string `rnsql:"PBV_OPTION.updated_time"  json:"updated_time"`
+}
+
+// 공유 링크로 다른 사람에게 보여줄 PBV 옵션 정보입니다.
+// 옵션 아이디처럼 주인만 알아야 하는 정보는 담지 않습니다.
+type PublicSharedPbvOption struct {
+	Name          string `json:"name"`
+	Version       int64  `json:"version"`
+	OwnerNickname string `json:"owner_nickname"`
+	ViewCount     int64  `json:"view_count"`
+	UpdatedTime   string `json:"updated_time"`
+}
+
+// 유저에게 보여줄 PBV 옵션 버전 리스트에 들어갈 정보를 담은 테이블입니다.
+type PublicPbvOptionVersion struct {
+	Version     int64  `rnsql:"PBV_OPTION_VERSION.version"  json:"version"`
+	CreatedTime string `rnsql:"PBV_OPTION_VERSION.created_time"  json:"created_time"`
+}
+
+// 현재 상품 가격과 재고로 계산한 PBV 옵션의 견적 정보를 담은 객체입니다.
+// 모든 부품이 구매 가능할 때만 Purchasable이 true입니다.
+type PbvQuote struct {
+	OptionId    int64           `json:"option_id"`
+	Lines       []*PbvQuoteLine `json:"lines"`
+	Total       int64           `json:"total"`
+	Purchasable bool            `json:"purchasable"`
+}
+
+// PBV 옵션 견적에 들어가는 부품 한 줄의 정보입니다.
+// 존재하지 않는 상품이라면 ProductName이 비어있고 Available이 false입니다.
+type PbvQuoteLine struct {
+	Slot        string `json:"slot"`
+	ProductId   int64  `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Price       int64  `json:"price"`
+	Amount      int64  `json:"amount"`
+	Stock       int64  `json:"stock"`
+	Subtotal    int64  `json:"subtotal"`
+	Available   bool   `json:"available"`
+}
+
 // 유저가 담아놓은 커스텀 PBV 옵션을 담은 테이블입니다.
+// 한 유저가 이름을 붙인 여러 개의 옵션(프리셋)을 가질 수 있습니다.
+// Version은 PBV_OPTION_VERSION에 저장된 최신 버전 번호입니다.
+// SchemaVersion은 Data가 따르는 스키마 버전이며, 0은 스키마가 생기기 이전의 데이터입니다.
 type PbvOption struct {
+	Id            int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	UserId        int64     `rnsql:"user_id"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"user_id"`
+	Name          string    `rnsql:"name"  rntype:"VARCHAR(100)"  rnopt:"NN"  json:"name"`
+	Version       int64     `rnsql:"version"  rntype:"INT"  rnopt:"NN"  json:"version"`
+	SchemaVersion int64     `rnsql:"schema_version"  rntype:"INT"  rnopt:"NN"  json:"schema_version"`
+	Data          DataJson  `rnsql:"data"  rntype:"JSON"  rnopt:"NN"  json:"data"  db:"data"`
+	CreatedTime   time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+	UpdatedTime   time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
+}
+
+// PBV 옵션의 데이터가 바뀔 때마다 이전 데이터를 보관하는 테이블입니다.
+type PbvOptionVersion struct {
+	Id            int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	PbvOptionId   int64     `rnsql:"pbv_option_id"  rntype:"INT"  rnopt:"NN"  FK:"PBV_OPTION.id"  json:"pbv_option_id"`
+	Version       int64     `rnsql:"version"  rntype:"INT"  rnopt:"NN"  json:"version"`
+	SchemaVersion int64     `rnsql:"schema_version"  rntype:"INT"  rnopt:"NN"  json:"schema_version"`
+	Data          DataJson  `rnsql:"data"  rntype:"JSON"  rnopt:"NN"  json:"data"  db:"data"`
+	CreatedTime   time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+// PBV 옵션을 공유 링크로 공개한 정보를 담은 테이블입니다.
+// 옵션 하나당 하나의 공유 토큰만 가질 수 있으며, 공유를 취소하면 행이 삭제됩니다.
+type PbvShare struct {
 	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
-	UserId      int64     `rnsql:"user_id"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"user_id"`
-	Data        DataJson  `rnsql:"data"  rntype:"JSON"  rnopt:"NN"  json:"data"  db:"data"`
+	PbvOptionId int64     `rnsql:"pbv_option_id"  rntype:"INT"  rnopt:"NN"  FK:"PBV_OPTION.id"  json:"pbv_option_id"`
+	Token       string    `rnsql:"token"  rntype:"VARCHAR(64)"  rnopt:"NN"  json:"token"`
+	ViewCount   int64     `rnsql:"view_count"  rntype:"BIGINT"  rnopt:"NN"  json:"view_count"`
 	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
-	UpdatedTime time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
 }
 
 type DataJson map[string]interface{}
 
-func (d *DataJson) Value() (driver.Value, error) {
+// 드라이버가 map 타입을 그대로 넘겨받지 못하므로 값 리시버로 구현합니다.
+func (d DataJson) Value() (driver.Value, error) {
 	return json.Marshal(d)
 }
 
@@ -33,8 +110,61 @@ func init() {
 		TableName: "PBV_OPTION",
 		IndexName: "id_UNIQUE",
 		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PBV_OPTION",
+		IndexName: "user_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
 		Columns: []*gorn.DBIndexColumn{
 			{ColumnName: "user_id", ASC: true},
 		},
 	})
+
+	AddTable("PBV_OPTION_VERSION", &PbvOptionVersion{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PBV_OPTION_VERSION",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PBV_OPTION_VERSION",
+		IndexName: "option_version_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "pbv_option_id", ASC: true},
+			{ColumnName: "version", ASC: true},
+		},
+	})
+
+	AddTable("PBV_SHARE", &PbvShare{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PBV_SHARE",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PBV_SHARE",
+		IndexName: "pbv_option_id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "pbv_option_id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PBV_SHARE",
+		IndexName: "token_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "token", ASC: true},
+		},
+	})
 }
@@ -7,17 +7,30 @@ import (
 )
 
 // 유저에게 보여줄 프로덕트 리스트에 들어갈 정보를 담은 테이블입니다.
+// IsWished는 조회한 유저가 찜한 상품인지를 나타내며, 게스트는 항상 false입니다.
+// FitsMyVehicle은 조회한 유저의 내 차고에 있는 차량에 장착할 수 있는 상품인지를 나타내며, 디비에서 가져오지 않고 따로 채워집니다.
+// Variants, Skus, Vehicles, Images, Description은 디비에서 가져오지 않으며, 개별 상품을 조회할 때만 채워집니다.
 type PublicProduct struct {
-	Id            int64        `rnsql:"PRODUCT.id"  json:"id"`
-	BrandId       int64        `rnsql:"PRODUCT.brand_id"  json:"brand_id"`
-	BrandName     string       `rnsql:"BRAND.name"  json:"brand_name"`
-	Categories    CategoryList `rnsql:"CONCAT('[', GROUP_CONCAT('{\"id\":', CATEGORY.id, ',\"name\":\"', CATEGORY.name, '\",\"description\":\"', CATEGORY.description, '\"}'), ']')"  json:"categories"`
-	Name          string       `rnsql:"PRODUCT.name"  json:"name"`
-	Price         int64        `rnsql:"PRODUCT.price"  json:"price"`
-	Amount        int64        `rnsql:"PRODUCT.amount"  json:"amount"`
-	TitleImageS3  string       `rnsql:"PRODUCT.title_image_s3"  json:"title_image_s3"`
-	DescriptionS3 string       `rnsql:"PRODUCT.description_s3"  json:"description_s3"`
-	CreatedTime   string       `rnsql:"PRODUCT.created_time"  json:"created_time"`
+	Id            int64                     `rnsql:"PRODUCT.id"  json:"id"`
+	BrandId       int64                     `rnsql:"PRODUCT.brand_id"  json:"brand_id"`
+	BrandName     string                    `rnsql:"BRAND.name"  json:"brand_name"`
+	Categories    CategoryList              `rnsql:"CONCAT('[', GROUP_CONCAT('{\"id\":', CATEGORY.id, ',\"name\":\"', CATEGORY.name, '\",\"description\":\"', CATEGORY.description, '\"}'), ']')"  json:"categories"`
+	Name          string                    `rnsql:"PRODUCT.name"  json:"name"`
+	Price         int64                     `rnsql:"PRODUCT.price"  json:"price"`
+	Amount        int64                     `rnsql:"PRODUCT.amount"  json:"amount"`
+	TitleImageS3  string                    `rnsql:"PRODUCT.title_image_s3"  json:"title_image_s3"`
+	DescriptionS3 string                    `rnsql:"PRODUCT.description_s3"  json:"description_s3"`
+	ReviewCount   int64                     `rnsql:"IFNULL(PRODUCT_STATISTICS.review_count, 0)"  json:"review_count"`
+	AverageScore  float64                   `rnsql:"IFNULL(PRODUCT_STATISTICS.sum_review_score / NULLIF(PRODUCT_STATISTICS.review_count, 0), 0)"  json:"average_score"`
+	FavoriteCount int64                     `rnsql:"IFNULL(PRODUCT_STATISTICS.favorite_count, 0)"  json:"favorite_count"`
+	IsWished      bool                      `rnsql:"COUNT(WISHLIST.id) > 0"  json:"is_wished"`
+	CreatedTime   string                    `rnsql:"PRODUCT.created_time"  json:"created_time"`
+	FitsMyVehicle bool                      `json:"fits_my_vehicle"`
+	Variants      []*PublicProductVariant   `json:"variants,omitempty"`
+	Skus          []*PublicProductSku       `json:"skus,omitempty"`
+	Vehicles      []*PublicVehicleModel     `json:"vehicles,omitempty"`
+	Images        []*PublicProductImage     `json:"images,omitempty"`
+	Description   *PublicProductDescription `json:"description,omitempty"`
 }
 
 // 판매자가 판매할 상품 정보를 담은 테이블입니다.
@@ -0,0 +1,97 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/thak1411/gorn"
+)
+
+// 상품 상세 설명의 형식입니다.
+// markdown은 마크다운 문서를, blocks는 product_description 스키마를 따르는 블록 JSON을 저장합니다.
+const (
+	DescriptionFormatMarkdown = "markdown"
+	DescriptionFormatBlocks   = "blocks"
+)
+
+// 유저에게 보여줄 상품 상세 설명입니다.
+// Html은 저장된 내용을 읽을 때마다 안전한 태그만 남도록 렌더링한 결과입니다.
+type PublicProductDescription struct {
+	Format      string `json:"format"`
+	Content     string `json:"content"`
+	Html        string `json:"html"`
+	Revision    int64  `json:"revision"`
+	UpdatedTime string `json:"updated_time"`
+}
+
+// 유저에게 보여줄 상품 상세 설명 수정 기록 리스트에 들어갈 정보를 담은 테이블입니다.
+type PublicProductDescriptionRevision struct {
+	Revision    int64  `rnsql:"PRODUCT_DESCRIPTION_REVISION.revision"  json:"revision"`
+	Format      string `rnsql:"PRODUCT_DESCRIPTION_REVISION.format"  json:"format"`
+	CreatedBy   int64  `rnsql:"PRODUCT_DESCRIPTION_REVISION.created_by"  json:"created_by"`
+	CreatedTime string `rnsql:"PRODUCT_DESCRIPTION_REVISION.created_time"  json:"created_time"`
+}
+
+// 상품의 현재 상세 설명을 담은 테이블입니다.
+// 상품 하나당 하나의 행만 가지며, Revision은 PRODUCT_DESCRIPTION_REVISION에 저장된 최신 수정 번호입니다.
+// SearchText는 검색에 사용하도록 내용에서 문법을 지우고 글자만 남긴 값입니다.
+type ProductDescription struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	ProductId   int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	Format      string    `rnsql:"format"  rntype:"VARCHAR(20)"  rnopt:"NN"  json:"format"`
+	Content     string    `rnsql:"content"  rntype:"TEXT"  rnopt:"NN"  json:"content"`
+	SearchText  string    `rnsql:"search_text"  rntype:"TEXT"  rnopt:"NN"  json:"search_text"`
+	Revision    int64     `rnsql:"revision"  rntype:"INT"  rnopt:"NN"  json:"revision"`
+	UpdatedBy   int64     `rnsql:"updated_by"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"updated_by"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+	UpdatedTime time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
+}
+
+// 상품 상세 설명이 바뀔 때마다 내용을 보관하는 테이블입니다.
+type ProductDescriptionRevision struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	ProductId   int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	Revision    int64     `rnsql:"revision"  rntype:"INT"  rnopt:"NN"  json:"revision"`
+	Format      string    `rnsql:"format"  rntype:"VARCHAR(20)"  rnopt:"NN"  json:"format"`
+	Content     string    `rnsql:"content"  rntype:"TEXT"  rnopt:"NN"  json:"content"`
+	CreatedBy   int64     `rnsql:"created_by"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"created_by"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+func init() {
+	AddTable("PRODUCT_DESCRIPTION", &ProductDescription{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_DESCRIPTION",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_DESCRIPTION",
+		IndexName: "product_id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+		},
+	})
+
+	AddTable("PRODUCT_DESCRIPTION_REVISION", &ProductDescriptionRevision{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_DESCRIPTION_REVISION",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_DESCRIPTION_REVISION",
+		IndexName: "product_revision_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+			{ColumnName: "revision", ASC: true},
+		},
+	})
+}
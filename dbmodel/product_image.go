@@ -0,0 +1,56 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/thak1411/gorn"
+)
+
+// 유저에게 보여줄 상품 이미지 정보입니다.
+// 주소는 저장소 설정에 따라 달라지므로 디비에서 가져오지 않고 저장소에서 만들어줍니다.
+type PublicProductImage struct {
+	Id           int64  `json:"id"`
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnail_url"`
+	Width        int64  `json:"width"`
+	Height       int64  `json:"height"`
+	Position     int64  `json:"position"`
+}
+
+// 상품의 갤러리 이미지 정보를 담은 테이블입니다.
+// 파일은 미디어 저장소에 있으며, 여기에는 저장소 key만 저장합니다.
+// Position이 작은 이미지부터 보여주며, 첫 번째 이미지가 상품의 대표 이미지가 됩니다.
+type ProductImage struct {
+	Id           int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	ProductId    int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	ImageKey     string    `rnsql:"image_key"  rntype:"VARCHAR(200)"  rnopt:"NN"  json:"image_key"`
+	ThumbnailKey string    `rnsql:"thumbnail_key"  rntype:"VARCHAR(200)"  rnopt:"NN"  json:"thumbnail_key"`
+	ContentType  string    `rnsql:"content_type"  rntype:"VARCHAR(50)"  rnopt:"NN"  json:"content_type"`
+	Size         int64     `rnsql:"size"  rntype:"INT"  rnopt:"NN"  json:"size"`
+	Width        int64     `rnsql:"width"  rntype:"INT"  rnopt:"NN"  json:"width"`
+	Height       int64     `rnsql:"height"  rntype:"INT"  rnopt:"NN"  json:"height"`
+	Position     int64     `rnsql:"position"  rntype:"INT"  rnopt:"NN"  json:"position"`
+	CreatedBy    int64     `rnsql:"created_by"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"created_by"`
+	CreatedTime  time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+func init() {
+	AddTable("PRODUCT_IMAGE", &ProductImage{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_IMAGE",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_IMAGE",
+		IndexName: "product_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+			{ColumnName: "position", ASC: true},
+		},
+	})
+}
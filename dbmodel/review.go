@@ -8,14 +8,22 @@ import (
 
 // 유저에게 보여줄 리뷰 리스트에 들어갈 정보를 담은 테이블입니다.
 type PublicReview struct {
-	Id          int64  `rnsql:"REVIEW.id"  json:"id"`
-	ProductId   int64  `rnsql:"REVIEW.product_id"  json:"product_id"`
-	UserId      int64  `rnsql:"REVIEW.user_id"  json:"user_id"`
-	Nickname    string `rnsql:"USER.nickname"  json:"nickname"`
-	Score       int64  `rnsql:"REVIEW.score"  json:"score"`
-	Content     string `rnsql:"REVIEW.content"  json:"content"`
-	IsParent    bool   `rnsql:"CASE WHEN REVIEW.parent_review_id > 0 THEN 0 ELSE 1 END AS is_parent"  json:"is_parent"`
-	CreatedTime string `rnsql:"REVIEW.created_time"  json:"created_time"`
+	Id             int64  `rnsql:"REVIEW.id"  json:"id"`
+	ProductId      int64  `rnsql:"REVIEW.product_id"  json:"product_id"`
+	UserId         int64  `rnsql:"REVIEW.user_id"  json:"user_id"`
+	Nickname       string `rnsql:"USER.nickname"  json:"nickname"`
+	Score          int64  `rnsql:"REVIEW.score"  json:"score"`
+	Content        string `rnsql:"REVIEW.content"  json:"content"`
+	IsParent       bool   `rnsql:"CASE WHEN REVIEW.parent_review_id > 0 THEN 0 ELSE 1 END AS is_parent"  json:"is_parent"`
+	ParentReviewId int64  `rnsql:"REVIEW.parent_review_id"  json:"parent_review_id"`
+	CreatedTime    string `rnsql:"REVIEW.created_time"  json:"created_time"`
+}
+
+// 답글을 원본 리뷰 밑에 묶은 리뷰 정보입니다.
+// 리뷰 정보는 펼쳐서 응답하며, 답글이 없다면 replies는 빈 리스트입니다.
+type PublicReviewThread struct {
+	*PublicReview
+	Replies []*PublicReview `json:"replies"`
 }
 
 // 유저가 작성한 리뷰를 담은 테이블입니다.
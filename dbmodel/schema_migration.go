@@ -0,0 +1,14 @@
+package dbmodel
+
+import "time"
+
+// 적용한 마이그레이션 기록을 담은 테이블의 이름입니다.
+const SchemaMigrationsTable = "schema_migrations"
+
+// 적용한 마이그레이션 기록을 담은 테이블입니다.
+// 마이그레이션 도구가 직접 만들고 관리하므로 AddTable로 등록하지 않습니다.
+type SchemaMigration struct {
+	Version   int64     `rnsql:"version"  rntype:"BIGINT"  rnopt:"PK NN"  json:"version"`
+	Name      string    `rnsql:"name"  rntype:"VARCHAR(255)"  rnopt:"NN"  json:"name"`
+	AppliedAt time.Time `rnsql:"applied_at"  rntype:"DATETIME"  rnopt:"NN"  json:"applied_at"`
+}
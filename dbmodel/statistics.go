@@ -2,6 +2,39 @@ package dbmodel
 
 import "github.com/thak1411/gorn"
 
+// 유저에게 보여줄 상품 통계 정보를 담은 객체입니다.
+// 별점 분포와 최근 추이를 함께 제공합니다.
+type PublicProductStatistics struct {
+	ProductId    int64                   `json:"product_id"`
+	ReviewCount  int64                   `json:"review_count"`
+	AverageScore float64                 `json:"average_score"`
+	SoldQuantity  int64                   `json:"sold_quantity"`
+	FavoriteCount int64                   `json:"favorite_count"`
+	Histogram    []*ReviewScoreCount     `json:"histogram"`
+	Trend        *ProductStatisticsTrend `json:"trend"`
+}
+
+// 별점 별 리뷰 개수를 담은 객체입니다.
+type ReviewScoreCount struct {
+	Score int64 `rnsql:"REVIEW.score"  json:"score"`
+	Count int64 `rnsql:"COUNT(*)"  json:"count"`
+}
+
+// 기간 내에 작성된 리뷰의 개수와 별점 합을 담은 객체입니다.
+type ReviewScoreSummary struct {
+	Count    int64 `rnsql:"COUNT(*)"  json:"count"`
+	SumScore int64 `rnsql:"IFNULL(SUM(REVIEW.score), 0)"  json:"sum_score"`
+}
+
+// 최근 기간과 그 이전 기간의 리뷰 추이를 담은 객체입니다.
+type ProductStatisticsTrend struct {
+	Days                 int64   `json:"days"`
+	RecentReviewCount    int64   `json:"recent_review_count"`
+	RecentAverageScore   float64 `json:"recent_average_score"`
+	PreviousReviewCount  int64   `json:"previous_review_count"`
+	PreviousAverageScore float64 `json:"previous_average_score"`
+}
+
 // 상품에 대한 통계를 저장해주는 테이블입니다.
 //DOC: 항상 새로운 프로덕트를 만들 때 같이 만들어야 합니다.
 type ProductStatistics struct {
@@ -9,6 +42,7 @@ type ProductStatistics struct {
 	ReviewCount    int64 `rnsql:"review_count"  rntype:"INT"  rnopt:"NN"  json:"review_count"`
 	SumReviewScore int64 `rnsql:"sum_review_score"  rntype:"INT"  rnopt:"NN"  json:"sum_review_score"`
 	SoldQuantity   int64 `rnsql:"sold_quantity"  rntype:"INT"  rnopt:"NN"  json:"sold_quantity"`
+	FavoriteCount  int64 `rnsql:"favorite_count"  rntype:"INT"  rnopt:"NN"  json:"favorite_count"`
 }
 
 func init() {
@@ -0,0 +1,146 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/thak1411/gorn"
+)
+
+// 유저에게 보여줄 상품 옵션(색상, 사이즈 등)과 선택할 수 있는 값 리스트입니다.
+type PublicProductVariant struct {
+	Id     int64                        `json:"id"`
+	Name   string                       `json:"name"`
+	Values []*PublicProductVariantValue `json:"values"`
+}
+
+// 유저에게 보여줄 상품 옵션 값입니다.
+type PublicProductVariantValue struct {
+	Id    int64  `json:"id"`
+	Value string `json:"value"`
+}
+
+// 유저에게 보여줄 SKU 정보입니다.
+// ValueIds는 옵션마다 하나씩 고른 옵션 값 아이디이며, Price는 상품 가격에 PriceDelta를 더한 가격입니다.
+type PublicProductSku struct {
+	Id         int64   `json:"id"`
+	Code       string  `json:"code"`
+	ValueIds   []int64 `json:"value_ids"`
+	PriceDelta int64   `json:"price_delta"`
+	Price      int64   `json:"price"`
+	Stock      int64   `json:"stock"`
+}
+
+// 상품의 옵션(색상, 사이즈, 트림 등) 정의를 담은 테이블입니다.
+// Position이 작은 옵션부터 보여줍니다.
+type ProductVariant struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	ProductId   int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	Name        string    `rnsql:"name"  rntype:"VARCHAR(50)"  rnopt:"NN"  json:"name"`
+	Position    int64     `rnsql:"position"  rntype:"INT"  rnopt:"NN"  json:"position"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+// 상품 옵션에서 고를 수 있는 값을 담은 테이블입니다.
+// 조회할 때 상품 단위로 가져올 수 있도록 ProductId를 함께 저장합니다.
+type ProductVariantValue struct {
+	Id        int64  `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	ProductId int64  `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	VariantId int64  `rnsql:"variant_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT_VARIANT.id"  json:"variant_id"`
+	Value     string `rnsql:"value"  rntype:"VARCHAR(100)"  rnopt:"NN"  json:"value"`
+	Position  int64  `rnsql:"position"  rntype:"INT"  rnopt:"NN"  json:"position"`
+}
+
+// 옵션 값의 조합 하나에 해당하는 판매 단위(SKU)를 담은 테이블입니다.
+// SKU가 있는 상품은 SKU마다 재고를 따로 관리하며, 가격은 상품 가격에 PriceDelta를 더한 값입니다.
+type ProductSku struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	ProductId   int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	Code        string    `rnsql:"code"  rntype:"VARCHAR(64)"  rnopt:"NN"  json:"code"`
+	PriceDelta  int64     `rnsql:"price_delta"  rntype:"BIGINT"  rnopt:"NN"  json:"price_delta"`
+	Amount      int64     `rnsql:"amount"  rntype:"BIGINT"  rnopt:"NN"  json:"amount"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+	UpdatedTime time.Time `rnsql:"updated_time"  rntype:"DATETIME"  rnopt:"NN"  json:"updated_time"`
+}
+
+// SKU가 어떤 옵션 값들의 조합인지 담은 N:M 맵입니다.
+type ProductSkuValue struct {
+	SkuId     int64 `rnsql:"sku_id"  rntype:"INT"  rnopt:"PK NN"  FK:"PRODUCT_SKU.id"  json:"sku_id"`
+	ValueId   int64 `rnsql:"value_id"  rntype:"INT"  rnopt:"PK NN"  FK:"PRODUCT_VARIANT_VALUE.id"  json:"value_id"`
+	ProductId int64 `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+}
+
+func init() {
+	AddTable("PRODUCT_VARIANT", &ProductVariant{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_VARIANT",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_VARIANT",
+		IndexName: "product_name_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+			{ColumnName: "name", ASC: true},
+		},
+	})
+
+	AddTable("PRODUCT_VARIANT_VALUE", &ProductVariantValue{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_VARIANT_VALUE",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_VARIANT_VALUE",
+		IndexName: "variant_value_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "variant_id", ASC: true},
+			{ColumnName: "value", ASC: true},
+		},
+	})
+
+	AddTable("PRODUCT_SKU", &ProductSku{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_SKU",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_SKU",
+		IndexName: "code_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "code", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_SKU",
+		IndexName: "product_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+		},
+	})
+
+	AddTable("PRODUCT_SKU_VALUE", &ProductSkuValue{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_SKU_VALUE",
+		IndexName: "product_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+		},
+	})
+}
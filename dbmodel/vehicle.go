@@ -0,0 +1,95 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/thak1411/gorn"
+)
+
+// 유저에게 보여줄 차종 정보입니다.
+type PublicVehicleModel struct {
+	Id       int64  `rnsql:"VEHICLE_MODEL.id"  json:"id"`
+	Maker    string `rnsql:"VEHICLE_MODEL.maker"  json:"maker"`
+	Model    string `rnsql:"VEHICLE_MODEL.model"  json:"model"`
+	YearFrom int64  `rnsql:"VEHICLE_MODEL.year_from"  json:"year_from"`
+	YearTo   int64  `rnsql:"VEHICLE_MODEL.year_to"  json:"year_to"`
+}
+
+// 유저에게 보여줄 내 차고(등록한 차량) 정보입니다.
+type PublicUserVehicle struct {
+	Id          int64  `rnsql:"USER_VEHICLE.id"  json:"id"`
+	VehicleId   int64  `rnsql:"VEHICLE_MODEL.id"  json:"vehicle_id"`
+	Maker       string `rnsql:"VEHICLE_MODEL.maker"  json:"maker"`
+	Model       string `rnsql:"VEHICLE_MODEL.model"  json:"model"`
+	YearFrom    int64  `rnsql:"VEHICLE_MODEL.year_from"  json:"year_from"`
+	YearTo      int64  `rnsql:"VEHICLE_MODEL.year_to"  json:"year_to"`
+	Nickname    string `rnsql:"USER_VEHICLE.nickname"  json:"nickname"`
+	CreatedTime string `rnsql:"USER_VEHICLE.created_time"  json:"created_time"`
+}
+
+// 상품이 장착될 수 있는 차종 정보를 담은 테이블입니다.
+// 같은 차종이라도 연식 구간마다 따로 등록합니다.
+type VehicleModel struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN UQ AI"  json:"id"`
+	Maker       string    `rnsql:"maker"  rntype:"VARCHAR(50)"  rnopt:"NN"  json:"maker"`
+	Model       string    `rnsql:"model"  rntype:"VARCHAR(100)"  rnopt:"NN"  json:"model"`
+	YearFrom    int64     `rnsql:"year_from"  rntype:"INT"  rnopt:"NN"  json:"year_from"`
+	YearTo      int64     `rnsql:"year_to"  rntype:"INT"  rnopt:"NN"  json:"year_to"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+// 상품이 어떤 차종에 장착될 수 있는지 담은 N:M 맵입니다.
+type ProductVehicleMap struct {
+	ProductId int64 `rnsql:"product_id"  rntype:"INT"  rnopt:"PK NN"  FK:"PRODUCT.id"  json:"product_id"`
+	VehicleId int64 `rnsql:"vehicle_id"  rntype:"INT"  rnopt:"PK NN"  FK:"VEHICLE_MODEL.id"  json:"vehicle_id"`
+}
+
+// 유저가 내 차고에 등록한 차량 정보를 담은 테이블입니다.
+type UserVehicle struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN AI"  json:"id"`
+	UserId      int64     `rnsql:"user_id"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"user_id"`
+	VehicleId   int64     `rnsql:"vehicle_id"  rntype:"INT"  rnopt:"NN"  FK:"VEHICLE_MODEL.id"  json:"vehicle_id"`
+	Nickname    string    `rnsql:"nickname"  rntype:"VARCHAR(50)"  rnopt:"NN"  json:"nickname"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+func init() {
+	AddTable("VEHICLE_MODEL", &VehicleModel{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "VEHICLE_MODEL",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "VEHICLE_MODEL",
+		IndexName: "model_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "maker", ASC: true},
+			{ColumnName: "model", ASC: true},
+			{ColumnName: "year_from", ASC: true},
+		},
+	})
+	AddTable("PRODUCT_VEHICLE_MAP", &ProductVehicleMap{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "PRODUCT_VEHICLE_MAP",
+		IndexName: "vehicle_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "vehicle_id", ASC: true},
+		},
+	})
+	AddTable("USER_VEHICLE", &UserVehicle{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "USER_VEHICLE",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "user_id", ASC: true},
+			{ColumnName: "vehicle_id", ASC: true},
+		},
+	})
+}
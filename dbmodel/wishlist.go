@@ -0,0 +1,49 @@
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/thak1411/gorn"
+)
+
+// 유저에게 보여줄 찜 목록에 들어갈 정보를 담은 테이블입니다.
+type PublicWishlist struct {
+	Id           int64  `rnsql:"WISHLIST.id"  json:"id"`
+	ProductId    int64  `rnsql:"PRODUCT.id"  json:"product_id"`
+	BrandId      int64  `rnsql:"BRAND.id"  json:"brand_id"`
+	BrandName    string `rnsql:"BRAND.name"  json:"brand_name"`
+	ProductName  string `rnsql:"PRODUCT.name"  json:"product_name"`
+	ProductPrice int64  `rnsql:"PRODUCT.price"  json:"product_price"`
+	Stock        int64  `rnsql:"PRODUCT.amount"  json:"stock"`
+	TitleImageS3 string `rnsql:"PRODUCT.title_image_s3"  json:"title_image_s3"`
+	CreatedTime  string `rnsql:"WISHLIST.created_time"  json:"created_time"`
+}
+
+// 유저가 나중에 보려고 찜해둔 상품 정보를 담은 N:M 맵입니다.
+type Wishlist struct {
+	Id          int64     `rnsql:"id"  rntype:"INT"  rnopt:"PK NN AI"  json:"id"`
+	UserId      int64     `rnsql:"user_id"  rntype:"INT"  rnopt:"NN"  FK:"USER.id"  json:"user_id"`
+	ProductId   int64     `rnsql:"product_id"  rntype:"INT"  rnopt:"NN"  FK:"PRODUCT.id"  json:"product_id"`
+	CreatedTime time.Time `rnsql:"created_time"  rntype:"DATETIME"  rnopt:"NN"  json:"created_time"`
+}
+
+func init() {
+	AddTable("WISHLIST", &Wishlist{})
+	AddIndex(&gorn.DBIndex{
+		TableName: "WISHLIST",
+		IndexName: "id_UNIQUE",
+		IndexType: gorn.DBIndexTypeUnique,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "user_id", ASC: true},
+			{ColumnName: "product_id", ASC: true},
+		},
+	})
+	AddIndex(&gorn.DBIndex{
+		TableName: "WISHLIST",
+		IndexName: "product_id_INDEX",
+		IndexType: gorn.DBIndexTypeIndex,
+		Columns: []*gorn.DBIndexColumn{
+			{ColumnName: "product_id", ASC: true},
+		},
+	})
+}
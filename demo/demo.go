@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/JongGeonClass/JGC-API/database"
 	"github.com/JongGeonClass/JGC-API/dbmodel"
@@ -11,13 +14,108 @@ import (
 	"github.com/thak1411/rnlog"
 )
 
+// 데모 데이터 명령입니다.
+const (
+	ActionGenerate = "generate"
+	ActionRemove   = "remove"
+	ActionReset    = "reset"
+)
+
+// 기본 데모 데이터의 개수입니다.
+const (
+	fixtureUsers    = 6
+	fixtureBrands   = 4
+	fixtureProducts = 20
+	fixtureReviews  = 3
+)
+
+// 데모 상품 이미지와 설명 파일의 개수입니다. (demo/title, demo/description)
+// 상품이 더 많다면 파일을 돌려가며 사용합니다.
+const fixtureFiles = 20
+
+// 데모 데이터를 만들 때 사용할 옵션입니다.
+// 개수가 기본 데모 데이터보다 많다면 나머지는 번호를 붙여서 만들고, 적다면 앞에서부터 그만큼만 만듭니다.
+// 같은 Seed와 개수로 만들면 항상 같은 데이터가 만들어집니다.
+type Options struct {
+	Seed     int64
+	Users    int
+	Brands   int
+	Products int
+	// 상품마다 작성할 리뷰 수입니다.
+	Reviews int
+}
+
+// 기본 데모 데이터와 같은 개수의 옵션을 반환합니다.
+func DefaultOptions() *Options {
+	return &Options{
+		Seed:     1,
+		Users:    fixtureUsers,
+		Brands:   fixtureBrands,
+		Products: fixtureProducts,
+		Reviews:  fixtureReviews,
+	}
+}
+
+// 옵션이 올바른지 확인합니다.
+// 브랜드와 리뷰는 유저를, 상품은 브랜드를 참조하므로 하나 이상 있어야 합니다.
+func (o *Options) validate() error {
+	if o.Users < 1 || o.Brands < 1 {
+		return fmt.Errorf("demo needs at least one user and one brand")
+	}
+	if o.Products < 0 || o.Reviews < 0 {
+		return fmt.Errorf("demo products and reviews must not be negative")
+	}
+	return nil
+}
+
+// 기본 데모 데이터에 정해진 아이디가 있다면 사용하고, 없거나 만들지 않는 아이디라면 1부터 n까지 돌려가며 고릅니다.
+// i는 1부터 시작하는 번호입니다.
+func pick(fixture []int64, i, n int) int64 {
+	if i <= len(fixture) && fixture[i-1] <= int64(n) {
+		return fixture[i-1]
+	}
+	return int64((i-1)%n + 1)
+}
+
+// 데모 데이터 명령을 실행합니다.
+// reset은 모든 데이터를 삭제한 뒤 다시 생성합니다.
+func Run(
+	userdb database.UserDatabase,
+	productdb database.ProductDatabase,
+	demodb database.DemoDatabase,
+	action string,
+	opt *Options,
+) error {
+	switch action {
+	case ActionGenerate:
+		return Generate(userdb, productdb, demodb, opt)
+	case ActionRemove:
+		return Remove(demodb)
+	case ActionReset:
+		if err := Remove(demodb); err != nil {
+			return err
+		}
+		return Generate(userdb, productdb, demodb, opt)
+	}
+	return fmt.Errorf("unknown demo command: %s (generate|remove|reset)", action)
+}
+
 // 디비에 데모 데이터를 생성합니다.
-// 이때 없는 데이터만 추가로 생성합니다.
+// 이때 없는 데이터만 추가로 생성하므로 여러 번 실행해도 됩니다.
+// 건너뛴 데이터도 난수는 똑같이 뽑으므로, 같은 Seed라면 나머지 데이터도 항상 같은 값으로 만들어집니다.
 func Generate(
 	userdb database.UserDatabase,
 	productdb database.ProductDatabase,
+	demodb database.DemoDatabase,
+	opt *Options,
 ) error {
 	ctx := context.Background()
+	if err := opt.validate(); err != nil {
+		return err
+	}
+	rng := rand.New(rand.NewSource(opt.Seed))
+	rnlog.Info("Generating demo data... (seed: %d, users: %d, brands: %d, products: %d, reviews: %d)",
+		opt.Seed, opt.Users, opt.Brands, opt.Products, opt.Reviews)
 
 	// 유저 데이터 생성
 	// id: morgan
@@ -78,18 +176,48 @@ func Generate(
 			Salt:     "demo_salt",
 		},
 	}
+	// 나머지 유저의 아이디와 비밀번호는 user{번호}입니다.
+	for i := len(users) + 1; i <= opt.Users; i++ {
+		username := fmt.Sprintf("user%d", i)
+		users = append(users, &dbmodel.User{
+			Id:       int64(i),
+			Email:    username + "@demo.jgc",
+			Nickname: fmt.Sprintf("User%d", i),
+			Username: username,
+			Password: util.Encrypt256(username, "demo_salt"),
+			Salt:     "demo_salt",
+		})
+	}
 	rnlog.Info("Generating demo users...")
-	for _, v := range users {
+	existing, err := demodb.GetIds(ctx, "USER", "id")
+	if err != nil {
+		rnlog.Error("Error while getting users: %v", err)
+		return err
+	}
+	added := 0
+	for _, v := range users[:opt.Users] {
+		if existing[v.Id] {
+			continue
+		}
 		if _, err := userdb.AddUser(ctx, v); err != nil {
 			rnlog.Error("Error while adding user: %v", err)
 			return err
 		}
+		added++
 	}
+	rnlog.Info("Added %d demo users, skipped %d", added, opt.Users-added)
 
 	// 카테고리 데이터 생성
 	rnlog.Info("Generating demo categories...")
 	categoryName := []string{"허드(HUD)", "네비게이션", "음향 기기", "의자", "카페트", "시트", "핸들", "방향제", "소품"}
-	for i := 1; i <= 9; i++ {
+	if existing, err = demodb.GetIds(ctx, "CATEGORY", "id"); err != nil {
+		rnlog.Error("Error while getting categories: %v", err)
+		return err
+	}
+	for i := 1; i <= len(categoryName); i++ {
+		if existing[int64(i)] {
+			continue
+		}
 		if _, err := productdb.AddCategory(ctx, &dbmodel.Category{
 			Id:          int64(i),
 			Name:        categoryName[i-1],
@@ -107,17 +235,32 @@ func Generate(
 	brandEmail := []string{
 		"root@mobis.com", "root@hyundai.com", "root@kia.com", "root@mobis.com",
 	}
-	for i := 1; i <= 4; i++ {
-		if _, err := productdb.AddBrand(ctx, &dbmodel.Brand{
+	if existing, err = demodb.GetIds(ctx, "BRAND", "id"); err != nil {
+		rnlog.Error("Error while getting brands: %v", err)
+		return err
+	}
+	added = 0
+	for i := 1; i <= opt.Brands; i++ {
+		if existing[int64(i)] {
+			continue
+		}
+		brand := &dbmodel.Brand{
 			Id:     int64(i),
-			UserId: brandOwner[i-1],
-			Name:   brandName[i-1],
-			Email:  brandEmail[i-1],
-		}); err != nil {
+			UserId: pick(brandOwner, i, opt.Users),
+			Name:   fmt.Sprintf("Demo Brand %d", i),
+			Email:  fmt.Sprintf("brand%d@demo.jgc", i),
+		}
+		if i <= len(brandName) {
+			brand.Name = brandName[i-1]
+			brand.Email = brandEmail[i-1]
+		}
+		if _, err := productdb.AddBrand(ctx, brand); err != nil {
 			rnlog.Error("Error while adding brand: %v", err)
 			return err
 		}
+		added++
 	}
+	rnlog.Info("Added %d demo brands, skipped %d", added, opt.Brands-added)
 
 	// 데모 상품 추가
 	rnlog.Info("Generating demo products...")
@@ -135,56 +278,93 @@ func Generate(
 		"Kia High Quality Speaker - JFIE385729", "Kia Miller Chair - JGKS38472", "Kia Very Soft Carpet - JHIKS348724", "Kia Ultra Comfortable Sheat - GFJ3548724",
 		"Kia Best Driver Handle - SFD2587", "Kia Malon Diffuser - JGIS58276", "Kia Key Ring - JHI34872", "Kia Logo - OITU39571",
 	}
-	for i := 1; i <= 20; i++ {
-		if _, err := productdb.AddProduct(ctx, &dbmodel.Product{
+	if existing, err = demodb.GetIds(ctx, "PRODUCT", "id"); err != nil {
+		rnlog.Error("Error while getting products: %v", err)
+		return err
+	}
+	added = 0
+	for i := 1; i <= opt.Products; i++ {
+		// 건너뛰더라도 난수는 뽑아야 다음 상품의 값이 바뀌지 않습니다.
+		price := int64(rng.Int31n(1000000) + 10000)
+		amount := int64(rng.Int31n(10000) + 1)
+		if existing[int64(i)] {
+			continue
+		}
+		file := (i-1)%fixtureFiles + 1
+		product := &dbmodel.Product{
 			Id:            int64(i),
-			BrandId:       brandId[i-1],
-			Name:          productName[i-1],
-			Price:         int64(rand.Int31n(1000000) + 10000),
-			Amount:        int64(rand.Int31n(10000) + 1),
-			TitleImageS3:  fmt.Sprintf("https://jgc-product-bucket.s3.us-east-2.amazonaws.com/title/%d.jpg", i),
-			DescriptionS3: fmt.Sprintf("https://jgc-product-bucket.s3.us-east-2.amazonaws.com/description/%d.txt", i),
-		}); err != nil {
+			BrandId:       pick(brandId, i, opt.Brands),
+			Name:          fmt.Sprintf("Demo Product %d", i),
+			Price:         price,
+			Amount:        amount,
+			TitleImageS3:  fmt.Sprintf("https://jgc-product-bucket.s3.us-east-2.amazonaws.com/title/%d.jpg", file),
+			DescriptionS3: fmt.Sprintf("https://jgc-product-bucket.s3.us-east-2.amazonaws.com/description/%d.txt", file),
+		}
+		if i <= len(productName) {
+			product.Name = productName[i-1]
+		}
+		if _, err := productdb.AddProduct(ctx, product); err != nil {
 			rnlog.Error("Error while adding product: %v", err)
 			return err
 		}
+		added++
 	}
+	rnlog.Info("Added %d demo products, skipped %d", added, opt.Products-added)
 
 	// 데모 리뷰 추가
+	// 상품마다 첫 리뷰는 5점이고, 두 번째 리뷰는 첫 리뷰의 답글입니다.
 	rnlog.Info("Generating demo reviews...")
 	reviewUser := []int64{4, 5, 6}
 	reviewContent := []string{"좋은 상품 배달 잘 받았습니다.\n포장 상태도 양호하고 배달도 아주 빠르게 잘 도착했습니다.\n\n제품 퀄리티도 매우 좋아서 잘 사용하겠습니다. 감사합니다.",
 		"저도 위 댓글 내용에 공감합니다. 감사합니다. 잘 사용하겠습니다.", "test"}
-	for i := 1; i <= 20; i++ {
-		reviewId := int64(0)
+	if existing, err = demodb.GetIds(ctx, "REVIEW", "id"); err != nil {
+		rnlog.Error("Error while getting reviews: %v", err)
+		return err
+	}
+	existingStatistics, err := demodb.GetIds(ctx, "PRODUCT_STATISTICS", "product_id")
+	if err != nil {
+		rnlog.Error("Error while getting product statistics: %v", err)
+		return err
+	}
+	added = 0
+	for i := 1; i <= opt.Products; i++ {
+		firstReviewId := int64((i-1)*opt.Reviews + 1)
 		sumScore := int64(0)
-		for j := 1; j <= 3; j++ {
-			sc := rand.Int63n(3) + 3
+		for j := 1; j <= opt.Reviews; j++ {
+			sc := rng.Int63n(3) + 3
 			if j == 1 {
 				sc = 5
 			}
+			sumScore += sc
 			review := &dbmodel.Review{
-				Id:        int64((i-1)*3 + j),
+				Id:        int64((i-1)*opt.Reviews + j),
 				ProductId: int64(i),
-				UserId:    reviewUser[j-1],
+				UserId:    pick(reviewUser, j, opt.Users),
 				Score:     sc,
-				Content:   reviewContent[j-1],
+				Content:   reviewContent[(j-1)%len(reviewContent)],
+			}
+			if j > len(reviewUser) {
+				review.UserId = int64((i+j-2)%opt.Users + 1)
 			}
-			sumScore += sc
 			if j == 2 {
-				review.ParentReviewId = reviewId
+				review.ParentReviewId = firstReviewId
+			}
+			if existing[review.Id] {
+				continue
 			}
-			id, err := productdb.AddReview(ctx, review)
-			reviewId = id
-			if err != nil {
+			if _, err := productdb.AddReview(ctx, review); err != nil {
 				rnlog.Error("Error while adding review: %v", err)
 				return err
 			}
+			added++
 		}
 		// 데모 통계 페이지 추가
+		if existingStatistics[int64(i)] {
+			continue
+		}
 		statistics := &dbmodel.ProductStatistics{
 			ProductId:      int64(i),
-			ReviewCount:    3,
+			ReviewCount:    int64(opt.Reviews),
 			SumReviewScore: sumScore,
 			SoldQuantity:   0,
 		}
@@ -194,29 +374,22 @@ func Generate(
 			return err
 		}
 	}
-
-	// 데모 통계 페이지 추가
-	// rnlog.Info("Generating demo statistics pages...")
-	// for i := 1; i <= 20; i++ {
-	// 	err := productdb.AddProductStatistics(ctx, &dbmodel.ProductStatistics{
-	// 		ProductId:      int64(i),
-	// 		ReviewCount:    0,
-	// 		SumReviewScore: 0,
-	// 		SoldQuantity:   0,
-	// 	})
-	// 	if err != nil {
-	// 		rnlog.Error("Error while adding product statistics: %v", err)
-	// 		return err
-	// 	}
-	// }
+	rnlog.Info("Added %d demo reviews, skipped %d", added, opt.Products*opt.Reviews-added)
 
 	// 데모 상품 카테고리 추가
 	rnlog.Info("Generating demo product categories...")
 	categoryId := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 9, 1, 2, 3, 4, 5, 6, 7, 8, 9, 9}
-	for i := 1; i <= 20; i++ {
+	if existing, err = demodb.GetIds(ctx, "PRODUCT_CATEGORY_MAP", "product_id"); err != nil {
+		rnlog.Error("Error while getting product categories: %v", err)
+		return err
+	}
+	for i := 1; i <= opt.Products; i++ {
+		if existing[int64(i)] {
+			continue
+		}
 		err := productdb.AddProductCategory(ctx, &dbmodel.ProductCategoryMap{
 			ProductId:  int64(i),
-			CategoryId: categoryId[i-1],
+			CategoryId: pick(categoryId, i, len(categoryName)),
 		})
 		if err != nil {
 			rnlog.Error("Error while adding product category: %v", err)
@@ -227,60 +400,74 @@ func Generate(
 	return nil
 }
 
-// 디비에 존재하는 데모 데이터를 삭제합니다.
-func Remove(
-	userdb database.UserDatabase,
-	productdb database.ProductDatabase,
-) error {
-	ctx := context.Background()
-
-	// 상품 카테고리 데이터 삭제
-	rnlog.Info("Removing demo product categories...")
-	if err := productdb.DeleteAllProductCategoryMap(ctx); err != nil {
-		rnlog.Error("Error while deleting product category: %v", err)
-		return err
-	}
-
-	// 상품 통계 데이터 삭제
-	rnlog.Info("Removing demo product statistics...")
-	if err := productdb.DeleteAllProductStatistics(ctx); err != nil {
-		rnlog.Error("Error while deleting product statistics: %v", err)
-		return err
-	}
-
-	// 모든 리뷰 삭제
-	rnlog.Info("Removing demo reviews...")
-	if err := productdb.DeleteAllReviews(ctx); err != nil {
-		rnlog.Error("Error while deleting review: %v", err)
-		return err
-	}
-
-	// 상품 데이터 삭제
-	rnlog.Info("Removing demo products...")
-	if err := productdb.DeleteAllProducts(ctx); err != nil {
-		rnlog.Error("Error while deleting product: %v", err)
-		return err
-	}
-
-	// 브랜드 데이터 삭제
-	rnlog.Info("Removing demo brands...")
-	if err := productdb.DeleteAllBrands(ctx); err != nil {
-		rnlog.Error("Error while deleting brand: %v", err)
-		return err
+// 외래 키가 가리키는 테이블보다 먼저 지워야 하는 순서로 dbmodel에 등록된 테이블 이름을 반환합니다.
+// 다른 테이블이 가리키지 않는 테이블부터 지우며, 순서가 정해지지 않는 테이블끼리는 이름 순서입니다.
+func deleteOrder() []string {
+	tables, tableNames := dbmodel.GetTables()
+	// referencedBy[a][b]는 b 테이블이 a 테이블을 가리킨다는 뜻입니다.
+	referencedBy := make(map[string]map[string]bool)
+	for i, table := range tables {
+		target := reflect.TypeOf(table)
+		if target.Kind() == reflect.Ptr {
+			target = target.Elem()
+		}
+		for j := 0; j < target.NumField(); j++ {
+			fk, ok := target.Field(j).Tag.Lookup("FK")
+			if !ok {
+				continue
+			}
+			parent := strings.Split(fk, ".")[0]
+			if parent == tableNames[i] {
+				continue
+			}
+			if referencedBy[parent] == nil {
+				referencedBy[parent] = make(map[string]bool)
+			}
+			referencedBy[parent][tableNames[i]] = true
+		}
 	}
 
-	// 카테고리 데이터 삭제
-	rnlog.Info("Removing demo categories...")
-	if err := productdb.DeleteAllCategories(ctx); err != nil {
-		rnlog.Error("Error while deleting category: %v", err)
-		return err
+	remaining := append([]string{}, tableNames...)
+	sort.Strings(remaining)
+	deleted := make(map[string]bool)
+	result := []string{}
+	for len(remaining) > 0 {
+		next := []string{}
+		for _, name := range remaining {
+			ready := true
+			for child := range referencedBy[name] {
+				if !deleted[child] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				result = append(result, name)
+				deleted[name] = true
+			} else {
+				next = append(next, name)
+			}
+		}
+		// 서로를 가리키는 테이블이 있다면 더 이상 순서를 정할 수 없으므로 남은 순서대로 지웁니다.
+		if len(next) == len(remaining) {
+			return append(result, next...)
+		}
+		remaining = next
 	}
+	return result
+}
 
-	// 유저 데이터 삭제
-	rnlog.Info("Removing demo users...")
-	if err := userdb.DeleteAllUsers(ctx); err != nil {
-		rnlog.Error("Error while deleting user: %v", err)
-		return err
+// 디비에 존재하는 데모 데이터를 삭제합니다.
+// 데모 데이터와 사용자가 만든 데이터를 구분하지 않으므로 dbmodel에 등록된 모든 테이블을 비웁니다.
+// 마이그레이션 기록은 등록된 테이블이 아니므로 남겨둡니다.
+func Remove(demodb database.DemoDatabase) error {
+	ctx := context.Background()
+	for _, name := range deleteOrder() {
+		rnlog.Info("Removing demo data from %s...", name)
+		if err := demodb.DeleteAll(ctx, name); err != nil {
+			rnlog.Error("Error while deleting %s: %v", name, err)
+			return err
+		}
 	}
 	return nil
 }
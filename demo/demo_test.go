@@ -0,0 +1,42 @@
+package demo
+
+import "testing"
+
+func TestPick(t *testing.T) {
+	fixture := []int64{1, 2, 3, 1}
+	tests := []struct {
+		name string
+		i    int
+		n    int
+		want int64
+	}{
+		{"fixture", 3, 3, 3},
+		{"fixture with more ids", 4, 10, 1},
+		{"fixture id not created", 3, 2, 1},
+		{"after fixture", 5, 3, 2},
+		{"wraps around", 7, 3, 1},
+		{"single id", 9, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pick(fixture, tt.i, tt.n); got != tt.want {
+				t.Errorf("pick(%v, %d, %d) = %d, want %d", fixture, tt.i, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickIsDeterministic(t *testing.T) {
+	fixture := []int64{2, 1}
+	for n := 1; n <= 5; n++ {
+		for i := 1; i <= 20; i++ {
+			got := pick(fixture, i, n)
+			if got < 1 || got > int64(n) {
+				t.Fatalf("pick(%v, %d, %d) = %d, out of [1, %d]", fixture, i, n, got, n)
+			}
+			if again := pick(fixture, i, n); again != got {
+				t.Fatalf("pick(%v, %d, %d) = %d then %d", fixture, i, n, got, again)
+			}
+		}
+	}
+}
@@ -11,3 +11,6 @@ require (
 )
 
 require github.com/go-sql-driver/mysql v1.7.0 // indirect
+
+// gorn v1.2.4에 필요한 공개 API를 추가한 사본을 사용합니다. (third_party/gorn/PATCHES.MD)
+replace github.com/thak1411/gorn => ./third_party/gorn
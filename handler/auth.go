@@ -6,9 +6,12 @@ import (
 	"time"
 
 	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/logger"
+	"github.com/JongGeonClass/JGC-API/model"
 	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/JongGeonClass/JGC-API/util"
 	"github.com/thak1411/gorn"
-	"github.com/thak1411/rnlog"
 )
 
 // Auth Hanlder의 구현체입니다.
@@ -60,27 +63,22 @@ func (h *AuthHandler) SignUp(c *gorn.Context) {
 
 	// 회원 가입 로직을 실행합니다.
 	ctx := c.GetContext()
-	userId, err := h.uc.SignUp(ctx, body.Email, body.Nickname, body.Username, body.Password)
-	if err != nil {
-		rnlog.Error("SignUp error: %+v", err)
-		c.SendInternalServerError()
+	if _, err := h.uc.SignUp(ctx, body.Email, body.Nickname, body.Username, body.Password); err != nil {
+		sendError(c, err, "SignUp")
 		return
-	} else if userId == -1 {
-		res.Code = 8001
-	} else if userId == -2 {
-		res.Code = 8002
 	}
 	c.SendJson(http.StatusOK, res)
 }
 
 // 로그인을 시도합니다.
-// 성공하면 토큰을 발급하고, 실패하면 에러를 반환합니다.
+// 성공하면 토큰을 발급하고, 아이디가 없거나 비밀번호가 틀렸다면 INVALID_CREDENTIALS 에러를 응답합니다.
 // 로그인에 성공했을 시에 쿠키를 생성합니다.
 // 토큰과 쿠키의 유효기간은 일주일로 설정합니다.
 func (h *AuthHandler) Login(c *gorn.Context) {
 	type Response struct { // 반환 타입
-		Code  int    `json:"code"`
-		Token string `json:"token"`
+		Code  int                      `json:"code"`
+		Token string                   `json:"token"`
+		Cart  *dbmodel.CartMergeResult `json:"cart"`
 	}
 	type Body struct { // Body 파라미터 타입
 		Username string `json:"username"`
@@ -94,7 +92,7 @@ func (h *AuthHandler) Login(c *gorn.Context) {
 		{4, 30}, // Username Length
 		{4, 30}, // Password Length
 	}
-	res := &Response{8000, ""}
+	res := &Response{8000, "", nil}
 	conf := config.Get()
 	body := &Body{}
 	if err := c.BindJsonBody(body); err != nil {
@@ -117,34 +115,58 @@ func (h *AuthHandler) Login(c *gorn.Context) {
 	ctx := c.GetContext()
 	token, err := h.uc.Login(ctx, body.Username, body.Password)
 	if err != nil {
-		rnlog.Error("Login error: %+v", err)
-		c.SendInternalServerError()
+		sendError(c, err, "Login")
 		return
-	} else if token != "" {
-		// 쿠키를 설정합니다.
-		cookie := &http.Cookie{
-			Name:     conf.Cookies.SessionName,
-			Path:     "/",
-			Expires:  time.Now().Add(conf.Cookies.SessionTimeout), // 쿠키 유효기간은 일주일입니다.
-			Value:    token,
-			HttpOnly: true,
-		}
-		// 퍼블릿 유저 쿠키를 설정합니다.
-		// 이 쿠키는 유저의 정보를 가져올 때 사용합니다.
-		cookie2 := &http.Cookie{
-			Name:    conf.Cookies.PublicSessionName,
-			Path:    "/",
-			Expires: time.Now().Add(conf.Cookies.SessionTimeout), // 쿠키 유효기간은 일주일입니다.
-			Value:   strings.Split(token, ".")[1],
-		}
-		c.SetCookie(cookie)
-		c.SetCookie(cookie2)
-		res.Token = token
-		c.SendJson(http.StatusOK, res)
-	} else {
-		res.Code = 8001
-		c.SendJson(http.StatusUnauthorized, res)
 	}
+	// 쿠키를 설정합니다.
+	cookie := &http.Cookie{
+		Name:     conf.Cookies.SessionName,
+		Path:     "/",
+		Expires:  time.Now().Add(conf.Cookies.SessionTimeout), // 쿠키 유효기간은 일주일입니다.
+		Value:    token,
+		HttpOnly: true,
+	}
+	// 퍼블릿 유저 쿠키를 설정합니다.
+	// 이 쿠키는 유저의 정보를 가져올 때 사용합니다.
+	cookie2 := &http.Cookie{
+		Name:    conf.Cookies.PublicSessionName,
+		Path:    "/",
+		Expires: time.Now().Add(conf.Cookies.SessionTimeout), // 쿠키 유효기간은 일주일입니다.
+		Value:   strings.Split(token, ".")[1],
+	}
+	c.SetCookie(cookie)
+	c.SetCookie(cookie2)
+	res.Token = token
+	res.Cart = h.mergeGuestCart(c, token)
+	c.SendJson(http.StatusOK, res)
+}
+
+// 게스트 쿠키가 있다면 게스트 장바구니를 방금 로그인한 유저의 장바구니로 합칩니다.
+// 합치는 데 실패해도 게스트 장바구니는 남아있으므로 로그인은 성공시키고 nil을 반환합니다.
+// 합치는 데 성공하면 게스트 쿠키를 삭제합니다.
+func (h *AuthHandler) mergeGuestCart(c *gorn.Context, token string) *dbmodel.CartMergeResult {
+	conf := config.Get()
+	guest, err := c.GetCookie(conf.Cookies.GuestSessionName)
+	if err != nil || !util.IsUuid(guest.Value) {
+		return nil
+	}
+	_, claims, err := util.AuthUserToken(token, conf.Jwt.SecretKey)
+	if err != nil {
+		logger.Error(c.GetContext(), "merge guest cart token error: %+v", err)
+		return nil
+	}
+	userId := claims.(model.AuthUserTokenClaims).Id
+	result, err := h.uc.MergeGuestCart(c.GetContext(), userId, guest.Value)
+	if err != nil {
+		logger.Error(c.GetContext(), "merge guest cart error: %+v", err)
+		return nil
+	}
+	c.SetCookie(&http.Cookie{
+		Name:   conf.Cookies.GuestSessionName,
+		Path:   "/",
+		MaxAge: -1,
+	})
+	return result
 }
 
 // 로그아웃 시킵니다.
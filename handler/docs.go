@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/thak1411/gorn"
+)
+
+// API 문서를 보여주는 Swagger UI 페이지입니다.
+// swagger-ui-dist는 CDN에서 버전을 고정해서 불러옵니다.
+const swaggerPage = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="utf-8" />
+  <meta name="viewport" content="width=device-width, initial-scale=1" />
+  <title>JGC API</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.17.14/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.17.14/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({
+        url: "/api/openapi.json",
+        dom_id: "#swagger-ui",
+        withCredentials: true,
+      });
+    };
+  </script>
+</body>
+</html>
+`
+
+// Docs Handler의 구현체입니다.
+// 명세는 서버가 뜰 때 한 번 만들어 두고 그대로 내려줍니다.
+type DocsHandler struct {
+	spec json.RawMessage
+}
+
+// API 명세를 OpenAPI 3 JSON으로 가져옵니다.
+func (h *DocsHandler) OpenApi(c *gorn.Context) {
+	c.SendJson(http.StatusOK, h.spec)
+}
+
+// API 명세를 Swagger UI로 보여줍니다.
+func (h *DocsHandler) SwaggerUi(c *gorn.Context) {
+	c.SendHtml(http.StatusOK, swaggerPage)
+}
+
+// Docs Handler를 반환합니다.
+func NewDocs(spec []byte) *DocsHandler {
+	return &DocsHandler{spec}
+}
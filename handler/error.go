@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JongGeonClass/JGC-API/logger"
+	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/thak1411/gorn"
+)
+
+// 에러 응답의 바디입니다.
+// 프론트엔드는 HTTP 상태 코드가 아닌 code로 실패 이유를 구분해야 합니다.
+type ErrorBody struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// 에러 응답 타입입니다.
+type ErrorResponse struct {
+	Error *ErrorBody `json:"error"`
+}
+
+// 에러 코드 목록의 한 줄입니다.
+type ErrorCatalogEntry struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// 에러 종류에 맞는 HTTP 상태 코드를 반환합니다.
+func errorStatus(kind usecase.ErrorKind) int {
+	switch kind {
+	case usecase.KindInvalid:
+		return http.StatusBadRequest
+	case usecase.KindUnauthorized:
+		return http.StatusUnauthorized
+	case usecase.KindForbidden:
+		return http.StatusForbidden
+	case usecase.KindNotFound:
+		return http.StatusNotFound
+	case usecase.KindConflict:
+		return http.StatusConflict
+	case usecase.KindTooLarge:
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusInternalServerError
+}
+
+// 에러 응답 형식을 컨텍스트에 담을 때 사용하는 키입니다.
+const typedErrorsKey = "typed-errors"
+
+// 이후의 핸들러가 에러를 v2 형식({"error": {...}})으로 응답하도록 표시하는 미들웨어입니다.
+// 이 미들웨어를 거치지 않은 요청은 기존 프론트엔드가 사용하던 v1 형식({"code": 8001})으로 응답합니다.
+func TypedErrors(c *gorn.Context) {
+	c.SetValue(typedErrorsKey, true)
+}
+
+// 유스케이스에서 받은 에러를 응답합니다.
+// *usecase.Error라면 v1 요청에는 legacyCodes에 적힌 코드를, v2 요청에는 종류에 맞는 HTTP 상태 코드와 에러 코드를 응답합니다.
+// 그 외의 에러는 예상하지 못한 에러이므로 "<what> error: ..." 로그를 남기고 500을 응답합니다.
+func sendError(c *gorn.Context, err error, what string) {
+	var uerr *usecase.Error
+	if !errors.As(err, &uerr) {
+		logger.Error(c.GetContext(), "%s error: %+v", what, err)
+		c.SendInternalServerError()
+		return
+	}
+	if typed, _ := c.GetValue(typedErrorsKey).(bool); !typed && sendLegacyError(c, uerr, what) {
+		return
+	}
+	c.SendJson(errorStatus(uerr.Kind), &ErrorResponse{&ErrorBody{
+		Code:    uerr.Code,
+		Message: uerr.Message,
+		Details: uerr.Details,
+	}})
+}
+
+// 유스케이스에 정의된 모든 에러를 에러 코드 목록으로 만들어 반환합니다.
+// 프론트엔드에 넘겨줄 에러 코드 목록을 만들 때 사용합니다.
+func ErrorCatalog() []*ErrorCatalogEntry {
+	errs := usecase.Errors()
+	result := make([]*ErrorCatalogEntry, 0, len(errs))
+	for _, v := range errs {
+		result = append(result, &ErrorCatalogEntry{
+			Code:    v.Code,
+			Status:  errorStatus(v.Kind),
+			Kind:    string(v.Kind),
+			Message: v.Message,
+		})
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/metrics"
+	"github.com/JongGeonClass/JGC-API/model"
+	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/thak1411/gorn"
+)
+
+// Health Handler의 구현체입니다.
+// 배포 파이프라인과 로드밸런서가 호출하는 엔드포인트이므로 인증 없이 사용할 수 있어야 합니다.
+type HealthHandler struct {
+	uc usecase.HealthUsecase
+}
+
+// 서버 프로세스가 살아있는지 확인합니다.
+// 디비처럼 외부 의존성은 확인하지 않으며, 응답할 수 있다면 항상 200을 반환합니다.
+func (h *HealthHandler) Healthz(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Status string `json:"status"`
+	}
+	c.SendJson(http.StatusOK, &Response{"ok"})
+}
+
+// 서버가 요청을 받을 준비가 되었는지 확인합니다.
+// 모든 검사를 통과하면 200을, 하나라도 실패하면 503을 검사 결과와 함께 반환합니다.
+func (h *HealthHandler) Readyz(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Status string                  `json:"status"`
+		Checks []*model.ReadinessCheck `json:"checks"`
+	}
+	checks, ready := h.uc.CheckReadiness(c.GetContext())
+	if !ready {
+		c.SendJson(http.StatusServiceUnavailable, &Response{"unavailable", checks})
+		return
+	}
+	c.SendJson(http.StatusOK, &Response{"ok", checks})
+}
+
+// 배포된 서버의 빌드 정보를 가져옵니다.
+func (h *HealthHandler) Version(c *gorn.Context) {
+	c.SendJson(http.StatusOK, h.uc.GetVersion())
+}
+
+// 서버 메트릭을 Prometheus 텍스트 형식으로 내보냅니다.
+// METRICS_TOKEN이 설정되어 있다면 Authorization: Bearer 헤더로 같은 토큰을 보내야 합니다.
+func (h *HealthHandler) Metrics(c *gorn.Context) {
+	conf := config.Get()
+	if conf.MetricsToken != "" {
+		expected := []byte("Bearer " + conf.MetricsToken)
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+			c.SendNotAuthorized()
+			return
+		}
+	}
+	sb := &strings.Builder{}
+	metrics.Write(sb)
+	c.SendPlainText(http.StatusOK, sb.String())
+}
+
+// Health Handler를 반환합니다.
+func NewHealth(uc usecase.HealthUsecase) *HealthHandler {
+	return &HealthHandler{uc}
+}
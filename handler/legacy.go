@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/thak1411/gorn"
+)
+
+// v1 에러 응답 타입입니다.
+// v1은 실패해도 대부분 200을 응답하며, 프론트엔드는 code(8001, 8002, ...)로 실패 이유를 구분합니다.
+// 입력 데이터 검증에 실패했다면 errors에 실패한 위치와 이유를 담습니다.
+type legacyErrorResponse struct {
+	Code   int         `json:"code"`
+	Errors interface{} `json:"errors,omitempty"`
+}
+
+// 유스케이스 에러 하나를 v1 코드로 바꾸는 규칙입니다.
+// Status가 0이라면 200으로 응답합니다.
+type legacyCode struct {
+	Err    *usecase.Error
+	Code   int
+	Status int
+}
+
+// EndPoint별로 유스케이스 에러를 v1 코드로 바꾸는 표입니다.
+// 키는 sendError에 넘기는 what이며, 같은 에러라도 EndPoint마다 코드가 다르므로 따로 적습니다.
+// 여기에 없는 유스케이스 에러는 v1에서 응답한 적이 없는 에러이므로 v2 형식으로 응답합니다.
+var legacyCodes = map[string][]*legacyCode{
+	"SignUp": {
+		{Err: usecase.ErrNicknameTaken, Code: 8001},
+		{Err: usecase.ErrUsernameTaken, Code: 8002},
+	},
+	"Login": {
+		{Err: usecase.ErrInvalidCredentials, Code: 8001, Status: http.StatusUnauthorized},
+	},
+	"add to cart": {
+		{Err: usecase.ErrInvalidSku, Code: 8001},
+	},
+	"add review": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrReviewNotFound, Code: 8002},
+	},
+	"get product statistics": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+	},
+	"add pbv option": {
+		{Err: usecase.ErrPbvOptionLimitReached, Code: 8001},
+		{Err: usecase.ErrInvalidPbvOption, Code: 8002},
+		{Err: usecase.ErrPbvOptionTooLarge, Code: 8003},
+	},
+	"get pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+	},
+	"update pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+		{Err: usecase.ErrInvalidPbvOption, Code: 8002},
+		{Err: usecase.ErrPbvOptionTooLarge, Code: 8003},
+	},
+	"rename pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+	},
+	"duplicate pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+		{Err: usecase.ErrPbvOptionLimitReached, Code: 8002},
+	},
+	"delete pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+	},
+	"get pbv option versions": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+	},
+	"get pbv option version": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+		{Err: usecase.ErrPbvOptionVersionNotFound, Code: 8001},
+	},
+	"restore pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+		{Err: usecase.ErrPbvOptionVersionNotFound, Code: 8002},
+	},
+	"get pbv quote": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+	},
+	"add pbv option to cart": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+		{Err: usecase.ErrPbvPartsUnavailable, Code: 8002},
+	},
+	"share pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+	},
+	"unshare pbv option": {
+		{Err: usecase.ErrPbvOptionNotFound, Code: 8001},
+		{Err: usecase.ErrPbvOptionNotShared, Code: 8002},
+	},
+	"get shared pbv option": {
+		{Err: usecase.ErrSharedPbvOptionNotFound, Code: 8001},
+	},
+	"clone shared pbv option": {
+		{Err: usecase.ErrSharedPbvOptionNotFound, Code: 8001},
+		{Err: usecase.ErrPbvOptionLimitReached, Code: 8002},
+	},
+	"add to wishlist": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+	},
+	"move wishlist to cart": {
+		{Err: usecase.ErrNotInWishlist, Code: 8001},
+		{Err: usecase.ErrInvalidSku, Code: 8002},
+	},
+	"add coupon": {
+		{Err: usecase.ErrPermissionDenied, Code: 8001},
+		{Err: usecase.ErrCouponCodeTaken, Code: 8002},
+		{Err: usecase.ErrCouponTargetNotFound, Code: 8003},
+	},
+	"add product discount": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+	},
+	"apply coupon": legacyCheckoutCodes,
+	"checkout":     legacyCheckoutCodes,
+	"add product variant": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrVariantNameTaken, Code: 8003},
+		{Err: usecase.ErrProductHasSkus, Code: 8004},
+	},
+	"add product sku": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrSkuCodeTaken, Code: 8003},
+		{Err: usecase.ErrInvalidSkuValues, Code: 8004},
+		{Err: usecase.ErrSkuCombinationTaken, Code: 8005},
+	},
+	"update product sku": {
+		{Err: usecase.ErrSkuNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrNegativeSkuPrice, Code: 8003},
+	},
+	"add vehicle model": {
+		{Err: usecase.ErrPermissionDenied, Code: 8001},
+		{Err: usecase.ErrVehicleModelTaken, Code: 8002},
+	},
+	"add product fitment": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrVehicleNotFound, Code: 8003},
+	},
+	"delete product fitment": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+	},
+	"add to garage": {
+		{Err: usecase.ErrVehicleNotFound, Code: 8001},
+		{Err: usecase.ErrVehicleAlreadyInGarage, Code: 8002},
+		{Err: usecase.ErrGarageFull, Code: 8003},
+	},
+	"upload product image": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrUnsupportedImage, Code: 8003},
+		{Err: usecase.ErrImageLimitReached, Code: 8004},
+		{Err: usecase.ErrImageTooLarge, Code: 8005},
+	},
+	"reorder product images": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrImageOrderMismatch, Code: 8003},
+	},
+	"delete product image": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrImageNotFound, Code: 8003},
+	},
+	"update product description": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrInvalidDescription, Code: 8003},
+		{Err: usecase.ErrDescriptionTooLarge, Code: 8004},
+	},
+	"get product description revisions": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+	},
+	"get product description revision": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrDescriptionRevisionNotFound, Code: 8003},
+	},
+	"restore product description": {
+		{Err: usecase.ErrProductNotFound, Code: 8001},
+		{Err: usecase.ErrPermissionDenied, Code: 8002},
+		{Err: usecase.ErrDescriptionRevisionNotFound, Code: 8003},
+	},
+}
+
+// 쿠폰 적용과 주문은 같은 검사를 하므로 같은 코드를 사용합니다.
+var legacyCheckoutCodes = []*legacyCode{
+	{Err: usecase.ErrCouponNotFound, Code: 8001},
+	{Err: usecase.ErrCouponNotActive, Code: 8002},
+	{Err: usecase.ErrCouponExhausted, Code: 8003},
+	{Err: usecase.ErrCouponUserLimitReached, Code: 8004},
+	{Err: usecase.ErrCouponNotApplicable, Code: 8005},
+	{Err: usecase.ErrCartEmpty, Code: 8006},
+	{Err: usecase.ErrOutOfStock, Code: 8007},
+}
+
+// 유스케이스 에러를 v1 형식으로 응답합니다.
+// what EndPoint의 표에 없는 에러라면 false를 반환하고 아무것도 응답하지 않습니다.
+func sendLegacyError(c *gorn.Context, uerr *usecase.Error, what string) bool {
+	for _, v := range legacyCodes[what] {
+		if !errors.Is(uerr, v.Err) {
+			continue
+		}
+		status := v.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		c.SendJson(status, &legacyErrorResponse{Code: v.Code, Errors: uerr.Details})
+		return true
+	}
+	return false
+}
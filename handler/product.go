@@ -2,13 +2,15 @@ package handler
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/JongGeonClass/JGC-API/config"
 	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/media"
 	"github.com/JongGeonClass/JGC-API/model"
 	"github.com/JongGeonClass/JGC-API/usecase"
 	"github.com/thak1411/gorn"
-	"github.com/thak1411/rnlog"
 )
 
 // Product Hanlder의 구현체입니다.
@@ -17,6 +19,7 @@ type ProductHandler struct {
 }
 
 // 개별 상품 정보 조회하기
+// 로그인한 사용자에게는 찜 여부를 함께 알려주므로 TokenDecodeWithGuest 미들웨어 다음에 호출해야 합니다.
 func (h *ProductHandler) GetProduct(c *gorn.Context) {
 	type Response struct { // 반환 타입
 		Code    int                    `json:"code"`
@@ -24,16 +27,17 @@ func (h *ProductHandler) GetProduct(c *gorn.Context) {
 	}
 	ctx := c.GetContext()
 	res := &Response{8000, nil}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
 
 	productId := c.GetParamInt64("product_id", -1) // 상품 번호를 가져옵니다.
 	if err := c.Assert(productId >= 0, "id must be greater than or equal to 0"); err != nil {
 		return
 	}
 
-	product, err := h.uc.GetProduct(ctx, productId) // 상품 정보를 가져옵니다.
+	product, err := h.uc.GetProduct(ctx, productId, token.Id) // 상품 정보를 가져옵니다.
 	if err != nil {
-		rnlog.Error("products get error: %+v", err)
-		c.SendInternalServerError()
+		sendError(c, err, "products get")
 		return
 	}
 	res.Product = product
@@ -41,6 +45,9 @@ func (h *ProductHandler) GetProduct(c *gorn.Context) {
 }
 
 // 상품 리스트 조회하기
+// vehicle_id를 넘겨주면 해당 차종에 장착할 수 있는 상품만 가져옵니다.
+// keyword를 넘겨주면 이름이나 상세 설명에 검색어가 들어간 상품만 가져옵니다.
+// 로그인한 사용자에게는 찜 여부와 내 차량 장착 여부를 함께 알려주므로 TokenDecodeWithGuest 미들웨어 다음에 호출해야 합니다.
 func (h *ProductHandler) GetProducts(c *gorn.Context) {
 	type Response struct { // 반환 타입
 		Code        int                      `json:"code"`
@@ -49,6 +56,8 @@ func (h *ProductHandler) GetProducts(c *gorn.Context) {
 	}
 	ctx := c.GetContext()
 	res := &Response{8000, nil, 1}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
 
 	page := c.GetParamInt64("page", 0) // 검색할 페이지 번호를 가져옵니다.
 	if err := c.Assert(page >= 0, "page must be greater than or equal to 0"); err != nil {
@@ -62,11 +71,18 @@ func (h *ProductHandler) GetProducts(c *gorn.Context) {
 	if err := c.Assert(categoryId >= 0, "category_id must be greater than or equal to 0"); err != nil {
 		return
 	}
+	vehicleId := c.GetParamInt64("vehicle_id", 0) // 검색할 차종 번호를 가져옵니다.
+	if err := c.Assert(vehicleId >= 0, "vehicle_id must be greater than or equal to 0"); err != nil {
+		return
+	}
+	keyword := strings.TrimSpace(c.GetParam("keyword", "")) // 검색어를 가져옵니다.
+	if err := c.AssertStrLen(keyword, 0, 100); err != nil {
+		return
+	}
 	// 상품 리스트를 가져옵니다.
-	products, maxPagesize, err := h.uc.GetProducts(ctx, page, pagesize, categoryId)
+	products, maxPagesize, err := h.uc.GetProducts(ctx, page, pagesize, categoryId, vehicleId, keyword, token.Id)
 	if err != nil {
-		rnlog.Error("products get error: %+v", err)
-		c.SendInternalServerError()
+		sendError(c, err, "products get")
 		return
 	}
 	res.Products = products
@@ -74,37 +90,65 @@ func (h *ProductHandler) GetProducts(c *gorn.Context) {
 	c.SendJson(http.StatusOK, res)
 }
 
-// 장바구니에 담긴 상품 리스트를 가져옵니다.
-// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+// 게스트라면 GuestDecode 미들웨어에서 탑재한 게스트 아이디를 반환합니다.
+// 로그인한 사용자라면 빈 문자열을 반환합니다.
+func getGuestId(c *gorn.Context, token model.AuthUserTokenClaims) string {
+	if token.Id != model.GuestAuthUserTokenClaims.Id {
+		return ""
+	}
+	conf := config.Get()
+	return c.GetValue(conf.Cookies.GuestSessionName).(string)
+}
+
+// 장바구니에 담긴 상품 리스트와 금액을 가져옵니다.
+// 가격이나 재고가 바뀐 상품은 각 상품의 price_changed, stock_shortage로 알려줍니다.
+// 로그인하지 않은 사용자는 게스트 장바구니를 사용하므로 TokenDecodeWithGuest, GuestDecode 미들웨어 다음에 호출해야 합니다.
 func (h *ProductHandler) GetCartProducts(c *gorn.Context) {
 	type Response struct { // 반환 타입
-		Code  int                   `json:"code"`
-		Carts []*dbmodel.PublicCart `json:"carts"`
+		Code        int                          `json:"code"`
+		Carts       []*dbmodel.PublicCart        `json:"carts"`
+		Groups      []*dbmodel.CartShippingGroup `json:"groups"`
+		Subtotal    int64                        `json:"subtotal"`
+		ShippingFee int64                        `json:"shipping_fee"`
+		Total       int64                        `json:"total"`
+		HasChanges  bool                         `json:"has_changes"`
 	}
 	ctx := c.GetContext()
-	res := &Response{8000, nil}
+	res := &Response{Code: 8000}
 	conf := config.Get()
 	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
 
 	// 장바구니에 담긴 상품 리스트를 가져옵니다.
-	carts, err := h.uc.GetCartProducts(ctx, token.Id)
+	var summary *dbmodel.CartSummary
+	var err error
+	if guestId := getGuestId(c, token); guestId != "" {
+		summary, err = h.uc.GetGuestCartProducts(ctx, guestId)
+	} else {
+		summary, err = h.uc.GetCartProducts(ctx, token.Id)
+	}
 	if err != nil {
-		rnlog.Error("products get error: %+v", err)
-		c.SendInternalServerError()
+		sendError(c, err, "products get")
 		return
 	}
-	res.Carts = carts
+	res.Carts = summary.Carts
+	res.Groups = summary.Groups
+	res.Subtotal = summary.Subtotal
+	res.ShippingFee = summary.ShippingFee
+	res.Total = summary.Total
+	res.HasChanges = summary.HasChanges
 	c.SendJson(http.StatusOK, res)
 }
 
 // 장바구니에 상품을 담습니다.
-// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+// SKU가 있는 상품은 sku_id로 SKU를 골라야 하며, SKU가 없는 상품은 sku_id를 비워둡니다.
+// 로그인하지 않은 사용자는 게스트 장바구니를 사용하므로 TokenDecodeWithGuest, GuestDecode 미들웨어 다음에 호출해야 합니다.
 func (h *ProductHandler) AddToCart(c *gorn.Context) {
 	type Response struct { // 반환 타입
 		Code int `json:"code"`
 	}
 	type Body struct { // Body 파라미터 타입
 		ProductId int64 `json:"product_id"`
+		SkuId     int64 `json:"sku_id"`
 		Amount    int64 `json:"amount"`
 	}
 	res := &Response{8000}
@@ -122,23 +166,33 @@ func (h *ProductHandler) AddToCart(c *gorn.Context) {
 		return
 	}
 
+	if err := c.Assert(body.SkuId >= 0, "sku_id must be greater than or equal to 0"); err != nil {
+		return
+	}
+
 	// 장바구니에 상품 담는 로직을 실행합니다.
-	if err := h.uc.AddToCart(ctx, token.Id, body.ProductId, body.Amount); err != nil {
-		rnlog.Error("add to cart error: %+v", err)
-		c.SendInternalServerError()
+	var err error
+	if guestId := getGuestId(c, token); guestId != "" {
+		err = h.uc.AddToGuestCart(ctx, guestId, body.ProductId, body.SkuId, body.Amount)
+	} else {
+		err = h.uc.AddToCart(ctx, token.Id, body.ProductId, body.SkuId, body.Amount)
+	}
+	if err != nil {
+		sendError(c, err, "add to cart")
 		return
 	}
 	c.SendJson(http.StatusOK, res)
 }
 
 // 장바구니에 담긴 상품의 개수를 변경합니다.
-// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+// 로그인하지 않은 사용자는 게스트 장바구니를 사용하므로 TokenDecodeWithGuest, GuestDecode 미들웨어 다음에 호출해야 합니다.
 func (h *ProductHandler) UpdateCartAmount(c *gorn.Context) {
 	type Response struct { // 반환 타입
 		Code int `json:"code"`
 	}
 	type Body struct { // Body 파라미터 타입
 		ProductId int64 `json:"product_id"`
+		SkuId     int64 `json:"sku_id"`
 		Amount    int64 `json:"amount"`
 	}
 	res := &Response{8000}
@@ -156,22 +210,28 @@ func (h *ProductHandler) UpdateCartAmount(c *gorn.Context) {
 		return
 	}
 	// 장바구니에 상품 개수를 변경하는 로직을 실행합니다.
-	if err := h.uc.UpdateCartAmount(ctx, token.Id, body.ProductId, body.Amount); err != nil {
-		rnlog.Error("update cart amount error: %+v", err)
-		c.SendInternalServerError()
+	var err error
+	if guestId := getGuestId(c, token); guestId != "" {
+		err = h.uc.UpdateGuestCartAmount(ctx, guestId, body.ProductId, body.SkuId, body.Amount)
+	} else {
+		err = h.uc.UpdateCartAmount(ctx, token.Id, body.ProductId, body.SkuId, body.Amount)
+	}
+	if err != nil {
+		sendError(c, err, "update cart amount")
 		return
 	}
 	c.SendJson(http.StatusOK, res)
 }
 
 // 장바구니에 등록된 상품 삭제
-// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+// 로그인하지 않은 사용자는 게스트 장바구니를 사용하므로 TokenDecodeWithGuest, GuestDecode 미들웨어 다음에 호출해야 합니다.
 func (h *ProductHandler) DeleteFromCart(c *gorn.Context) {
 	type Response struct { // 반환 타입
 		Code int `json:"code"`
 	}
 	type Body struct { // Body 파라미터 타입
 		ProductId int64 `json:"product_id"`
+		SkuId     int64 `json:"sku_id"`
 	}
 	res := &Response{8000}
 	ctx := c.GetContext()
@@ -185,9 +245,123 @@ func (h *ProductHandler) DeleteFromCart(c *gorn.Context) {
 		return
 	}
 	// 장바구니에 상품을 삭제하는 로직을 실행합니다.
-	if err := h.uc.DeleteFromCart(ctx, token.Id, body.ProductId); err != nil {
-		rnlog.Error("delete from cart error: %+v", err)
-		c.SendInternalServerError()
+	var err error
+	if guestId := getGuestId(c, token); guestId != "" {
+		err = h.uc.DeleteFromGuestCart(ctx, guestId, body.ProductId, body.SkuId)
+	} else {
+		err = h.uc.DeleteFromCart(ctx, token.Id, body.ProductId, body.SkuId)
+	}
+	if err != nil {
+		sendError(c, err, "delete from cart")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 장바구니에서 선택한 상품들을 한 번에 삭제합니다.
+// items에는 삭제할 줄의 product_id와 sku_id를 담으며, SKU가 없는 상품이라면 sku_id는 0입니다.
+// 로그인하지 않은 사용자는 게스트 장바구니를 사용하므로 TokenDecodeWithGuest, GuestDecode 미들웨어 다음에 호출해야 합니다.
+func (h *ProductHandler) DeleteSelectedFromCart(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Items []*dbmodel.CartItem `json:"items"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(len(body.Items) > 0 && len(body.Items) <= 100, "items length must be between 1 and 100"); err != nil {
+		return
+	}
+	for _, v := range body.Items {
+		if err := c.Assert(v != nil && v.ProductId > 0, "product_id must be greater than 0"); err != nil {
+			return
+		}
+		if err := c.Assert(v.SkuId >= 0, "sku_id must not be negative"); err != nil {
+			return
+		}
+	}
+	// 장바구니에서 선택한 상품들을 삭제하는 로직을 실행합니다.
+	var err error
+	if guestId := getGuestId(c, token); guestId != "" {
+		err = h.uc.DeleteSelectedFromGuestCart(ctx, guestId, body.Items)
+	} else {
+		err = h.uc.DeleteSelectedFromCart(ctx, token.Id, body.Items)
+	}
+	if err != nil {
+		sendError(c, err, "delete selected from cart")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 장바구니에 담긴 여러 상품의 개수를 한 번에 변경합니다.
+// 로그인하지 않은 사용자는 게스트 장바구니를 사용하므로 TokenDecodeWithGuest, GuestDecode 미들웨어 다음에 호출해야 합니다.
+func (h *ProductHandler) UpdateCartAmounts(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Items []*dbmodel.CartAmount `json:"items"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(len(body.Items) > 0 && len(body.Items) <= 100, "items length must be between 1 and 100"); err != nil {
+		return
+	}
+	for _, v := range body.Items {
+		if err := c.Assert(v != nil && v.ProductId > 0, "product_id must be greater than 0"); err != nil {
+			return
+		}
+		if err := c.Assert(v.Amount > 0, "amount must be greater than 0"); err != nil {
+			return
+		}
+	}
+	// 장바구니에 담긴 상품들의 개수를 변경하는 로직을 실행합니다.
+	var err error
+	if guestId := getGuestId(c, token); guestId != "" {
+		err = h.uc.UpdateGuestCartAmounts(ctx, guestId, body.Items)
+	} else {
+		err = h.uc.UpdateCartAmounts(ctx, token.Id, body.Items)
+	}
+	if err != nil {
+		sendError(c, err, "update cart amounts")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 장바구니를 모두 비웁니다.
+// 로그인하지 않은 사용자는 게스트 장바구니를 사용하므로 TokenDecodeWithGuest, GuestDecode 미들웨어 다음에 호출해야 합니다.
+func (h *ProductHandler) ClearCart(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	// 장바구니를 비우는 로직을 실행합니다.
+	var err error
+	if guestId := getGuestId(c, token); guestId != "" {
+		err = h.uc.ClearGuestCart(ctx, guestId)
+	} else {
+		err = h.uc.ClearCart(ctx, token.Id)
+	}
+	if err != nil {
+		sendError(c, err, "clear cart")
 		return
 	}
 	c.SendJson(http.StatusOK, res)
@@ -225,13 +399,8 @@ func (h *ProductHandler) AddReview(c *gorn.Context) {
 	}
 	// 리뷰를 작성하는 로직을 실행합니다.
 	if reviewId, err := h.uc.AddReview(ctx, token.Id, body.ProductId, body.Score, body.ParentReviewId, &body.Content); err != nil {
-		rnlog.Error("add review error: %+v", err)
-		c.SendInternalServerError()
+		sendError(c, err, "add review")
 		return
-	} else if reviewId == -1 { // 리뷰를 작성하려는 상품이 존재하지 않습니다.
-		res.Code = 8001
-	} else if reviewId == -2 { // 대댓글을 달려고 하는 부모 리뷰가 존재하지 않습니다.
-		res.Code = 8002
 	} else {
 		res.ReviewId = reviewId
 	}
@@ -252,8 +421,7 @@ func (h *ProductHandler) GetReviews(c *gorn.Context) {
 	}
 	// 리뷰를 조회하는 로직을 실행합니다.
 	if reviews, err := h.uc.GetReviews(ctx, productId); err != nil {
-		rnlog.Error("get reviews error: %+v", err)
-		c.SendInternalServerError()
+		sendError(c, err, "get reviews")
 		return
 	} else {
 		res.Reviews = reviews
@@ -261,6 +429,29 @@ func (h *ProductHandler) GetReviews(c *gorn.Context) {
 	c.SendJson(http.StatusOK, res)
 }
 
+// 상품 통계 조회하기
+// 평균 별점, 별점 분포, 판매량, 최근 리뷰 추이를 반환합니다.
+func (h *ProductHandler) GetProductStatistics(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code       int                              `json:"code"`
+		Statistics *dbmodel.PublicProductStatistics `json:"statistics"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	productId := c.GetParamInt64("product_id", 0)
+	if err := c.Assert(productId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	// 상품 통계를 조회하는 로직을 실행합니다.
+	if statistics, err := h.uc.GetProductStatistics(ctx, productId); err != nil {
+		sendError(c, err, "get product statistics")
+		return
+	} else {
+		res.Statistics = statistics
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
 // 모든 카테고리 리스트를 가져옵니다.
 func (h *ProductHandler) GetCategories(c *gorn.Context) {
 	type Response struct { // 반환 타입
@@ -271,8 +462,7 @@ func (h *ProductHandler) GetCategories(c *gorn.Context) {
 	ctx := c.GetContext()
 	// 카테고리 리스트를 가져오는 로직을 실행합니다.
 	if categories, err := h.uc.GetCategories(ctx); err != nil {
-		rnlog.Error("get categories error: %+v", err)
-		c.SendInternalServerError()
+		sendError(c, err, "get categories")
 		return
 	} else {
 		res.Categories = categories
@@ -284,12 +474,14 @@ func (h *ProductHandler) GetCategories(c *gorn.Context) {
 // 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
 func (h *ProductHandler) AddPbvOption(c *gorn.Context) {
 	type Response struct { // 반환 타입
-		Code int `json:"code"`
+		Code int   `json:"code"`
+		Id   int64 `json:"id"`
 	}
 	type Body struct { // Body 파라미터 타입
+		Name string `json:"name"`
 		Data string `json:"data"`
 	}
-	res := &Response{8000}
+	res := &Response{8000, 0}
 	ctx := c.GetContext()
 	body := &Body{}
 	conf := config.Get()
@@ -297,51 +489,82 @@ func (h *ProductHandler) AddPbvOption(c *gorn.Context) {
 	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
 		return
 	}
+	if err := c.AssertStrLen(body.Name, 0, 100); err != nil {
+		return
+	}
 	// 옵션을 추가하는 로직을 실행합니다.
-	if id, err := h.uc.AddPbvOption(ctx, token.Id, body.Data); err != nil {
-		rnlog.Error("add pbv option error: %+v", err)
-		c.SendInternalServerError()
+	if id, err := h.uc.AddPbvOption(ctx, token.Id, body.Name, body.Data); err != nil {
+		sendError(c, err, "add pbv option")
+		return
+	} else {
+		res.Id = id
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 유저가 등록한 pbv 옵션 리스트를 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetPbvOptions(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code    int                        `json:"code"`
+		Options []*dbmodel.PublicPbvOption `json:"options"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	// 옵션 리스트를 가져오는 로직을 실행합니다.
+	if options, err := h.uc.GetPbvOptions(ctx, token.Id); err != nil {
+		sendError(c, err, "get pbv options")
 		return
-	} else if id == -1 { // 이미 옵션이 존재합니다.
-		res.Code = 8001
+	} else {
+		res.Options = options
 	}
 	c.SendJson(http.StatusOK, res)
 }
 
 // 등록된 pbv 옵션을 가져옵니다.
+// id를 넘기지 않으면 가장 최근에 수정한 옵션을 가져옵니다.
 // 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
 func (h *ProductHandler) GetPbvOption(c *gorn.Context) {
 	type Response struct { // 반환 타입
-		Code int    `json:"code"`
-		Data string `json:"data"`
+		Code   int                      `json:"code"`
+		Option *dbmodel.PublicPbvOption `json:"option"`
+		Data   string                   `json:"data"`
 	}
-	res := &Response{8000, ""}
+	res := &Response{8000, nil, ""}
 	ctx := c.GetContext()
 	conf := config.Get()
 	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	optionId := c.GetParamInt64("id", 0)
+	if err := c.Assert(optionId >= 0, "id must be greater than or equal to 0"); err != nil {
+		return
+	}
 	// 옵션을 가져오는 로직을 실행합니다.
-	if data, err := h.uc.GetPbvOption(ctx, token.Id); err != nil {
-		rnlog.Error("get pbv option error: %+v", err)
-		c.SendInternalServerError()
+	if option, data, err := h.uc.GetPbvOption(ctx, token.Id, optionId); err != nil {
+		sendError(c, err, "get pbv option")
 		return
-	} else if data == "" { // 옵션이 존재하지 않습니다.
-		res.Code = 8001
 	} else {
+		res.Option = option
 		res.Data = data
 	}
 	c.SendJson(http.StatusOK, res)
 }
 
 // 등록된 pbv 옵션을 업데이트합니다.
+// 바뀐 데이터는 새로운 버전으로 기록됩니다.
+// id를 넘기지 않으면 가장 최근에 수정한 옵션을 업데이트합니다.
 // 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
 func (h *ProductHandler) UpdatePbvOption(c *gorn.Context) {
 	type Response struct { // 반환 타입
-		Code int `json:"code"`
+		Code    int   `json:"code"`
+		Version int64 `json:"version"`
 	}
 	type Body struct { // Body 파라미터 타입
+		Id   int64  `json:"id"`
 		Data string `json:"data"`
 	}
-	res := &Response{8000}
+	res := &Response{8000, 0}
 	ctx := c.GetContext()
 	body := &Body{}
 	conf := config.Get()
@@ -349,18 +572,89 @@ func (h *ProductHandler) UpdatePbvOption(c *gorn.Context) {
 	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
 		return
 	}
+	if err := c.Assert(body.Id >= 0, "id must be greater than or equal to 0"); err != nil {
+		return
+	}
 	// 옵션을 업데이트하는 로직을 실행합니다.
-	if id, err := h.uc.UpdatePbvOption(ctx, token.Id, body.Data); err != nil {
-		rnlog.Error("update pbv option error: %+v", err)
-		c.SendInternalServerError()
+	if version, err := h.uc.UpdatePbvOption(ctx, token.Id, body.Id, body.Data); err != nil {
+		sendError(c, err, "update pbv option")
+		return
+	} else {
+		res.Version = version
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 등록된 pbv 옵션의 이름을 변경합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) RenamePbvOption(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Id   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.Id > 0, "id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.AssertStrLen(body.Name, 1, 100); err != nil {
+		return
+	}
+	// 옵션 이름을 변경하는 로직을 실행합니다.
+	if err := h.uc.RenamePbvOption(ctx, token.Id, body.Id, body.Name); err != nil {
+		sendError(c, err, "rename pbv option")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 등록된 pbv 옵션을 복제합니다.
+// 이름을 넘기지 않으면 원본 이름 뒤에 " (copy)"를 붙입니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) DuplicatePbvOption(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int   `json:"code"`
+		Id   int64 `json:"id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Id   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.Id > 0, "id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.AssertStrLen(body.Name, 0, 100); err != nil {
+		return
+	}
+	// 옵션을 복제하는 로직을 실행합니다.
+	if id, err := h.uc.DuplicatePbvOption(ctx, token.Id, body.Id, body.Name); err != nil {
+		sendError(c, err, "duplicate pbv option")
 		return
-	} else if id == -1 { // 옵션이 존재하지 않습니다.
-		res.Code = 8001
+	} else {
+		res.Id = id
 	}
 	c.SendJson(http.StatusOK, res)
 }
 
 // 등록된 pbv 옵션을 삭제합니다.
+// id를 넘기지 않으면 가장 최근에 수정한 옵션을 삭제합니다.
 // 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
 func (h *ProductHandler) DeletePbvOption(c *gorn.Context) {
 	type Response struct { // 반환 타입
@@ -370,35 +664,1206 @@ func (h *ProductHandler) DeletePbvOption(c *gorn.Context) {
 	ctx := c.GetContext()
 	conf := config.Get()
 	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	// 이전 클라이언트는 바디 없이 요청하므로 id를 쿼리 파라미터로 받습니다.
+	optionId := c.GetParamInt64("id", 0)
+	if err := c.Assert(optionId >= 0, "id must be greater than or equal to 0"); err != nil {
+		return
+	}
 	// 옵션을 삭제하는 로직을 실행합니다.
-	if id, err := h.uc.DeletePbvOption(ctx, token.Id); err != nil {
-		rnlog.Error("delete pbv option error: %+v", err)
-		c.SendInternalServerError()
+	if err := h.uc.DeletePbvOption(ctx, token.Id, optionId); err != nil {
+		sendError(c, err, "delete pbv option")
 		return
-	} else if id == -1 { // 옵션이 존재하지 않습니다.
-		res.Code = 8001
 	}
 	c.SendJson(http.StatusOK, res)
 }
 
-// 유저가 운영중인 브랜드 리스트를 가져옵니다.
+// 등록된 pbv 옵션의 버전 기록을 가져옵니다.
 // 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
-func (h *ProductHandler) GetBrands(c *gorn.Context) {
+func (h *ProductHandler) GetPbvOptionVersions(c *gorn.Context) {
 	type Response struct { // 반환 타입
-		Code   int              `json:"code"`
-		Brands []*dbmodel.Brand `json:"brands"`
+		Code     int                               `json:"code"`
+		Versions []*dbmodel.PublicPbvOptionVersion `json:"versions"`
 	}
 	res := &Response{8000, nil}
 	ctx := c.GetContext()
 	conf := config.Get()
 	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
-	// 브랜드 리스트를 가져오는 로직을 실행합니다.
-	if brands, err := h.uc.GetBrands(ctx, token.Id); err != nil {
-		rnlog.Error("get brands error: %+v", err)
-		c.SendInternalServerError()
+	optionId := c.GetParamInt64("id", 0)
+	if err := c.Assert(optionId > 0, "id must be greater than 0"); err != nil {
+		return
+	}
+	// 버전 기록을 가져오는 로직을 실행합니다.
+	if versions, err := h.uc.GetPbvOptionVersions(ctx, token.Id, optionId); err != nil {
+		sendError(c, err, "get pbv option versions")
 		return
 	} else {
-		res.Brands = brands
+		res.Versions = versions
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 등록된 pbv 옵션의 특정 버전 데이터를 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetPbvOptionVersion(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int    `json:"code"`
+		Data string `json:"data"`
+	}
+	res := &Response{8000, ""}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	optionId := c.GetParamInt64("id", 0)
+	if err := c.Assert(optionId > 0, "id must be greater than 0"); err != nil {
+		return
+	}
+	version := c.GetParamInt64("version", 0)
+	if err := c.Assert(version > 0, "version must be greater than 0"); err != nil {
+		return
+	}
+	// 버전 데이터를 가져오는 로직을 실행합니다.
+	if data, err := h.uc.GetPbvOptionVersion(ctx, token.Id, optionId, version); err != nil {
+		sendError(c, err, "get pbv option version")
+		return
+	} else {
+		res.Data = data
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 등록된 pbv 옵션을 이전 버전으로 되돌립니다.
+// 이전 버전의 데이터가 새로운 버전으로 기록됩니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) RestorePbvOption(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code    int   `json:"code"`
+		Version int64 `json:"version"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Id      int64 `json:"id"`
+		Version int64 `json:"version"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.Id > 0, "id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.Assert(body.Version > 0, "version must be greater than 0"); err != nil {
+		return
+	}
+	// 옵션을 되돌리는 로직을 실행합니다.
+	if version, err := h.uc.RestorePbvOption(ctx, token.Id, body.Id, body.Version); err != nil {
+		sendError(c, err, "restore pbv option")
+		return
+	} else {
+		res.Version = version
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// pbv 옵션의 현재 견적을 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetPbvQuote(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code  int               `json:"code"`
+		Quote *dbmodel.PbvQuote `json:"quote"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	optionId := c.GetParamInt64("id", 0)
+	if err := c.Assert(optionId >= 0, "id must be greater than or equal to 0"); err != nil {
+		return
+	}
+	// 견적을 계산하는 로직을 실행합니다.
+	if quote, err := h.uc.GetPbvQuote(ctx, token.Id, optionId); err != nil {
+		sendError(c, err, "get pbv quote")
+		return
+	} else {
+		res.Quote = quote
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// pbv 옵션의 부품들을 한 번에 장바구니에 담습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddPbvOptionToCart(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code  int   `json:"code"`
+		Count int64 `json:"count"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Id int64 `json:"id"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.Id >= 0, "id must be greater than or equal to 0"); err != nil {
+		return
+	}
+	// 장바구니에 담는 로직을 실행합니다.
+	if count, err := h.uc.AddPbvOptionToCart(ctx, token.Id, body.Id); err != nil {
+		sendError(c, err, "add pbv option to cart")
+		return
+	} else {
+		res.Count = count
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 등록된 pbv 옵션을 공유 링크로 공개합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) SharePbvOption(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code  int    `json:"code"`
+		Token string `json:"token"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Id int64 `json:"id"`
+	}
+	res := &Response{8000, ""}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.Id > 0, "id must be greater than 0"); err != nil {
+		return
+	}
+	// 옵션을 공유하는 로직을 실행합니다.
+	if shareToken, err := h.uc.SharePbvOption(ctx, token.Id, body.Id); err != nil {
+		sendError(c, err, "share pbv option")
+		return
+	} else {
+		res.Token = shareToken
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 등록된 pbv 옵션의 공유를 취소합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) UnsharePbvOption(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	optionId := c.GetParamInt64("id", 0)
+	if err := c.Assert(optionId > 0, "id must be greater than 0"); err != nil {
+		return
+	}
+	// 공유를 취소하는 로직을 실행합니다.
+	if err := h.uc.UnsharePbvOption(ctx, token.Id, optionId); err != nil {
+		sendError(c, err, "unshare pbv option")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 공유 링크로 공개된 pbv 옵션을 가져옵니다.
+// 공유 토큰은 경로의 마지막 부분(/pbv/shared/{token})으로 받습니다.
+// 로그인하지 않은 사용자도 볼 수 있도록 게스트 토큰을 허용하는 미들웨어에서 호출해야 합니다.
+func (h *ProductHandler) GetSharedPbvOption(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code   int                            `json:"code"`
+		Option *dbmodel.PublicSharedPbvOption `json:"option"`
+		Data   string                         `json:"data"`
+	}
+	res := &Response{8000, nil, ""}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	shareToken := c.PathRest()
+	if err := c.AssertStrLen(shareToken, 1, 64); err != nil {
+		return
+	}
+	// 공유 토큰이 담긴 주소가 다른 사이트에 Referer로 전달되지 않도록 합니다.
+	c.SetHeader("Referrer-Policy", "no-referrer")
+	// 공유된 옵션을 가져오는 로직을 실행합니다.
+	if option, data, err := h.uc.GetSharedPbvOption(ctx, token.Id, shareToken); err != nil {
+		sendError(c, err, "get shared pbv option")
+		return
+	} else {
+		res.Option = option
+		res.Data = data
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 공유 링크로 공개된 pbv 옵션을 내 옵션으로 복사합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) CloneSharedPbvOption(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int   `json:"code"`
+		Id   int64 `json:"id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Token string `json:"token"`
+		Name  string `json:"name"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.AssertStrLen(body.Token, 1, 64); err != nil {
+		return
+	}
+	if err := c.AssertStrLen(body.Name, 0, 100); err != nil {
+		return
+	}
+	// 공유된 옵션을 복사하는 로직을 실행합니다.
+	if id, err := h.uc.CloneSharedPbvOption(ctx, token.Id, body.Token, body.Name); err != nil {
+		sendError(c, err, "clone shared pbv option")
+		return
+	} else {
+		res.Id = id
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 찜한 상품 리스트를 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetWishlist(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code     int                       `json:"code"`
+		Wishlist []*dbmodel.PublicWishlist `json:"wishlist"`
+	}
+	ctx := c.GetContext()
+	res := &Response{8000, nil}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+
+	// 찜한 상품 리스트를 가져옵니다.
+	wishlist, err := h.uc.GetWishlist(ctx, token.Id)
+	if err != nil {
+		sendError(c, err, "get wishlist")
+		return
+	}
+	res.Wishlist = wishlist
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품을 찜합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddToWishlist(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64 `json:"product_id"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	// 상품을 찜하는 로직을 실행합니다.
+	if err := h.uc.AddToWishlist(ctx, token.Id, body.ProductId); err != nil {
+		sendError(c, err, "add to wishlist")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 찜한 상품을 찜 목록에서 삭제합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) DeleteFromWishlist(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64 `json:"product_id"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	// 찜 목록에서 상품을 삭제하는 로직을 실행합니다.
+	if err := h.uc.DeleteFromWishlist(ctx, token.Id, body.ProductId); err != nil {
+		sendError(c, err, "delete from wishlist")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 찜한 상품을 장바구니로 옮깁니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) MoveWishlistToCart(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64 `json:"product_id"`
+		SkuId     int64 `json:"sku_id"`
+		Amount    int64 `json:"amount"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.Assert(body.SkuId >= 0, "sku_id must be greater than or equal to 0"); err != nil {
+		return
+	}
+	if body.Amount == 0 { // 개수가 없다면 하나만 담습니다.
+		body.Amount = 1
+	}
+	if err := c.Assert(body.Amount > 0, "amount must be greater than 0"); err != nil {
+		return
+	}
+	// 찜한 상품을 장바구니로 옮기는 로직을 실행합니다.
+	if err := h.uc.MoveWishlistToCart(ctx, token.Id, body.ProductId, body.SkuId, body.Amount); err != nil {
+		sendError(c, err, "move wishlist to cart")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 유저가 운영중인 브랜드 리스트를 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetBrands(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code   int              `json:"code"`
+		Brands []*dbmodel.Brand `json:"brands"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	// 브랜드 리스트를 가져오는 로직을 실행합니다.
+	if brands, err := h.uc.GetBrands(ctx, token.Id); err != nil {
+		sendError(c, err, "get brands")
+		return
+	} else {
+		res.Brands = brands
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 할인 방식과 할인 값이 올바른지 확인합니다.
+// 정률 할인은 1 ~ 100 퍼센트, 정액 할인은 1원 이상이어야 합니다.
+func assertDiscount(c *gorn.Context, discountType string, discountValue int64) error {
+	if err := c.Assert(discountType == dbmodel.DiscountTypeFixed || discountType == dbmodel.DiscountTypePercent, "discount_type must be fixed or percent"); err != nil {
+		return err
+	}
+	if discountType == dbmodel.DiscountTypePercent {
+		return c.AssertInt64Range(discountValue, 1, 100)
+	}
+	return c.Assert(discountValue > 0, "discount_value must be greater than 0")
+}
+
+// 새로운 쿠폰을 만듭니다.
+// 관리자는 모든 쿠폰을, 브랜드 주인은 자기 브랜드 쿠폰만 만들 수 있습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddCoupon(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code     int   `json:"code"`
+		CouponId int64 `json:"coupon_id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Code          string    `json:"code"`
+		Name          string    `json:"name"`
+		DiscountType  string    `json:"discount_type"`
+		DiscountValue int64     `json:"discount_value"`
+		MaxDiscount   int64     `json:"max_discount"`
+		MinSpend      int64     `json:"min_spend"`
+		BrandId       int64     `json:"brand_id"`
+		CategoryId    int64     `json:"category_id"`
+		StartTime     time.Time `json:"start_time"`
+		EndTime       time.Time `json:"end_time"`
+		UsageLimit    int64     `json:"usage_limit"`
+		PerUserLimit  int64     `json:"per_user_limit"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.AssertStrRegex(body.Code, "^[A-Za-z0-9_-]{4,50}$"); err != nil {
+		return
+	}
+	if err := c.AssertStrLen(body.Name, 1, 100); err != nil {
+		return
+	}
+	if err := assertDiscount(c, body.DiscountType, body.DiscountValue); err != nil {
+		return
+	}
+	if err := c.Assert(body.MaxDiscount >= 0 && body.MinSpend >= 0, "max_discount and min_spend must be greater than or equal to 0"); err != nil {
+		return
+	}
+	if err := c.Assert(body.BrandId >= 0 && body.CategoryId >= 0, "brand_id and category_id must be greater than or equal to 0"); err != nil {
+		return
+	}
+	if err := c.Assert(body.UsageLimit >= 0 && body.PerUserLimit >= 0, "usage_limit and per_user_limit must be greater than or equal to 0"); err != nil {
+		return
+	}
+	if err := c.Assert(body.EndTime.After(body.StartTime), "end_time must be after start_time"); err != nil {
+		return
+	}
+	// 쿠폰을 만드는 로직을 실행합니다.
+	if couponId, err := h.uc.AddCoupon(ctx, token.Id, &dbmodel.Coupon{
+		Code:          body.Code,
+		Name:          body.Name,
+		DiscountType:  body.DiscountType,
+		DiscountValue: body.DiscountValue,
+		MaxDiscount:   body.MaxDiscount,
+		MinSpend:      body.MinSpend,
+		BrandId:       body.BrandId,
+		CategoryId:    body.CategoryId,
+		StartTime:     body.StartTime,
+		EndTime:       body.EndTime,
+		UsageLimit:    body.UsageLimit,
+		PerUserLimit:  body.PerUserLimit,
+	}); err != nil {
+		sendError(c, err, "add coupon")
+		return
+	} else {
+		res.CouponId = couponId
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품에 기간 할인을 등록합니다.
+// 관리자나 상품 브랜드의 주인만 등록할 수 있습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddProductDiscount(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code       int   `json:"code"`
+		DiscountId int64 `json:"discount_id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId     int64     `json:"product_id"`
+		DiscountType  string    `json:"discount_type"`
+		DiscountValue int64     `json:"discount_value"`
+		StartTime     time.Time `json:"start_time"`
+		EndTime       time.Time `json:"end_time"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := assertDiscount(c, body.DiscountType, body.DiscountValue); err != nil {
+		return
+	}
+	if err := c.Assert(body.EndTime.After(body.StartTime), "end_time must be after start_time"); err != nil {
+		return
+	}
+	// 상품 할인을 등록하는 로직을 실행합니다.
+	if discountId, err := h.uc.AddProductDiscount(ctx, token.Id, &dbmodel.ProductDiscount{
+		ProductId:     body.ProductId,
+		DiscountType:  body.DiscountType,
+		DiscountValue: body.DiscountValue,
+		StartTime:     body.StartTime,
+		EndTime:       body.EndTime,
+	}); err != nil {
+		sendError(c, err, "add product discount")
+		return
+	} else {
+		res.DiscountId = discountId
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 현재 장바구니에 쿠폰을 적용한 가격을 계산합니다.
+// 쿠폰을 실제로 사용하지는 않습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) ApplyCoupon(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int                 `json:"code"`
+		Cart *dbmodel.PricedCart `json:"cart"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Code string `json:"code"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.AssertStrLen(body.Code, 1, 50); err != nil {
+		return
+	}
+	// 쿠폰을 적용한 가격을 계산하는 로직을 실행합니다.
+	cart, err := h.uc.ApplyCoupon(ctx, token.Id, body.Code)
+	if err != nil {
+		sendError(c, err, "apply coupon")
+		return
+	}
+	res.Cart = cart
+	c.SendJson(http.StatusOK, res)
+}
+
+// 장바구니에 담긴 모든 상품을 결제합니다.
+// 쿠폰 코드가 없다면 쿠폰 없이 결제합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) Checkout(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code    int   `json:"code"`
+		OrderId int64 `json:"order_id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		CouponCode string `json:"coupon_code"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.AssertStrLen(body.CouponCode, 0, 50); err != nil {
+		return
+	}
+	// 결제 로직을 실행합니다.
+	orderId, err := h.uc.Checkout(ctx, token.Id, body.CouponCode)
+	if err != nil {
+		sendError(c, err, "checkout")
+		return
+	}
+	res.OrderId = orderId
+	c.SendJson(http.StatusOK, res)
+}
+
+// 유저의 주문 리스트를 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetOrders(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code   int                    `json:"code"`
+		Orders []*dbmodel.PublicOrder `json:"orders"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	// 주문 리스트를 가져오는 로직을 실행합니다.
+	orders, err := h.uc.GetOrders(ctx, token.Id)
+	if err != nil {
+		sendError(c, err, "get orders")
+		return
+	}
+	res.Orders = orders
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품에 새로운 옵션(색상, 사이즈 등)과 고를 수 있는 값들을 추가합니다.
+// 관리자나 상품 브랜드의 주인만 추가할 수 있습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddProductVariant(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code      int   `json:"code"`
+		VariantId int64 `json:"variant_id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64    `json:"product_id"`
+		Name      string   `json:"name"`
+		Values    []string `json:"values"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.AssertStrLen(body.Name, 1, 50); err != nil {
+		return
+	}
+	if err := c.Assert(len(body.Values) > 0 && len(body.Values) <= 50, "values length must be between 1 and 50"); err != nil {
+		return
+	}
+	seen := map[string]bool{}
+	for _, v := range body.Values {
+		if err := c.AssertStrLen(v, 1, 100); err != nil {
+			return
+		}
+		if err := c.Assert(!seen[v], "values must be unique"); err != nil {
+			return
+		}
+		seen[v] = true
+	}
+	// 옵션을 추가하는 로직을 실행합니다.
+	if variantId, err := h.uc.AddProductVariant(ctx, token.Id, body.ProductId, body.Name, body.Values); err != nil {
+		sendError(c, err, "add product variant")
+		return
+	} else {
+		res.VariantId = variantId
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품에 새로운 SKU를 추가합니다.
+// value_ids는 상품의 옵션마다 하나씩 고른 옵션 값 아이디입니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddProductSku(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code  int   `json:"code"`
+		SkuId int64 `json:"sku_id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId  int64   `json:"product_id"`
+		Code       string  `json:"code"`
+		ValueIds   []int64 `json:"value_ids"`
+		PriceDelta int64   `json:"price_delta"`
+		Amount     int64   `json:"amount"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.AssertStrRegex(body.Code, "^[A-Za-z0-9_-]{1,64}$"); err != nil {
+		return
+	}
+	if err := c.Assert(len(body.ValueIds) > 0 && len(body.ValueIds) <= 10, "value_ids length must be between 1 and 10"); err != nil {
+		return
+	}
+	if err := c.Assert(body.Amount >= 0, "amount must be greater than or equal to 0"); err != nil {
+		return
+	}
+	// SKU를 추가하는 로직을 실행합니다.
+	if skuId, err := h.uc.AddProductSku(ctx, token.Id, body.ProductId, body.Code, body.ValueIds, body.PriceDelta, body.Amount); err != nil {
+		sendError(c, err, "add product sku")
+		return
+	} else {
+		res.SkuId = skuId
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// SKU의 추가 금액과 재고를 변경합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) UpdateProductSku(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId  int64 `json:"product_id"`
+		SkuId      int64 `json:"sku_id"`
+		PriceDelta int64 `json:"price_delta"`
+		Amount     int64 `json:"amount"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0 && body.SkuId > 0, "product_id and sku_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.Assert(body.Amount >= 0, "amount must be greater than or equal to 0"); err != nil {
+		return
+	}
+	// SKU를 변경하는 로직을 실행합니다.
+	if err := h.uc.UpdateProductSku(ctx, token.Id, body.ProductId, body.SkuId, body.PriceDelta, body.Amount); err != nil {
+		sendError(c, err, "update product sku")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 차종 리스트를 가져옵니다.
+// maker를 넘겨주면 해당 제조사의 차종만 가져옵니다.
+func (h *ProductHandler) GetVehicleModels(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code     int                           `json:"code"`
+		Vehicles []*dbmodel.PublicVehicleModel `json:"vehicles"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	maker := c.GetParam("maker", "") // 검색할 제조사를 가져옵니다.
+	if err := c.AssertStrLen(maker, 0, 50); err != nil {
+		return
+	}
+	// 차종 리스트를 가져옵니다.
+	vehicles, err := h.uc.GetVehicleModels(ctx, maker)
+	if err != nil {
+		sendError(c, err, "get vehicle models")
+		return
+	}
+	res.Vehicles = vehicles
+	c.SendJson(http.StatusOK, res)
+}
+
+// 새로운 차종을 추가합니다.
+// 관리자만 추가할 수 있습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddVehicleModel(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code      int   `json:"code"`
+		VehicleId int64 `json:"vehicle_id"`
+	}
+	type Body struct { // Body 파라미터 타입
+		Maker    string `json:"maker"`
+		Model    string `json:"model"`
+		YearFrom int64  `json:"year_from"`
+		YearTo   int64  `json:"year_to"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.AssertStrLen(body.Maker, 1, 50); err != nil {
+		return
+	}
+	if err := c.AssertStrLen(body.Model, 1, 100); err != nil {
+		return
+	}
+	if err := c.AssertInt64Range(body.YearFrom, 1900, 2100); err != nil {
+		return
+	}
+	if err := c.AssertInt64Range(body.YearTo, body.YearFrom, 2100); err != nil {
+		return
+	}
+	// 차종을 추가하는 로직을 실행합니다.
+	if vehicleId, err := h.uc.AddVehicleModel(ctx, token.Id, &dbmodel.VehicleModel{
+		Maker:    body.Maker,
+		Model:    body.Model,
+		YearFrom: body.YearFrom,
+		YearTo:   body.YearTo,
+	}); err != nil {
+		sendError(c, err, "add vehicle model")
+		return
+	} else {
+		res.VehicleId = vehicleId
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품에 장착할 수 있는 차종들을 추가합니다.
+// 관리자나 상품 브랜드의 주인만 추가할 수 있습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddProductFitment(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId  int64   `json:"product_id"`
+		VehicleIds []int64 `json:"vehicle_ids"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.Assert(len(body.VehicleIds) > 0 && len(body.VehicleIds) <= 100, "vehicle_ids length must be between 1 and 100"); err != nil {
+		return
+	}
+	// 차종을 추가하는 로직을 실행합니다.
+	if err := h.uc.AddProductFitment(ctx, token.Id, body.ProductId, body.VehicleIds); err != nil {
+		sendError(c, err, "add product fitment")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품에서 장착할 수 있는 차종을 삭제합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) DeleteProductFitment(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64 `json:"product_id"`
+		VehicleId int64 `json:"vehicle_id"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0 && body.VehicleId > 0, "product_id and vehicle_id must be greater than 0"); err != nil {
+		return
+	}
+	// 차종을 삭제하는 로직을 실행합니다.
+	if err := h.uc.DeleteProductFitment(ctx, token.Id, body.ProductId, body.VehicleId); err != nil {
+		sendError(c, err, "delete product fitment")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 내 차고에 등록한 차량 리스트를 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetGarage(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code     int                          `json:"code"`
+		Vehicles []*dbmodel.PublicUserVehicle `json:"vehicles"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+
+	// 내 차고를 가져옵니다.
+	vehicles, err := h.uc.GetGarage(ctx, token.Id)
+	if err != nil {
+		sendError(c, err, "get garage")
+		return
+	}
+	res.Vehicles = vehicles
+	c.SendJson(http.StatusOK, res)
+}
+
+// 내 차고에 차량을 등록합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) AddToGarage(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		VehicleId int64  `json:"vehicle_id"`
+		Nickname  string `json:"nickname"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.VehicleId > 0, "vehicle_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.AssertStrLen(body.Nickname, 0, 50); err != nil {
+		return
+	}
+	// 내 차고에 차량을 등록하는 로직을 실행합니다.
+	if err := h.uc.AddToGarage(ctx, token.Id, body.VehicleId, body.Nickname); err != nil {
+		sendError(c, err, "add to garage")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 내 차고에서 차량을 삭제합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) DeleteFromGarage(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		VehicleId int64 `json:"vehicle_id"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.VehicleId > 0, "vehicle_id must be greater than 0"); err != nil {
+		return
+	}
+	// 내 차고에서 차량을 삭제하는 로직을 실행합니다.
+	if err := h.uc.DeleteFromGarage(ctx, token.Id, body.VehicleId); err != nil {
+		sendError(c, err, "delete from garage")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품 갤러리에 이미지를 업로드합니다.
+// 이미지는 data에 base64로 인코딩해서 보내며, content_type은 image/jpeg 또는 image/png여야 합니다.
+// 바디 크기는 서버에서 config.MaxBodySize로 먼저 제한하므로, 너무 큰 바디는 이 함수까지 오지 않습니다.
+// 관리자나 상품 브랜드의 주인만 업로드할 수 있습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) UploadProductImage(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code  int                         `json:"code"`
+		Image *dbmodel.PublicProductImage `json:"image"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId   int64  `json:"product_id"`
+		ContentType string `json:"content_type"`
+		Data        []byte `json:"data"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.Assert(media.IsSupportedImageType(body.ContentType), "content_type must be image/jpeg or image/png"); err != nil {
+		return
+	}
+	if err := c.Assert(len(body.Data) > 0, "data must not be empty"); err != nil {
+		return
+	}
+	// 이미지를 업로드하는 로직을 실행합니다.
+	if image, err := h.uc.UploadProductImage(ctx, token.Id, body.ProductId, body.ContentType, body.Data); err != nil {
+		sendError(c, err, "upload product image")
+		return
+	} else {
+		res.Image = image
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품 갤러리의 이미지 순서를 변경합니다.
+// image_ids에는 상품의 모든 이미지 아이디를 보여줄 순서대로 담아야 합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) ReorderProductImages(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64   `json:"product_id"`
+		ImageIds  []int64 `json:"image_ids"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.Assert(len(body.ImageIds) > 0 && int64(len(body.ImageIds)) <= conf.Media.MaxImagesPerProduct, "image_ids length is out of range"); err != nil {
+		return
+	}
+	// 이미지 순서를 변경하는 로직을 실행합니다.
+	if err := h.uc.ReorderProductImages(ctx, token.Id, body.ProductId, body.ImageIds); err != nil {
+		sendError(c, err, "reorder product images")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품 갤러리에서 이미지를 삭제합니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) DeleteProductImage(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code int `json:"code"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64 `json:"product_id"`
+		ImageId   int64 `json:"image_id"`
+	}
+	res := &Response{8000}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0 && body.ImageId > 0, "product_id and image_id must be greater than 0"); err != nil {
+		return
+	}
+	// 이미지를 삭제하는 로직을 실행합니다.
+	if err := h.uc.DeleteProductImage(ctx, token.Id, body.ProductId, body.ImageId); err != nil {
+		sendError(c, err, "delete product image")
+		return
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품 상세 설명을 작성하거나 수정합니다.
+// format은 markdown 또는 blocks이며, 바뀐 내용은 새로운 수정 기록으로 남습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) UpdateProductDescription(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code     int   `json:"code"`
+		Revision int64 `json:"revision"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64  `json:"product_id"`
+		Format    string `json:"format"`
+		Content   string `json:"content"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	if err := c.Assert(body.Format == dbmodel.DescriptionFormatMarkdown || body.Format == dbmodel.DescriptionFormatBlocks, "format must be markdown or blocks"); err != nil {
+		return
+	}
+	// 상세 설명을 수정하는 로직을 실행합니다.
+	if revision, err := h.uc.UpdateProductDescription(ctx, token.Id, body.ProductId, body.Format, body.Content); err != nil {
+		sendError(c, err, "update product description")
+		return
+	} else {
+		res.Revision = revision
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품 상세 설명의 수정 기록 리스트를 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetProductDescriptionRevisions(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code      int                                         `json:"code"`
+		Revisions []*dbmodel.PublicProductDescriptionRevision `json:"revisions"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	productId := c.GetParamInt64("product_id", 0)
+	if err := c.Assert(productId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	// 수정 기록 리스트를 가져오는 로직을 실행합니다.
+	if revisions, err := h.uc.GetProductDescriptionRevisions(ctx, token.Id, productId); err != nil {
+		sendError(c, err, "get product description revisions")
+		return
+	} else {
+		res.Revisions = revisions
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품 상세 설명의 특정 수정 기록을 가져옵니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) GetProductDescriptionRevision(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code        int                               `json:"code"`
+		Description *dbmodel.PublicProductDescription `json:"description"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	productId := c.GetParamInt64("product_id", 0)
+	revision := c.GetParamInt64("revision", 0)
+	if err := c.Assert(productId > 0 && revision > 0, "product_id and revision must be greater than 0"); err != nil {
+		return
+	}
+	// 수정 기록을 가져오는 로직을 실행합니다.
+	if description, err := h.uc.GetProductDescriptionRevision(ctx, token.Id, productId, revision); err != nil {
+		sendError(c, err, "get product description revision")
+		return
+	} else {
+		res.Description = description
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// 상품 상세 설명을 특정 수정 기록의 내용으로 되돌립니다.
+// 되돌린 내용은 새로운 수정 기록으로 남습니다.
+// 이 함수는 항상 인증된 사용자만 사용할 수 있도록 미들웨어에서만 호출해야 합니다.
+func (h *ProductHandler) RestoreProductDescription(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code     int   `json:"code"`
+		Revision int64 `json:"revision"`
+	}
+	type Body struct { // Body 파라미터 타입
+		ProductId int64 `json:"product_id"`
+		Revision  int64 `json:"revision"`
+	}
+	res := &Response{8000, 0}
+	ctx := c.GetContext()
+	body := &Body{}
+	conf := config.Get()
+	token := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if err := c.BindJsonBody(body); err != nil { // 바디 바인딩
+		return
+	}
+	if err := c.Assert(body.ProductId > 0 && body.Revision > 0, "product_id and revision must be greater than 0"); err != nil {
+		return
+	}
+	// 상세 설명을 되돌리는 로직을 실행합니다.
+	if revision, err := h.uc.RestoreProductDescription(ctx, token.Id, body.ProductId, body.Revision); err != nil {
+		sendError(c, err, "restore product description")
+		return
+	} else {
+		res.Revision = revision
 	}
 	c.SendJson(http.StatusOK, res)
 }
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/thak1411/gorn"
+)
+
+// v2 Product Handler의 구현체입니다.
+// 응답 형식이 바뀐 EndPoint만 다시 구현하고, 나머지는 v1 핸들러를 그대로 사용합니다.
+type ProductV2Handler struct {
+	*ProductHandler
+}
+
+// 상품의 리뷰 리스트를 답글을 묶어서 조회하기
+// v1과 달리 답글은 원본 리뷰의 replies에 담아서 응답합니다.
+func (h *ProductV2Handler) GetReviews(c *gorn.Context) {
+	type Response struct { // 반환 타입
+		Code    int                           `json:"code"`
+		Reviews []*dbmodel.PublicReviewThread `json:"reviews"`
+	}
+	res := &Response{8000, nil}
+	ctx := c.GetContext()
+	productId := c.GetParamInt64("product_id", 0)
+	if err := c.Assert(productId > 0, "product_id must be greater than 0"); err != nil {
+		return
+	}
+	// 답글을 묶은 리뷰를 조회하는 로직을 실행합니다.
+	if reviews, err := h.uc.GetReviewThreads(ctx, productId); err != nil {
+		sendError(c, err, "get review threads")
+		return
+	} else {
+		res.Reviews = reviews
+	}
+	c.SendJson(http.StatusOK, res)
+}
+
+// v2 Product Handler를 반환합니다.
+func NewProductV2(uc usecase.ProductUsecase) *ProductV2Handler {
+	return &ProductV2Handler{NewProduct(uc)}
+}
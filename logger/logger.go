@@ -0,0 +1,196 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thak1411/rnlog"
+)
+
+// 요청 정보를 함께 남기는 로거입니다.
+// ctx에 요청 정보(WithRequest)가 있다면 요청 아이디와 유저 아이디를 로그에 붙여줍니다.
+// 출력은 rnlog를 통해 콘솔과 로그 파일에 남기며, SetFormat으로 사람이 읽는 텍스트와 JSON 중 하나를 고를 수 있습니다.
+
+// 로그 출력 형식입니다.
+const (
+	FormatText = "text"
+	FormatJson = "json"
+)
+
+// JSON 형식으로 출력할지 여부입니다.
+var jsonFormat atomic.Value
+
+// 로그 출력 형식을 설정합니다. json이 아니라면 텍스트로 출력합니다.
+// 메인에서 설정을 불러온 뒤 한 번만 호출해야 합니다.
+func SetFormat(format string) {
+	jsonFormat.Store(format == FormatJson)
+}
+
+// JSON 형식으로 출력하는지 확인합니다.
+func isJson() bool {
+	v, _ := jsonFormat.Load().(bool)
+	return v
+}
+
+// 요청 하나의 정보입니다.
+// 서버가 요청을 받을 때 만들어 ctx에 넣어두고, 인증 미들웨어가 유저 아이디를 채웁니다.
+// 같은 요청 안에서는 같은 객체를 공유하므로, 핸들러가 끝난 뒤 접근 로그에서도 유저 아이디를 알 수 있습니다.
+type RequestInfo struct {
+	RequestId string
+	// 요청을 추적하고 있다면 트레이스 아이디입니다. 로그에서 트레이스를 찾아갈 때 사용합니다.
+	TraceId string
+	userId  int64
+}
+
+// 요청한 유저 아이디를 기록합니다.
+func (r *RequestInfo) SetUserId(userId int64) {
+	atomic.StoreInt64(&r.userId, userId)
+}
+
+// 요청한 유저 아이디를 가져옵니다. 로그인하지 않은 요청이라면 0입니다.
+func (r *RequestInfo) UserId() int64 {
+	return atomic.LoadInt64(&r.userId)
+}
+
+type requestInfoKey struct{}
+
+// ctx에 요청 정보를 넣어줍니다.
+func WithRequest(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// ctx에 들어있는 요청 정보를 가져옵니다. 요청 밖에서 호출했다면 nil입니다.
+func RequestFrom(ctx context.Context) *RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info
+}
+
+// ctx의 요청 정보에 유저 아이디를 기록합니다. 요청 정보가 없다면 무시합니다.
+func SetUserId(ctx context.Context, userId int64) {
+	if info := RequestFrom(ctx); info != nil {
+		info.SetUserId(userId)
+	}
+}
+
+// 로그 한 줄의 필드입니다. 순서를 지키기 위해 맵 대신 슬라이스를 사용합니다.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// 로그 한 줄을 형식에 맞게 출력합니다.
+func write(level, caller, msg string, fields []field) {
+	now := time.Now()
+	if isJson() {
+		sb := &strings.Builder{}
+		sb.WriteString("{")
+		writeJsonField(sb, "time", now.Format(time.RFC3339Nano), true)
+		writeJsonField(sb, "level", strings.ToLower(level), false)
+		if caller != "" {
+			writeJsonField(sb, "caller", caller, false)
+		}
+		writeJsonField(sb, "msg", msg, false)
+		for _, v := range fields {
+			writeJsonField(sb, v.key, v.value, false)
+		}
+		sb.WriteString("}")
+		rnlog.Log("%s", sb.String())
+		return
+	}
+	// rnlog와 같은 형식으로 출력하고, 필드는 key=value로 뒤에 붙입니다.
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "[%5s][%s][%s] %s", level, now.Format("2006-01-02 15:04:05"), caller, msg)
+	for _, v := range fields {
+		fmt.Fprintf(sb, " %s=%v", v.key, v.value)
+	}
+	rnlog.Log("%s", sb.String())
+}
+
+// JSON 객체에 필드 하나를 씁니다.
+func writeJsonField(sb *strings.Builder, key string, value interface{}, first bool) {
+	if !first {
+		sb.WriteString(",")
+	}
+	k, _ := json.Marshal(key)
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+	sb.Write(k)
+	sb.WriteString(":")
+	sb.Write(v)
+}
+
+// ctx의 요청 정보를 필드로 만들어줍니다.
+func requestFields(ctx context.Context) []field {
+	info := RequestFrom(ctx)
+	if info == nil {
+		return nil
+	}
+	fields := []field{{"request_id", info.RequestId}}
+	if info.TraceId != "" {
+		fields = append(fields, field{"trace_id", info.TraceId})
+	}
+	if userId := info.UserId(); userId > 0 {
+		fields = append(fields, field{"user_id", userId})
+	}
+	return fields
+}
+
+// 로거 함수를 호출한 함수 이름과 줄 번호를 가져옵니다.
+func callerName() string {
+	pc, _, line, ok := runtime.Caller(3)
+	if !ok {
+		return "UnknownFunction"
+	}
+	spt := strings.Split(runtime.FuncForPC(pc).Name(), "/")
+	return fmt.Sprintf("%s:%d", spt[len(spt)-1], line)
+}
+
+// 레벨에 맞춰 로그를 남깁니다. 직접 호출하지 않고 Info, Warn, Error를 통해서만 호출해야 합니다.
+func logf(ctx context.Context, level, format string, v ...interface{}) {
+	write(level, callerName(), fmt.Sprintf(format, v...), requestFields(ctx))
+}
+
+// 동작하고 있는 상태를 알려주기 위한 로그를 남깁니다.
+func Info(ctx context.Context, format string, v ...interface{}) {
+	logf(ctx, "INFO", format, v...)
+}
+
+// 예상치 못한 일이 생겼지만 계속 동작할 수 있을 때 로그를 남깁니다.
+func Warn(ctx context.Context, format string, v ...interface{}) {
+	logf(ctx, "WARN", format, v...)
+}
+
+// 오류로 인해 요청을 처리하지 못했을 때 로그를 남깁니다.
+func Error(ctx context.Context, format string, v ...interface{}) {
+	logf(ctx, "ERROR", format, v...)
+}
+
+// 요청 하나의 접근 로그입니다.
+type AccessEntry struct {
+	Method  string
+	Route   string
+	Path    string
+	Status  int
+	Latency time.Duration
+	Bytes   int64
+}
+
+// 요청이 끝난 뒤 접근 로그를 한 줄 남깁니다.
+func Access(ctx context.Context, entry *AccessEntry) {
+	fields := []field{
+		{"method", entry.Method},
+		{"route", entry.Route},
+		{"path", entry.Path},
+		{"status", entry.Status},
+		{"latency_ms", float64(entry.Latency.Microseconds()) / 1000},
+		{"bytes", entry.Bytes},
+	}
+	write("INFO", "access", "access", append(fields, requestFields(ctx)...))
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -8,8 +10,18 @@ import (
 
 	"github.com/JongGeonClass/JGC-API/config"
 	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/demo"
+	"github.com/JongGeonClass/JGC-API/handler"
+	"github.com/JongGeonClass/JGC-API/logger"
+	"github.com/JongGeonClass/JGC-API/media"
+	"github.com/JongGeonClass/JGC-API/metrics"
 	"github.com/JongGeonClass/JGC-API/migrate"
+	"github.com/JongGeonClass/JGC-API/openapi"
+	"github.com/JongGeonClass/JGC-API/reconcile"
 	"github.com/JongGeonClass/JGC-API/router"
+	"github.com/JongGeonClass/JGC-API/schema"
+	"github.com/JongGeonClass/JGC-API/server"
+	"github.com/JongGeonClass/JGC-API/tracing"
 	"github.com/JongGeonClass/JGC-API/util"
 	"github.com/thak1411/gorn"
 	"github.com/thak1411/rnlog"
@@ -20,15 +32,66 @@ func main() {
 	// 기본 플래그는 로컬입니다.
 	envPath := flag.String("env_path", "", "Environment's Parent Folder path")
 	envp := flag.String("env", "native", "Environment\n- native.env\n- test.env\n- product.env\n")
-	isMigrate := flag.Bool("migrate", false, "Migrate database")
+	migrateCommand := flag.String("migrate", "", "Migrate database\n- up: apply all pending migrations\n- down: roll back the latest migration\n- status: show applied and pending migrations\n- to=N: migrate up or down to version N\n")
+	isDryRun := flag.Bool("dry-run", false, "Print the SQL of -migrate without running it")
+	isReconcileStats := flag.Bool("reconcile-stats", false, "Compare PRODUCT_STATISTICS with source tables and report drift")
+	isFix := flag.Bool("fix", false, "Fix drift found by -reconcile-stats")
+	isRepairStats := flag.Bool("repair-stats", false, "Recompute PRODUCT_STATISTICS from source tables (same as -reconcile-stats -fix)")
+	isSchemaCheck := flag.Bool("schema-check", false, "Compare the database schema with dbmodel and print the difference")
+	demoCommand := flag.String("demo", "", "Manage demo data\n- generate: add demo data that does not exist yet\n- remove: delete all data in dbmodel tables\n- reset: remove and generate\n")
+	isForce := flag.Bool("force", false, "Allow -demo to run when ENVIRONMENT is not native or test")
+	demoOptions := demo.DefaultOptions()
+	flag.Int64Var(&demoOptions.Seed, "seed", demoOptions.Seed, "Random seed of -demo generate")
+	flag.IntVar(&demoOptions.Users, "demo-users", demoOptions.Users, "Number of users of -demo generate")
+	flag.IntVar(&demoOptions.Brands, "demo-brands", demoOptions.Brands, "Number of brands of -demo generate")
+	flag.IntVar(&demoOptions.Products, "demo-products", demoOptions.Products, "Number of products of -demo generate")
+	flag.IntVar(&demoOptions.Reviews, "demo-reviews", demoOptions.Reviews, "Number of reviews per product of -demo generate")
+	isErrorCatalog := flag.Bool("error-catalog", false, "Print the error code catalog as JSON and exit")
+	isOpenApiCheck := flag.Bool("openapi-check", false, "Check that every registered route and error code is in openapi/openapi.json")
 	flag.Parse()
 
+	// 에러 코드 목록은 설정이나 디비 없이 만들 수 있으므로, 가장 먼저 출력하고 종료합니다.
+	if *isErrorCatalog {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(handler.ErrorCatalog()); err != nil {
+			fmt.Println("Failed to print error catalog:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// API 명세 검사도 설정이나 디비 없이 라우터만 만들어서 확인합니다.
+	// 명세에 빠진 EndPoint나 에러 코드가 있다면 출력하고 0이 아닌 값으로 종료합니다.
+	if *isOpenApiCheck {
+		problems, err := openapi.Check(router.NewApi(nil, nil, nil).Routes())
+		if err != nil {
+			fmt.Println("Failed to check openapi spec:", err)
+			os.Exit(1)
+		}
+		for _, v := range problems {
+			fmt.Println(v)
+		}
+		if len(problems) > 0 {
+			os.Exit(1)
+		}
+		fmt.Println("openapi spec is up to date")
+		return
+	}
+
 	// config file을 초기화 합니다. 이때 rn logger를 사용하는데 초기화 하지 않았으므로,
 	// 에러 로그가 파일로 저장되지 않습니다.
 	config.Init(filepath.Join(*envPath, ".env.default.env"), filepath.Join(*envPath, *envp))
 
 	conf := config.Get()
 
+	// 데모 데이터 명령은 데이터를 지울 수 있으므로, 실수로 운영 디비에 실행하지 않도록
+	// 불러온 설정의 ENVIRONMENT가 개발 환경이 아니라면 -force 없이는 거부합니다.
+	if *demoCommand != "" && !conf.IsDevelopment() && !*isForce {
+		fmt.Printf("Refusing to run -demo against ENVIRONMENT=%q (db host: %s) without -force\n", conf.Environment, conf.DB.Host)
+		os.Exit(1)
+	}
+
 	// rn logger를 초기화 합니다.
 	err := rnlog.Init(conf.LogFilePath, conf.LogFile)
 	if err != nil {
@@ -38,6 +101,15 @@ func main() {
 	defer rnlog.Close()
 	rnlog.Log(util.BarLine(120))
 
+	// 서버가 남기는 로그의 출력 형식을 설정합니다.
+	logger.SetFormat(conf.LogFormat)
+
+	// 요청 데이터를 검증할 JSON Schema를 불러옵니다.
+	if err := schema.Init(); err != nil {
+		rnlog.Fatal("Schema load Error: %+v\n", err)
+		return
+	}
+
 	// 디비는 서버가 종료될 때 닫아주어야 하기 때문에, 메인에서 생성합니다.
 	db := gorn.NewDB("mysql")
 	if err := db.Open(&gorn.DBConfig{
@@ -59,40 +131,124 @@ func main() {
 	defer db.Close()
 	// 만약 마이그레이션 로직을 실행해야 한다면
 	// 마이그레이션 로직을 실행하고 종료합니다.
-	if isMigrate != nil && *isMigrate {
-		migrate.Migrate(db)
+	// 배포 스크립트가 실패를 알 수 있도록, 실패했다면 0이 아닌 값으로 종료합니다.
+	if *migrateCommand != "" {
+		if err := migrate.Run(db, *migrateCommand, *isDryRun); err != nil {
+			rnlog.Error("Migrate Error: %+v", err)
+			db.Close()
+			rnlog.Close()
+			os.Exit(1)
+		}
 		return
 	}
 
-	// // 데모 데이터를 삭제합니다.
-	// if err := demo.Remove(
-	// 	database.NewUser(db),
-	// 	database.NewProduct(db),
-	// ); err != nil {
-	// 	rnlog.Error("Failed to remove demo data: %+v", err)
-	// 	return
-	// }
-
-	// // 데모 데이터를 생성합니다.
-	// if err := demo.Generate(
-	// 	database.NewUser(db),
-	// 	database.NewProduct(db),
-	// ); err != nil {
-	// 	rnlog.Error("Failed to generate demo data: %+v", err)
-	// 	return
-	// }
+	// 만약 상품 통계 검사 로직을 실행해야 한다면
+	// 원본 테이블과 비교해 불일치를 보고하고 종료합니다.
+	// -fix 플래그가 있다면 불일치를 수정하며, -repair-stats는 -reconcile-stats -fix와 같습니다.
+	// 검사에 실패했거나, -fix 없이 불일치를 찾았다면 불일치를 출력하고 0이 아닌 값으로 종료합니다.
+	if *isReconcileStats || *isRepairStats {
+		fix := *isFix || *isRepairStats
+		drifts, err := reconcile.Statistics(database.NewProduct(db), fix)
+		if err != nil {
+			db.Close()
+			rnlog.Close()
+			os.Exit(1)
+		}
+		if len(drifts) > 0 && !fix {
+			for _, v := range drifts {
+				fmt.Println(v.String())
+			}
+			db.Close()
+			rnlog.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	// 만약 스키마 검사 로직을 실행해야 한다면
+	// dbmodel과 디비의 스키마를 비교해 다른 부분을 출력하고 종료합니다.
+	// 배포 전에 확인할 수 있도록, 다른 부분이 있다면 0이 아닌 값으로 종료합니다.
+	if *isSchemaCheck {
+		drifts, err := reconcile.Schema(database.NewSchema(db))
+		if err != nil {
+			db.Close()
+			rnlog.Close()
+			os.Exit(1)
+		}
+		if len(drifts) > 0 {
+			fmt.Println("--- dbmodel")
+			fmt.Println("+++ database")
+			for _, v := range drifts {
+				fmt.Println(v.Diff())
+			}
+			db.Close()
+			rnlog.Close()
+			os.Exit(1)
+		}
+		fmt.Println("database schema is up to date")
+		return
+	}
+
+	// 만약 데모 데이터 명령을 실행해야 한다면
+	// 데모 데이터를 생성하거나 삭제하고 종료합니다.
+	if *demoCommand != "" {
+		if err := demo.Run(
+			database.NewUser(db),
+			database.NewProduct(db),
+			database.NewDemo(db),
+			*demoCommand,
+			demoOptions,
+		); err != nil {
+			rnlog.Error("Demo Error: %+v", err)
+			db.Close()
+			rnlog.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	// 커넥션 풀 상태를 메트릭으로 내보냅니다.
+	// 메트릭을 내보내지 못하더라도 서버는 실행합니다.
+	if sqlDB, err := database.SqlDB(db); err != nil {
+		rnlog.Error("DB pool metrics Error: %+v", err)
+	} else {
+		metrics.RegisterDBStats(sqlDB.Stats)
+	}
+
+	// 상품 이미지 같은 미디어 파일을 저장할 저장소를 만듭니다.
+	storage, err := media.New(conf)
+	if err != nil {
+		rnlog.Fatal("Media storage Error: %+v\n", err)
+		return
+	}
 
 	router := router.New(
 		database.NewUser(db),
 		database.NewProduct(db),
+		database.NewHealth(db),
+		storage,
 	)
 
-	rnlog.Info("JGC API server is running...")
-	rnlog.Info("Server port: %d", conf.Port)
+	// 종료 신호를 받으면 처리 중인 요청을 기다린 뒤 반환하므로,
+	// 이후 defer로 디비와 로거를 닫을 때 진행 중인 트랜잭션이 끊기지 않습니다.
+	srv := server.New(router, conf.Port, conf.ShutdownTimeout, conf.MaxBodySize())
+
+	// 요청 추적을 설정합니다. 익스포터가 none이라면 아무것도 기록하지 않습니다.
+	// 스팬을 내보내는 작업은 서버가 종료될 때 남은 스팬을 모두 내보낸 뒤 멈춥니다.
+	if conf.Tracing.Exporter == "otlp" {
+		exporter := tracing.NewOtlpExporter(conf.Tracing.Endpoint, conf.Tracing.Headers, conf.Tracing.ServiceName)
+		tracer := tracing.Init(exporter, conf.Tracing.SampleRatio)
+		srv.Go("trace-exporter", tracer.Run)
+		rnlog.Info("Exporting traces to %s", conf.Tracing.Endpoint)
+	}
+
+	ctx := context.Background()
+	logger.Info(ctx, "JGC API server is running...")
+	logger.Info(ctx, "Server port: %d", conf.Port)
 
-	if err = router.Run(conf.Port); err != nil {
-		rnlog.Error("Server error: " + err.Error())
+	if err = srv.Run(); err != nil {
+		logger.Error(ctx, "Server error: %+v", err)
 	} else {
-		rnlog.Info("Server is shutting down...")
+		logger.Info(ctx, "Server is shutting down...")
 	}
 }
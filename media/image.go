@@ -0,0 +1,146 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// 업로드할 수 있는 이미지의 content type과 확장자입니다.
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+// 업로드한 이미지가 올바르지 않을 때 반환하는 에러입니다.
+var (
+	ErrUnsupportedImage = errors.New("unsupported image type")
+	ErrImageTooLarge    = errors.New("image is too large")
+)
+
+// 검증을 통과한 이미지 정보입니다.
+type Image struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	img         image.Image
+}
+
+// 업로드할 수 있는 content type인지 확인합니다.
+func IsSupportedImageType(contentType string) bool {
+	_, ok := imageExtensions[contentType]
+	return ok
+}
+
+// 업로드한 데이터가 선언한 content type의 이미지가 맞는지 검증하고 디코딩합니다.
+// 파일 내용으로 판별한 타입이 선언한 타입과 다르거나, 디코딩할 수 없다면 ErrUnsupportedImage를,
+// 크기나 픽셀 수가 제한을 넘는다면 ErrImageTooLarge를 반환합니다.
+func DecodeImage(data []byte, contentType string, maxSize, maxPixels int) (*Image, error) {
+	if len(data) > maxSize {
+		return nil, ErrImageTooLarge
+	}
+	ext, ok := imageExtensions[contentType]
+	if !ok || http.DetectContentType(data) != contentType {
+		return nil, ErrUnsupportedImage
+	}
+	// 픽셀 수가 너무 큰 이미지를 디코딩하면 메모리를 많이 쓰므로 헤더만 먼저 읽어 확인합니다.
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if conf.Width <= 0 || conf.Height <= 0 {
+		return nil, ErrUnsupportedImage
+	}
+	if conf.Width*conf.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	return &Image{
+		ContentType: contentType,
+		Extension:   ext,
+		Width:       conf.Width,
+		Height:      conf.Height,
+		img:         img,
+	}, nil
+}
+
+// 긴 변이 size 픽셀이 되도록 줄인 썸네일을 원본과 같은 형식으로 인코딩해 반환합니다.
+// 원본이 이미 작다면 크기를 바꾸지 않고 다시 인코딩합니다.
+func (i *Image) Thumbnail(size int) ([]byte, error) {
+	width, height := i.Width, i.Height
+	if width > size || height > size {
+		if width >= height {
+			height = maxInt(1, height*size/width)
+			width = size
+		} else {
+			width = maxInt(1, width*size/height)
+			height = size
+		}
+	}
+	thumb := resize(i.img, width, height)
+
+	buf := &bytes.Buffer{}
+	switch i.ContentType {
+	case "image/jpeg":
+		if err := jpeg.Encode(buf, thumb, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+	case "image/png":
+		if err := png.Encode(buf, thumb); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedImage
+	}
+	return buf.Bytes(), nil
+}
+
+// 두 정수 중 큰 값을 반환합니다.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// 원본의 각 영역 평균 색으로 이미지를 width x height 크기로 줄입니다. (box filter)
+// 투명한 부분의 색이 섞이지 않도록 알파가 곱해진 색(RGBA)으로 평균을 냅니다.
+func resize(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := bounds.Min.Y + maxInt((y+1)*srcH/height, y*srcH/height+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := bounds.Min.X + maxInt((x+1)*srcW/width, x*srcW/width+1)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.RGBA64Model.Convert(src.At(sx, sy)).(color.RGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
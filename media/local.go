@@ -0,0 +1,73 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 로컬 디스크에 파일을 저장하는 저장소입니다.
+// 개발 환경에서 사용하며, 파일은 별도의 정적 파일 서버로 제공해야 합니다.
+type LocalStorage struct {
+	root      string
+	publicUrl string
+}
+
+// key를 root 아래의 파일 경로로 바꿔줍니다.
+// root 밖을 가리키는 key라면 에러를 반환합니다.
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + filepath.FromSlash(key))
+	if clean == string(filepath.Separator) {
+		return "", errors.New("empty media key")
+	}
+	return filepath.Join(s.root, clean), nil
+}
+
+// 파일을 저장합니다.
+// 임시 파일에 먼저 쓴 뒤 옮기므로, 쓰는 도중 실패해도 반쯤 쓰인 파일이 남지 않습니다.
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// 파일을 삭제합니다.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// 파일에 접근할 수 있는 주소를 반환합니다.
+func (s *LocalStorage) Url(key string) string {
+	return s.publicUrl + "/" + strings.TrimPrefix(key, "/")
+}
+
+// 로컬 저장소를 만들어줍니다.
+// publicUrl은 root 폴더를 제공하는 정적 파일 서버의 주소입니다.
+func NewLocal(root, publicUrl string) *LocalStorage {
+	return &LocalStorage{
+		root:      root,
+		publicUrl: strings.TrimSuffix(publicUrl, "/"),
+	}
+}
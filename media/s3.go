@@ -0,0 +1,182 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// S3 호환 저장소(AWS S3, MinIO 등)에 파일을 저장하는 저장소입니다.
+// SDK 없이 AWS Signature Version 4로 서명한 요청을 직접 보내며, path-style 주소(endpoint/bucket/key)를 사용합니다.
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicUrl string
+	client    *http.Client
+}
+
+// 서명에 사용할 sha256 해시를 16진수 문자열로 반환합니다.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// 서명에 사용할 HMAC-SHA256 값을 반환합니다.
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// 버킷과 key로 요청 경로를 만들어줍니다. key의 각 부분은 URI 인코딩합니다.
+func (s *S3Storage) objectPath(key string) string {
+	parts := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, v := range parts {
+		parts[i] = url.PathEscape(v)
+	}
+	return strings.TrimSuffix(s.endpoint.Path, "/") + "/" + url.PathEscape(s.bucket) + "/" + strings.Join(parts, "/")
+}
+
+// 요청에 AWS Signature Version 4 서명을 추가합니다.
+func (s *S3Storage) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	headerValues := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		signedHeaders = append([]string{"content-type"}, signedHeaders...)
+		headerValues["content-type"] = contentType
+	}
+	canonicalHeaders := ""
+	for _, v := range signedHeaders {
+		canonicalHeaders += v + ":" + strings.TrimSpace(headerValues[v]) + "\n"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+	signingKey := hmacSha256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSha256(signingKey, s.region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, strings.Join(signedHeaders, ";"), signature,
+	))
+}
+
+// 서명한 요청을 보내고, 성공 응답이 아니라면 에러를 반환합니다.
+// 404 응답은 allowNotFound가 true일 때만 성공으로 봅니다.
+func (s *S3Storage) do(ctx context.Context, method, key string, data []byte, contentType string, allowNotFound bool) (err error) {
+	ctx, span := tracing.Start(ctx, "S3 "+method, tracing.KindClient,
+		tracing.String("http.method", method),
+		tracing.String("aws.s3.bucket", s.bucket),
+		tracing.String("aws.s3.key", key),
+	)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	u := *s.endpoint
+	u.RawPath = s.objectPath(key)
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, sha256Hex(data), time.Now())
+	// traceparent는 서명하지 않는 헤더이므로 서명 뒤에 넣어도 됩니다.
+	tracing.Inject(ctx, req.Header)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 == 2 || (allowNotFound && res.StatusCode == http.StatusNotFound) {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3 %s %s failed: %s: %s", method, key, res.Status, strings.TrimSpace(string(body)))
+}
+
+// 파일을 저장합니다.
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	return s.do(ctx, http.MethodPut, key, data, contentType, false)
+}
+
+// 파일을 삭제합니다.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, http.MethodDelete, key, nil, "", true)
+}
+
+// 파일에 접근할 수 있는 주소를 반환합니다.
+func (s *S3Storage) Url(key string) string {
+	return s.publicUrl + "/" + strings.TrimPrefix(key, "/")
+}
+
+// S3 호환 저장소를 만들어줍니다.
+// publicUrl이 비어있다면 endpoint/bucket을 파일 주소로 사용합니다.
+func NewS3(endpoint, region, bucket, accessKey, secretKey, publicUrl string) (*S3Storage, error) {
+	if endpoint == "" || bucket == "" || accessKey == "" || secretKey == "" {
+		return nil, errors.New("s3 endpoint, bucket and keys are required")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	if publicUrl == "" {
+		publicUrl = strings.TrimSuffix(endpoint, "/") + "/" + bucket
+	}
+	return &S3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		publicUrl: strings.TrimSuffix(publicUrl, "/"),
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
@@ -0,0 +1,39 @@
+package media
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JongGeonClass/JGC-API/config"
+)
+
+// 미디어 파일을 저장할 저장소의 인터페이스입니다.
+// key는 "products/1/abc.jpg"처럼 슬래시로 구분된 상대 경로입니다.
+type Storage interface {
+	// 파일을 저장합니다. 같은 key의 파일이 있다면 덮어씁니다.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+
+	// 파일을 삭제합니다. 존재하지 않는 파일이라면 무시합니다.
+	Delete(ctx context.Context, key string) error
+
+	// 클라이언트가 파일에 접근할 수 있는 주소를 반환합니다.
+	Url(key string) string
+}
+
+// 설정에 맞는 저장소를 만들어줍니다.
+func New(conf *config.Config) (Storage, error) {
+	switch conf.Media.Driver {
+	case "local":
+		return NewLocal(conf.Media.LocalRoot, conf.Media.PublicUrl), nil
+	case "s3":
+		return NewS3(
+			conf.Media.S3Endpoint,
+			conf.Media.S3Region,
+			conf.Media.S3Bucket,
+			conf.Media.S3AccessKey,
+			conf.Media.S3SecretKey,
+			conf.Media.PublicUrl,
+		)
+	}
+	return nil, fmt.Errorf("unknown media driver: %s", conf.Media.Driver)
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 서버에서 기록하는 메트릭입니다.
+var (
+	// 라우트별 HTTP 요청 수입니다. route는 등록된 경로이며, 등록되지 않은 경로는 unmatched로 묶습니다.
+	HttpRequests = NewCounter(
+		"jgc_http_requests_total",
+		"Number of HTTP requests by method, route and status code.",
+		"method", "route", "status",
+	)
+
+	// 라우트별 HTTP 요청 처리 시간(초)입니다.
+	HttpRequestDuration = NewHistogram(
+		"jgc_http_request_duration_seconds",
+		"HTTP request latency in seconds by method and route.",
+		DefaultBuckets,
+		"method", "route",
+	)
+
+	// 회원가입에 성공한 수입니다.
+	Signups = NewCounter(
+		"jgc_signups_total",
+		"Number of successful sign ups.",
+	)
+
+	// 로그인 시도 수입니다. result는 success 또는 failure입니다.
+	Logins = NewCounter(
+		"jgc_logins_total",
+		"Number of login attempts by result.",
+		"result",
+	)
+
+	// 장바구니에 상품을 담은 수입니다. cart는 user 또는 guest입니다.
+	CartAdds = NewCounter(
+		"jgc_cart_adds_total",
+		"Number of products added to carts by cart type.",
+		"cart",
+	)
+
+	// 작성된 리뷰 수입니다.
+	Reviews = NewCounter(
+		"jgc_reviews_total",
+		"Number of reviews and replies written.",
+	)
+)
+
+var registerDBStatsOnce sync.Once
+
+// 디비 커넥션 풀 상태를 메트릭으로 등록합니다.
+// stats는 메트릭을 내보낼 때마다 호출되며, 여러 번 호출해도 처음 한 번만 등록됩니다.
+func RegisterDBStats(stats func() sql.DBStats) {
+	registerDBStatsOnce.Do(func() {
+		NewGaugeFunc("jgc_db_connections_open", "Number of established connections in the DB pool.", func() float64 {
+			return float64(stats().OpenConnections)
+		})
+		NewGaugeFunc("jgc_db_connections_in_use", "Number of DB connections currently in use.", func() float64 {
+			return float64(stats().InUse)
+		})
+		NewGaugeFunc("jgc_db_connections_idle", "Number of idle DB connections.", func() float64 {
+			return float64(stats().Idle)
+		})
+		NewGaugeFunc("jgc_db_connections_max_open", "Maximum number of open DB connections.", func() float64 {
+			return float64(stats().MaxOpenConnections)
+		})
+		NewCounterFunc("jgc_db_wait_count_total", "Total number of times a request waited for a DB connection.", func() float64 {
+			return float64(stats().WaitCount)
+		})
+		NewCounterFunc("jgc_db_wait_duration_seconds_total", "Total time spent waiting for a DB connection in seconds.", func() float64 {
+			return stats().WaitDuration.Seconds()
+		})
+	})
+}
+
+// 요청 하나의 처리 결과를 기록합니다.
+func ObserveHttpRequest(method, route string, status int, elapsed time.Duration) {
+	HttpRequests.Inc(method, route, strconv.Itoa(status))
+	HttpRequestDuration.Observe(elapsed.Seconds(), method, route)
+}
@@ -0,0 +1,240 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Prometheus 텍스트 형식(0.0.4)으로 내보낼 수 있는 간단한 메트릭 모음입니다.
+// 메트릭은 패키지 변수로 한 번만 만들어 등록하고, 여러 고루틴에서 동시에 값을 바꿀 수 있습니다.
+
+// 등록된 메트릭입니다. 등록한 순서대로 내보냅니다.
+type collector interface {
+	write(w io.Writer)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []collector
+	names      = map[string]bool{}
+)
+
+// 메트릭을 등록합니다. 같은 이름으로 두 번 등록하면 패닉을 일으킵니다.
+func register(name string, c collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if names[name] {
+		panic("metrics: duplicate metric " + name)
+	}
+	names[name] = true
+	registry = append(registry, c)
+}
+
+// 등록된 모든 메트릭을 Prometheus 텍스트 형식으로 씁니다.
+func Write(w io.Writer) {
+	registryMu.Lock()
+	collectors := append([]collector{}, registry...)
+	registryMu.Unlock()
+	for _, v := range collectors {
+		v.write(w)
+	}
+}
+
+// 레이블 값에 들어갈 수 없는 문자를 이스케이프합니다.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// 레이블 이름과 값으로 {a="1",b="2"} 형식의 문자열을 만들어줍니다.
+// extra는 히스토그램의 le처럼 뒤에 덧붙일 레이블입니다.
+func formatLabels(labels, values []string, extra ...string) string {
+	parts := []string{}
+	for i, v := range labels {
+		parts = append(parts, v+`="`+labelEscaper.Replace(values[i])+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		parts = append(parts, extra[i]+`="`+labelEscaper.Replace(extra[i+1])+`"`)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// 숫자를 Prometheus 형식으로 바꿔줍니다.
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// 레이블 값들을 맵의 키로 쓸 수 있도록 하나의 문자열로 합칩니다.
+func labelKey(name string, labels, values []string) string {
+	if len(values) != len(labels) {
+		panic(fmt.Sprintf("metrics: %s needs %d label values, got %d", name, len(labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// 키를 정렬해서 항상 같은 순서로 내보내도록 합니다.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// 증가만 하는 값입니다. (요청 수, 가입 수 등)
+type Counter struct {
+	name   string
+	help   string
+	labels []string
+	mu     sync.Mutex
+	values map[string]float64
+	series map[string][]string
+}
+
+// 카운터를 만들어 등록합니다.
+func NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: map[string]float64{},
+		series: map[string][]string{},
+	}
+	register(name, c)
+	return c
+}
+
+// 레이블 값에 해당하는 카운터를 1 증가시킵니다.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// 레이블 값에 해당하는 카운터를 v만큼 증가시킵니다. v는 0 이상이어야 합니다.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic("metrics: counter cannot decrease")
+	}
+	key := labelKey(c.name, c.labels, labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.series[key]; !ok {
+		c.series[key] = append([]string{}, labelValues...)
+	}
+	c.values[key] += v
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	if len(c.labels) == 0 && len(c.series) == 0 {
+		fmt.Fprintf(w, "%s 0\n", c.name)
+	}
+	for _, k := range sortedKeys(c.series) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, formatLabels(c.labels, c.series[k]), formatValue(c.values[k]))
+	}
+}
+
+// 요청 시간처럼 값의 분포를 구간별로 세는 히스토그램입니다.
+type Histogram struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+	mu      sync.Mutex
+	counts  map[string][]uint64
+	sums    map[string]float64
+	series  map[string][]string
+}
+
+// HTTP 요청 시간(초)에 알맞은 기본 구간입니다.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// 히스토그램을 만들어 등록합니다. buckets는 오름차순이어야 합니다.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: buckets,
+		counts:  map[string][]uint64{},
+		sums:    map[string]float64{},
+		series:  map[string][]string{},
+	}
+	register(name, h)
+	return h
+}
+
+// 레이블 값에 해당하는 히스토그램에 값을 기록합니다.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	key := labelKey(h.name, h.labels, labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	counts, ok := h.counts[key]
+	if !ok {
+		// 마지막 칸은 +Inf 구간입니다.
+		counts = make([]uint64, len(h.buckets)+1)
+		h.counts[key] = counts
+		h.series[key] = append([]string{}, labelValues...)
+	}
+	for i, b := range h.buckets {
+		if v <= b {
+			counts[i]++
+		}
+	}
+	counts[len(h.buckets)]++
+	h.sums[key] += v
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	for _, k := range sortedKeys(h.series) {
+		values := h.series[k]
+		counts := h.counts[k]
+		for i, b := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labels, values, "le", formatValue(b)), counts[i])
+		}
+		total := counts[len(h.buckets)]
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, formatLabels(h.labels, values, "le", "+Inf"), total)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, formatLabels(h.labels, values), formatValue(h.sums[k]))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, formatLabels(h.labels, values), total)
+	}
+}
+
+// 내보낼 때마다 함수를 호출해서 값을 가져오는 메트릭입니다.
+// 커넥션 풀 상태처럼 다른 곳에서 관리하는 값을 내보낼 때 사용합니다.
+type valueFunc struct {
+	name      string
+	help      string
+	valueType string
+	fn        func() float64
+}
+
+// 내보낼 때마다 fn으로 값을 가져오는 게이지를 등록합니다.
+func NewGaugeFunc(name, help string, fn func() float64) {
+	register(name, &valueFunc{name, help, "gauge", fn})
+}
+
+// 내보낼 때마다 fn으로 값을 가져오는 카운터를 등록합니다.
+// fn은 누적된 값을 반환해야 합니다.
+func NewCounterFunc(name, help string, fn func() float64) {
+	register(name, &valueFunc{name, help, "counter", fn})
+}
+
+func (f *valueFunc) write(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %s\n", f.name, f.help, f.name, f.valueType, f.name, formatValue(f.fn()))
+}
@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/JongGeonClass/JGC-API/config"
 	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/logger"
 	"github.com/JongGeonClass/JGC-API/model"
 	"github.com/JongGeonClass/JGC-API/util"
 	"github.com/thak1411/gorn"
-	"github.com/thak1411/rnlog"
 )
 
 type AuthMiddleware struct {
@@ -19,16 +22,18 @@ func (md *AuthMiddleware) TokenDecode(c *gorn.Context) {
 
 	token, err := c.GetCookie(conf.Cookies.SessionName)
 	if err != nil {
-		rnlog.Error("token decode cookie get error: %+v", err)
+		logger.Error(c.GetContext(), "token decode cookie get error: %+v", err)
 		c.SendNotAuthorized()
 		return
 	}
 	tok, claims, err := util.AuthUserToken(token.Value, conf.Jwt.SecretKey)
 	if err != nil || !tok.Valid {
-		rnlog.Error("token decode validation error: %+v", err)
+		logger.Error(c.GetContext(), "token decode validation error: %+v", err)
 		c.SendNotAuthorized()
 		return
 	}
+	// 접근 로그와 요청 중에 남기는 로그에 유저 아이디를 남길 수 있도록 기록해둡니다.
+	logger.SetUserId(c.GetContext(), claims.(model.AuthUserTokenClaims).Id)
 	c.SetValue(conf.Cookies.SessionName, claims)
 }
 
@@ -49,11 +54,39 @@ func (md *AuthMiddleware) TokenDecodeWithGuest(c *gorn.Context) {
 			claims = model.GuestAuthUserTokenClaims
 		} else {
 			claims = clm
+			logger.SetUserId(c.GetContext(), clm.(model.AuthUserTokenClaims).Id)
 		}
 	}
 	c.SetValue(conf.Cookies.SessionName, claims)
 }
 
+// 게스트 쿠키를 읽어 Request Context에 게스트 아이디를 탑재합니다.
+// 게스트 쿠키가 없거나 올바르지 않다면 새로 발급합니다.
+// 로그인한 사용자라면 아무것도 하지 않으므로 TokenDecodeWithGuest 다음에 호출해야 합니다.
+func (md *AuthMiddleware) GuestDecode(c *gorn.Context) {
+	conf := config.Get()
+
+	claims := c.GetValue(conf.Cookies.SessionName).(model.AuthUserTokenClaims)
+	if claims.Id != model.GuestAuthUserTokenClaims.Id {
+		return
+	}
+	guestId := ""
+	if cookie, err := c.GetCookie(conf.Cookies.GuestSessionName); err == nil && util.IsUuid(cookie.Value) {
+		guestId = cookie.Value
+	} else {
+		guestId = util.NewUuid()
+	}
+	// 게스트 장바구니가 사라지지 않도록 요청이 올 때마다 유효기간을 늘려줍니다.
+	c.SetCookie(&http.Cookie{
+		Name:     conf.Cookies.GuestSessionName,
+		Path:     "/",
+		Expires:  time.Now().Add(conf.Cookies.GuestSessionTimeout),
+		Value:    guestId,
+		HttpOnly: true,
+	})
+	c.SetValue(conf.Cookies.GuestSessionName, guestId)
+}
+
 // Auth Middleware를 반환합니다.
 func NewAuth(userdb database.UserDatabase) *AuthMiddleware {
 	return &AuthMiddleware{
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/thak1411/gorn"
+)
+
+// 더 이상 사용하지 않을 버전의 EndPoint임을 응답 헤더로 알려줍니다.
+// successor는 대신 사용할 버전의 경로이며, Link 헤더로 함께 알려줍니다. (예: /api/v2)
+// 응답을 보내기 전에 헤더를 붙여야 하므로 가장 먼저 호출해야 합니다.
+func Deprecated(successor string) func(c *gorn.Context) {
+	link := fmt.Sprintf("<%s>; rel=\"successor-version\"", successor)
+	return func(c *gorn.Context) {
+		c.SetHeader("Deprecation", "true")
+		c.SetHeader("Link", link)
+		// 다른 오리진의 프론트엔드에서도 헤더를 읽을 수 있도록 노출합니다.
+		c.AddHeader("Access-Control-Expose-Headers", "Deprecation, Link")
+	}
+}
@@ -2,107 +2,436 @@ package migrate
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/JongGeonClass/JGC-API/database"
 	"github.com/JongGeonClass/JGC-API/dbmodel"
 	"github.com/thak1411/gorn"
 	"github.com/thak1411/rnlog"
 )
 
-// Drop All FK & INDEX
-// Migrate All Table For GORN
-// If you Migrate From Gorn Before, You Must Not Use This Function
-func MigrateAllReset(db *gorn.DB) error {
-	_, tableNames := dbmodel.GetTables()
-	err := db.ExecTx(context.Background(), func(txdb *gorn.DB) error {
-		// Delete All Foreign Key
-		rnlog.Info("Delete All Foreign Key...")
-		for _, tableName := range tableNames {
-			fkeys, err := txdb.GetForeignKeys(tableName)
-			if err != nil {
-				rnlog.Error("Get Foreign Key Error: %s", err.Error())
-				return err
+// 여러 컨테이너가 동시에 마이그레이션하지 못하도록 잡는 디비 락의 이름입니다.
+const lockName = "jgc_schema_migrations"
+
+// 다른 컨테이너가 잡은 락을 기다릴 최대 시간(초)입니다.
+const lockTimeout = 30
+
+// 기존 gorn 마이그레이션으로 만든 스키마와 같은 마이그레이션 번호입니다.
+const baselineVersion = 1
+
+// 마이그레이션 명령의 종류입니다.
+const (
+	ActionUp     = "up"
+	ActionDown   = "down"
+	ActionStatus = "status"
+	ActionTo     = "to"
+)
+
+// -migrate 플래그로 받은 마이그레이션 명령입니다.
+// Target은 ActionTo일 때만 사용합니다.
+type Command struct {
+	Action string
+	Target int64
+}
+
+// up, down, status, to=N 형식의 명령을 파싱합니다.
+func ParseCommand(command string) (*Command, error) {
+	switch command {
+	case ActionUp, ActionDown, ActionStatus:
+		return &Command{Action: command}, nil
+	}
+	if strings.HasPrefix(command, ActionTo+"=") {
+		target, err := strconv.ParseInt(strings.TrimPrefix(command, ActionTo+"="), 10, 64)
+		if err != nil || target < 0 {
+			return nil, fmt.Errorf("invalid migrate target: %s", command)
+		}
+		return &Command{Action: ActionTo, Target: target}, nil
+	}
+	return nil, fmt.Errorf("unknown migrate command: %s (up|down|status|to=N)", command)
+}
+
+// 한 번의 실행 동안 하나의 커넥션으로 마이그레이션을 실행하는 객체입니다.
+// 디비 락과 FOREIGN_KEY_CHECKS는 커넥션마다 따로 관리되므로, 풀이 아닌 커넥션 하나를 잡아서 사용합니다.
+// dryRun이라면 스키마를 바꾸는 SQL은 실행하지 않고 out에 출력합니다.
+type migrator struct {
+	conn       *sql.Conn
+	dryRun     bool
+	out        io.Writer
+	migrations []*Migration
+}
+
+// 다른 컨테이너가 마이그레이션하고 있다면 끝날 때까지 기다린 뒤 락을 잡습니다.
+func (m *migrator) lock(ctx context.Context) error {
+	var locked sql.NullInt64
+	if err := m.conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return fmt.Errorf("another migration is running: could not get lock %s in %d seconds", lockName, lockTimeout)
+	}
+	return nil
+}
+
+// 잡아둔 락을 풉니다.
+func (m *migrator) unlock(ctx context.Context) {
+	if _, err := m.conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName); err != nil {
+		rnlog.Error("Release migration lock Error: %+v", err)
+	}
+}
+
+// 현재 스키마에 만들어져 있는 테이블 이름을 모두 가져옵니다.
+func (m *migrator) tableNames(ctx context.Context) (map[string]bool, error) {
+	rows, err := m.conn.QueryContext(ctx,
+		"SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		result[name] = true
+	}
+	return result, rows.Err()
+}
+
+// SQL 한 문장을 실행합니다. dryRun이라면 실행하지 않고 출력합니다.
+func (m *migrator) exec(ctx context.Context, query string, params ...interface{}) error {
+	query = strings.TrimRight(query, "; \n")
+	if m.dryRun {
+		if len(params) > 0 {
+			fmt.Fprintf(m.out, "%s; -- %v\n", query, params)
+		} else {
+			fmt.Fprintf(m.out, "%s;\n", query)
+		}
+		return nil
+	}
+	_, err := m.conn.ExecContext(ctx, query, params...)
+	return err
+}
+
+// 마이그레이션 기록 테이블이 없다면 만듭니다.
+func (m *migrator) createTable(ctx context.Context) error {
+	sql := gorn.NewSql().CreateTable(dbmodel.SchemaMigrationsTable, &dbmodel.SchemaMigration{})
+	return m.exec(ctx, sql.Query())
+}
+
+// 적용한 마이그레이션 기록을 번호 순서대로 가져옵니다.
+// 기록 테이블이 아직 없다면 빈 리스트를 반환합니다.
+func (m *migrator) applied(ctx context.Context) ([]*dbmodel.SchemaMigration, error) {
+	result := []*dbmodel.SchemaMigration{}
+	tables, err := m.tableNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !tables[dbmodel.SchemaMigrationsTable] {
+		return result, nil
+	}
+	sql := gorn.NewSql().
+		Select(&dbmodel.SchemaMigration{}).
+		From(dbmodel.SchemaMigrationsTable).
+		OrderBy("version").ASC()
+	rows, err := m.conn.QueryContext(ctx, sql.Query(), sql.Params()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		v := &dbmodel.SchemaMigration{}
+		if err := rows.Scan(&v.Version, &v.Name, &v.AppliedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, rows.Err()
+}
+
+// 적용한 마이그레이션 기록으로 현재 버전을 구합니다.
+// 기록은 1번부터 빠짐없이 이어져 있어야 합니다.
+func currentVersion(applied []*dbmodel.SchemaMigration) (int64, error) {
+	for i, v := range applied {
+		if v.Version != int64(i+1) {
+			return 0, fmt.Errorf("%s has a gap: expected version %d, found %d", dbmodel.SchemaMigrationsTable, i+1, v.Version)
+		}
+	}
+	return int64(len(applied)), nil
+}
+
+// 현재 스키마에 만들어져 있는 컬럼 이름을 테이블별로 모두 가져옵니다.
+func (m *migrator) columnNames(ctx context.Context) (map[string]map[string]bool, error) {
+	rows, err := m.conn.QueryContext(ctx,
+		"SELECT TABLE_NAME, COLUMN_NAME FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = DATABASE()")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := make(map[string]map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+		if result[table] == nil {
+			result[table] = make(map[string]bool)
+		}
+		result[table][column] = true
+	}
+	return result, rows.Err()
+}
+
+// 기록 없는 스키마가 기준 마이그레이션으로 만든 스키마인지 확인합니다.
+// 기준 마이그레이션의 테이블이 하나도 없다면 새 디비이므로 false를 반환합니다.
+// 기준 마이그레이션의 테이블이나 컬럼이 일부만 있거나, 이후 마이그레이션이 추가하는 테이블이나 컬럼이 이미 있다면
+// 어느 버전인지 알 수 없으므로 에러를 반환합니다.
+func checkLegacy(migrations []*Migration, columns map[string]map[string]bool) (bool, error) {
+	baseline := migrations[baselineVersion-1].addedColumns()
+	tables := make([]string, 0, len(baseline))
+	for table := range baseline {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	missingTables, missingColumns := []string{}, []string{}
+	for _, table := range tables {
+		if columns[table] == nil {
+			missingTables = append(missingTables, table)
+			continue
+		}
+		for _, column := range baseline[table] {
+			if !columns[table][column] {
+				missingColumns = append(missingColumns, table+"."+column)
 			}
-			for _, fkey := range fkeys {
-				if err := txdb.DropForeignKey(tableName, fkey.ConstraintName); err != nil {
-					rnlog.Error("Drop Foreign Key Error: %s", err.Error())
-					return err
+		}
+	}
+	later := []string{}
+	for _, migration := range migrations[baselineVersion:] {
+		for table, added := range migration.addedColumns() {
+			for _, column := range added {
+				if columns[table][column] {
+					later = append(later, fmt.Sprintf("%s.%s (%04d_%s)", table, column, migration.Version, migration.Name))
 				}
 			}
 		}
-		// Delete All Index
-		rnlog.Info("Delete All Index...")
-		indexes, err := txdb.GetIndexes()
-		if err != nil {
-			rnlog.Error("Get Index Error: %s", err.Error())
+	}
+	sort.Strings(later)
+
+	if len(later) > 0 {
+		return false, fmt.Errorf("schema has no migration history and already has columns added after the baseline: %s", strings.Join(later, ", "))
+	}
+	if len(missingTables) == len(tables) {
+		return false, nil
+	}
+	if len(missingTables) > 0 {
+		return false, fmt.Errorf("schema has no migration history and is missing tables: %s", strings.Join(missingTables, ", "))
+	}
+	if len(missingColumns) > 0 {
+		return false, fmt.Errorf("schema has no migration history and is missing columns: %s", strings.Join(missingColumns, ", "))
+	}
+	return true, nil
+}
+
+// 기록 없이 기존 gorn 마이그레이션으로 만든 디비라면 기준 마이그레이션만 적용한 것으로 기록합니다.
+// 이후 마이그레이션은 기록한 뒤에 이어서 적용합니다.
+// 새 디비라면 아무것도 하지 않고 false를 반환합니다.
+func (m *migrator) adoptLegacy(ctx context.Context) (bool, error) {
+	columns, err := m.columnNames(ctx)
+	if err != nil {
+		return false, err
+	}
+	if legacy, err := checkLegacy(m.migrations, columns); err != nil || !legacy {
+		return false, err
+	}
+	rnlog.Info("Existing schema found without migration history, recording version %d as applied", baselineVersion)
+	return true, m.record(ctx, m.migrations[baselineVersion-1])
+}
+
+// 마이그레이션을 적용했다고 기록합니다.
+func (m *migrator) record(ctx context.Context, migration *Migration) error {
+	sql := gorn.NewSql().Insert(dbmodel.SchemaMigrationsTable, &dbmodel.SchemaMigration{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		AppliedAt: time.Now().UTC(),
+	})
+	return m.exec(ctx, sql.Query(), sql.Params()...)
+}
+
+// 마이그레이션 기록을 지웁니다.
+func (m *migrator) unrecord(ctx context.Context, migration *Migration) error {
+	sql := gorn.NewSql().
+		DeleteFrom(dbmodel.SchemaMigrationsTable).
+		Where("version = ?", migration.Version)
+	return m.exec(ctx, sql.Query(), sql.Params()...)
+}
+
+// 마이그레이션 하나의 SQL을 차례로 실행합니다.
+// DDL은 트랜잭션으로 되돌릴 수 없으므로, 중간에 실패했다면 몇 번째 문장인지 알려줍니다.
+func (m *migrator) run(ctx context.Context, migration *Migration, direction string, statements []string) error {
+	if m.dryRun {
+		fmt.Fprintf(m.out, "-- %04d_%s.%s.sql\n", migration.Version, migration.Name, direction)
+	} else {
+		rnlog.Info("Migrate %s: %04d_%s", direction, migration.Version, migration.Name)
+	}
+	for i, statement := range statements {
+		if err := m.exec(ctx, statement); err != nil {
+			return fmt.Errorf("%04d_%s.%s.sql statement %d/%d: %v (schema may be partially migrated)",
+				migration.Version, migration.Name, direction, i+1, len(statements), err)
+		}
+	}
+	if direction == ActionUp {
+		return m.record(ctx, migration)
+	}
+	return m.unrecord(ctx, migration)
+}
+
+// 현재 버전에서 target 버전까지 마이그레이션을 적용하거나 되돌립니다.
+func (m *migrator) migrateTo(ctx context.Context, current, target int64) error {
+	if current == target {
+		rnlog.Info("Schema is already at version %d", current)
+		return nil
+	}
+	for v := current + 1; v <= target; v++ {
+		migration := m.migrations[v-1]
+		if err := m.run(ctx, migration, ActionUp, migration.Up); err != nil {
 			return err
 		}
-		for _, index := range indexes {
-			if err := txdb.DropIndex(index); err != nil {
-				rnlog.Error("Drop Index Error: %s", err.Error())
-				return err
-			}
+	}
+	for v := current; v > target; v-- {
+		migration := m.migrations[v-1]
+		if err := m.run(ctx, migration, ActionDown, migration.Down); err != nil {
+			return err
 		}
-		return nil
-	})
-	if err != nil {
-		rnlog.Error("Migrate Error: %+v\n", err)
-		return err
 	}
-	rnlog.Info("Migrate Success")
+	if !m.dryRun {
+		rnlog.Info("Schema migrated from version %d to %d", current, target)
+	}
 	return nil
 }
 
-func Migrate(db *gorn.DB) {
-	var err error
-	err = MigrateAllReset(db)
+// 마이그레이션 파일별로 적용 여부를 출력합니다.
+func (m *migrator) status(ctx context.Context) error {
+	applied, err := m.applied(ctx)
 	if err != nil {
-		rnlog.Error("Migrate All Reset Error: %+v\n", err)
-		return
-	}
-
-	tables, tableNames := dbmodel.GetTables()
-	indexes := dbmodel.GetIndexes()
-	err = db.ExecTx(context.Background(), func(txdb *gorn.DB) error {
-		// Set Foreign Key Check Off
-		rnlog.Info("Set Foreign Key Check Off...")
-		sql := gorn.NewSql().
-			Set("@OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0")
-		if result, err := txdb.Exec(context.Background(), sql); err != nil {
-			return err
-		} else if _, err := result.RowsAffected(); err != nil {
+		return err
+	}
+	appliedAt := make(map[int64]time.Time)
+	for _, v := range applied {
+		appliedAt[v.Version] = v.AppliedAt
+	}
+	for _, migration := range m.migrations {
+		if at, ok := appliedAt[migration.Version]; ok {
+			fmt.Fprintf(m.out, "%04d_%s\tapplied\t%s\n", migration.Version, migration.Name, at.Format(time.RFC3339))
+		} else {
+			fmt.Fprintf(m.out, "%04d_%s\tpending\n", migration.Version, migration.Name)
+		}
+	}
+	for _, v := range applied {
+		if v.Version > int64(len(m.migrations)) {
+			fmt.Fprintf(m.out, "%04d_%s\tapplied\t%s\t(no migration file)\n", v.Version, v.Name, v.AppliedAt.Format(time.RFC3339))
+		}
+	}
+	if len(applied) == 0 {
+		if legacy, err := m.hasLegacyTables(ctx); err != nil {
 			return err
+		} else if legacy {
+			fmt.Fprintf(m.out, "no migration history; existing tables will be recorded as version %d on the next up\n", baselineVersion)
 		}
+	}
+	return nil
+}
 
-		// Migrate Table
-		rnlog.Info("Migrate Tables...")
-		for i, table := range tables {
-			rnlog.Info("Migrate Table: %s", tableNames[i])
-			tableName := tableNames[i]
-			if err := txdb.Migration(tableName, table); err != nil {
-				rnlog.Error("Migrate Table Error: %s", err.Error())
-				return err
-			}
+// 마이그레이션 기록 없이 기준 마이그레이션의 테이블이 만들어져 있는지 확인합니다.
+func (m *migrator) hasLegacyTables(ctx context.Context) (bool, error) {
+	tables, err := m.tableNames(ctx)
+	if err != nil {
+		return false, err
+	}
+	for table := range m.migrations[baselineVersion-1].addedColumns() {
+		if tables[table] {
+			return true, nil
 		}
-		// Migrate Index
-		rnlog.Info("Migrate Indexes...")
-		if err := txdb.MigrationIndex(indexes); err != nil {
-			rnlog.Error("Migrate Index Error: %s", err.Error())
+	}
+	return false, nil
+}
+
+// 명령에 맞게 마이그레이션을 실행합니다.
+// dryRun이라면 디비를 바꾸지 않고 실행할 SQL을 표준 출력으로 출력합니다.
+func Run(db *gorn.DB, command string, dryRun bool) error {
+	cmd, err := ParseCommand(command)
+	if err != nil {
+		return err
+	}
+	migrations, err := Load()
+	if err != nil {
+		return err
+	}
+	latest := int64(len(migrations))
+
+	sqlDB, err := database.SqlDB(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	m := &migrator{conn: conn, dryRun: dryRun, out: os.Stdout, migrations: migrations}
+	if cmd.Action == ActionStatus {
+		return m.status(ctx)
+	}
+
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+	defer m.unlock(ctx)
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := currentVersion(applied)
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		if err := m.createTable(ctx); err != nil {
 			return err
 		}
-		// Set Foreign Key Check On
-		rnlog.Info("Set Foreign Key Check On...")
-		sql = gorn.NewSql().
-			Set("FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS")
-		if result, err := txdb.Exec(context.Background(), sql); err != nil {
-			return err
-		} else if _, err := result.RowsAffected(); err != nil {
+		if adopted, err := m.adoptLegacy(ctx); err != nil {
 			return err
+		} else if adopted {
+			current = baselineVersion
 		}
-		return nil
-	})
-	if err != nil {
-		rnlog.Error("Migrate Error: %+v\n", err)
-		return
 	}
-	rnlog.Info("Migrate Success")
+	if current > latest {
+		return fmt.Errorf("schema version %d is newer than the latest migration file %d", current, latest)
+	}
+
+	target := latest
+	switch cmd.Action {
+	case ActionDown:
+		if current == 0 {
+			return errors.New("no migration to roll back")
+		}
+		target = current - 1
+	case ActionTo:
+		if cmd.Target > latest {
+			return fmt.Errorf("migrate target %d is newer than the latest migration file %d", cmd.Target, latest)
+		}
+		target = cmd.Target
+	}
+	return m.migrateTo(ctx, current, target)
 }
@@ -0,0 +1,121 @@
+package migrate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    *Command
+		wantErr bool
+	}{
+		{"up", &Command{Action: ActionUp}, false},
+		{"down", &Command{Action: ActionDown}, false},
+		{"status", &Command{Action: ActionStatus}, false},
+		{"to=0", &Command{Action: ActionTo, Target: 0}, false},
+		{"to=12", &Command{Action: ActionTo, Target: 12}, false},
+		{"to=-1", nil, true},
+		{"to=", nil, true},
+		{"to=a", nil, true},
+		{"to", nil, true},
+		{"UP", nil, true},
+		{"", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommand(tt.command)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCommand(%q) error = %v, wantErr %v", tt.command, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseCommand(%q) = %+v, want %+v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentVersion(t *testing.T) {
+	applied := func(versions ...int64) []*dbmodel.SchemaMigration {
+		result := []*dbmodel.SchemaMigration{}
+		for _, v := range versions {
+			result = append(result, &dbmodel.SchemaMigration{Version: v})
+		}
+		return result
+	}
+	tests := []struct {
+		name    string
+		applied []*dbmodel.SchemaMigration
+		want    int64
+		wantErr bool
+	}{
+		{"none", applied(), 0, false},
+		{"baseline", applied(1), 1, false},
+		{"continuous", applied(1, 2, 3), 3, false},
+		{"gap", applied(1, 3), 0, true},
+		{"missing baseline", applied(2, 3), 0, true},
+		{"duplicate", applied(1, 1), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := currentVersion(tt.applied)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("currentVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("currentVersion() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLegacy(t *testing.T) {
+	migrations := loadMigrations(t)
+	latest := newTestSchema()
+	for _, v := range migrations {
+		latest.execAll(t, v.Name, v.Up)
+	}
+	tests := []struct {
+		name    string
+		columns func(columns map[string]map[string]bool) map[string]map[string]bool
+		want    bool
+		wantErr bool
+	}{
+		{"new database", func(map[string]map[string]bool) map[string]map[string]bool {
+			return map[string]map[string]bool{dbmodel.SchemaMigrationsTable: {"version": true}}
+		}, false, false},
+		{"legacy schema", func(c map[string]map[string]bool) map[string]map[string]bool { return c }, true, false},
+		{"missing table", func(c map[string]map[string]bool) map[string]map[string]bool {
+			delete(c, "REVIEW")
+			return c
+		}, false, true},
+		{"missing column", func(c map[string]map[string]bool) map[string]map[string]bool {
+			delete(c["USER"], "salt")
+			return c
+		}, false, true},
+		{"column added after baseline", func(c map[string]map[string]bool) map[string]map[string]bool {
+			c["PBV_OPTION"]["name"] = true
+			return c
+		}, false, true},
+		{"table added after baseline", func(c map[string]map[string]bool) map[string]map[string]bool {
+			c["WISHLIST"] = map[string]bool{"id": true}
+			return c
+		}, false, true},
+		{"latest schema", func(map[string]map[string]bool) map[string]map[string]bool {
+			return latest.columnNames()
+		}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkLegacy(migrations, tt.columns(legacySchema(t).columnNames()))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkLegacy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checkLegacy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+-- 0001_baseline에서 만든 모든 테이블을 지웁니다.
+
+SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0;
+
+DROP TABLE IF EXISTS `USER`;
+DROP TABLE IF EXISTS `PRODUCT_STATISTICS`;
+DROP TABLE IF EXISTS `REVIEW`;
+DROP TABLE IF EXISTS `PRODUCT_CATEGORY_MAP`;
+DROP TABLE IF EXISTS `PRODUCT`;
+DROP TABLE IF EXISTS `PBV_OPTION`;
+DROP TABLE IF EXISTS `CATEGORY`;
+DROP TABLE IF EXISTS `CART`;
+DROP TABLE IF EXISTS `BRAND`;
+
+SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;
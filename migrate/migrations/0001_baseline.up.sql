@@ -0,0 +1,116 @@
+-- 서버가 사용하던 모든 테이블과 인덱스를 만듭니다.
+-- 번호가 붙은 마이그레이션을 도입하기 전, 기존 gorn 마이그레이션(-migrate)으로 만들던 시점의 스키마입니다.
+-- 이후에 추가된 테이블과 컬럼은 0002번부터의 마이그레이션으로 만듭니다.
+-- 테이블끼리 서로 참조하므로 외래 키 검사를 끈 상태로 만듭니다.
+
+SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0;
+
+CREATE TABLE `BRAND` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `user_id` INT NOT NULL,
+  `name` VARCHAR(200) NOT NULL,
+  `email` VARCHAR(200) NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_BRAND_0 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `BRAND` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+
+CREATE TABLE `CART` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `user_id` INT NOT NULL,
+  `product_id` INT NOT NULL,
+  `amount` BIGINT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_CART_0 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_CART_1 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `CART` ADD UNIQUE INDEX `id_UNIQUE` (`user_id` ASC, `product_id` ASC);
+
+CREATE TABLE `CATEGORY` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `name` VARCHAR(30) NOT NULL,
+  `description` VARCHAR(200) NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE = InnoDB;
+ALTER TABLE `CATEGORY` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+
+CREATE TABLE `PBV_OPTION` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `user_id` INT NOT NULL,
+  `data` JSON NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PBV_OPTION_0 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PBV_OPTION` ADD UNIQUE INDEX `id_UNIQUE` (`user_id` ASC);
+
+CREATE TABLE `PRODUCT` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `brand_id` INT NOT NULL,
+  `name` VARCHAR(200) NOT NULL,
+  `price` BIGINT NOT NULL,
+  `amount` BIGINT NOT NULL,
+  `title_image_s3` VARCHAR(200) NOT NULL,
+  `description_s3` VARCHAR(200) NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_0 FOREIGN KEY (`brand_id`) REFERENCES BRAND (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+
+CREATE TABLE `PRODUCT_CATEGORY_MAP` (
+  `product_id` INT NOT NULL,
+  `category_id` INT NOT NULL,
+  PRIMARY KEY (`product_id`, `category_id`),
+  CONSTRAINT GORN_FK_PRODUCT_CATEGORY_MAP_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_CATEGORY_MAP_1 FOREIGN KEY (`category_id`) REFERENCES CATEGORY (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_CATEGORY_MAP` ADD UNIQUE INDEX `id_UNIQUE` (`product_id` ASC, `category_id` ASC);
+
+CREATE TABLE `REVIEW` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `user_id` INT NOT NULL,
+  `score` INT NOT NULL,
+  `content` VARCHAR(1000) NOT NULL,
+  `parent_review_id` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_REVIEW_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_REVIEW_1 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `REVIEW` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+
+CREATE TABLE `PRODUCT_STATISTICS` (
+  `product_id` INT NOT NULL,
+  `review_count` INT NOT NULL,
+  `sum_review_score` INT NOT NULL,
+  `sold_quantity` INT NOT NULL,
+  PRIMARY KEY (`product_id`),
+  CONSTRAINT GORN_FK_PRODUCT_STATISTICS_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_STATISTICS` ADD UNIQUE INDEX `id_UNIQUE` (`product_id` ASC);
+
+CREATE TABLE `USER` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `email` VARCHAR(200) NOT NULL,
+  `nickname` VARCHAR(30) NOT NULL,
+  `username` VARCHAR(30) NOT NULL,
+  `password` VARCHAR(512) NOT NULL,
+  `salt` VARCHAR(512) NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE = InnoDB;
+ALTER TABLE `USER` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `USER` ADD UNIQUE INDEX `username_UNIQUE` (`username` ASC);
+ALTER TABLE `USER` ADD INDEX `nickname_INDEX` (`nickname` ASC);
+
+SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;
@@ -0,0 +1,10 @@
+-- 0002_pbv_option_presets를 되돌립니다.
+-- 옵션을 두 개 이상 가진 유저가 있다면 유저 아이디의 유니크 인덱스를 만들 수 없으므로 실패합니다.
+
+DROP TABLE IF EXISTS `PBV_OPTION_VERSION`;
+ALTER TABLE `PBV_OPTION`
+  DROP INDEX `id_UNIQUE`,
+  ADD UNIQUE INDEX `id_UNIQUE` (`user_id` ASC),
+  DROP INDEX `user_id_INDEX`,
+  DROP COLUMN `name`,
+  DROP COLUMN `version`;
@@ -0,0 +1,23 @@
+-- pbv 옵션을 이름이 있는 여러 개의 프리셋으로 저장하고, 저장할 때마다 버전 기록을 남깁니다.
+-- 유저마다 하나뿐이던 옵션의 유니크 인덱스를 옵션 아이디로 바꾸고, 유저 아이디에는 일반 인덱스를 만듭니다.
+-- 외래 키가 사용할 인덱스가 항상 남아 있도록 인덱스는 한 문장으로 바꿉니다.
+
+ALTER TABLE `PBV_OPTION`
+  ADD COLUMN `name` VARCHAR(100) NOT NULL AFTER `user_id`,
+  ADD COLUMN `version` INT NOT NULL AFTER `name`,
+  ADD INDEX `user_id_INDEX` (`user_id` ASC),
+  DROP INDEX `id_UNIQUE`,
+  ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+UPDATE `PBV_OPTION` SET `name` = 'My PBV' WHERE `name` = '';
+
+CREATE TABLE `PBV_OPTION_VERSION` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `pbv_option_id` INT NOT NULL,
+  `version` INT NOT NULL,
+  `data` JSON NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PBV_OPTION_VERSION_0 FOREIGN KEY (`pbv_option_id`) REFERENCES PBV_OPTION (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PBV_OPTION_VERSION` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PBV_OPTION_VERSION` ADD UNIQUE INDEX `option_version_UNIQUE` (`pbv_option_id` ASC, `version` ASC);
@@ -0,0 +1,4 @@
+-- 0003_pbv_option_schema_version을 되돌립니다.
+
+ALTER TABLE `PBV_OPTION_VERSION` DROP COLUMN `schema_version`;
+ALTER TABLE `PBV_OPTION` DROP COLUMN `schema_version`;
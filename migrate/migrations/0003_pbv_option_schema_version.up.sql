@@ -0,0 +1,5 @@
+-- pbv 옵션 데이터가 어느 JSON Schema 버전으로 저장되었는지 기록합니다.
+-- 이미 저장된 데이터는 스키마가 없던 시절의 데이터이므로 0번 버전이 됩니다.
+
+ALTER TABLE `PBV_OPTION` ADD COLUMN `schema_version` INT NOT NULL AFTER `version`;
+ALTER TABLE `PBV_OPTION_VERSION` ADD COLUMN `schema_version` INT NOT NULL AFTER `version`;
@@ -0,0 +1,3 @@
+-- 0004_pbv_share를 되돌립니다.
+
+DROP TABLE IF EXISTS `PBV_SHARE`;
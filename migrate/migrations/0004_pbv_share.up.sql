@@ -0,0 +1,14 @@
+-- pbv 옵션을 공유 링크로 공개할 때 사용하는 토큰과 조회수를 기록합니다.
+
+CREATE TABLE `PBV_SHARE` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `pbv_option_id` INT NOT NULL,
+  `token` VARCHAR(64) NOT NULL,
+  `view_count` BIGINT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PBV_SHARE_0 FOREIGN KEY (`pbv_option_id`) REFERENCES PBV_OPTION (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PBV_SHARE` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PBV_SHARE` ADD UNIQUE INDEX `pbv_option_id_UNIQUE` (`pbv_option_id` ASC);
+ALTER TABLE `PBV_SHARE` ADD UNIQUE INDEX `token_UNIQUE` (`token` ASC);
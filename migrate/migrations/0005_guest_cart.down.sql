@@ -0,0 +1,3 @@
+-- 0005_guest_cart를 되돌립니다.
+
+DROP TABLE IF EXISTS `GUEST_CART`;
@@ -0,0 +1,13 @@
+-- 로그인하지 않은 사용자의 장바구니를 게스트 쿠키의 아이디로 저장합니다.
+
+CREATE TABLE `GUEST_CART` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `guest_id` VARCHAR(64) NOT NULL,
+  `product_id` INT NOT NULL,
+  `amount` BIGINT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_GUEST_CART_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `GUEST_CART` ADD UNIQUE INDEX `id_UNIQUE` (`guest_id` ASC, `product_id` ASC);
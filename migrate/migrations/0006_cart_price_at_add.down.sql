@@ -0,0 +1,4 @@
+-- 0006_cart_price_at_add를 되돌립니다.
+
+ALTER TABLE `GUEST_CART` DROP COLUMN `price_at_add`;
+ALTER TABLE `CART` DROP COLUMN `price_at_add`;
@@ -0,0 +1,5 @@
+-- 장바구니에 담을 때의 상품 가격을 기록해서, 이후 가격이 바뀐 상품을 알려줍니다.
+-- 이미 담겨 있던 상품은 0이 되며, 0은 가격을 기록하지 않은 것으로 보고 가격 변경을 알리지 않습니다.
+
+ALTER TABLE `CART` ADD COLUMN `price_at_add` BIGINT NOT NULL AFTER `amount`;
+ALTER TABLE `GUEST_CART` ADD COLUMN `price_at_add` BIGINT NOT NULL AFTER `amount`;
@@ -0,0 +1,4 @@
+-- 0007_wishlist를 되돌립니다.
+
+DROP TABLE IF EXISTS `WISHLIST`;
+ALTER TABLE `PRODUCT_STATISTICS` DROP COLUMN `favorite_count`;
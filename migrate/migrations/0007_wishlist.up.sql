@@ -0,0 +1,15 @@
+-- 유저가 찜한 상품과 상품별 찜 수를 기록합니다.
+
+ALTER TABLE `PRODUCT_STATISTICS` ADD COLUMN `favorite_count` INT NOT NULL AFTER `sold_quantity`;
+
+CREATE TABLE `WISHLIST` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `user_id` INT NOT NULL,
+  `product_id` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_WISHLIST_0 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_WISHLIST_1 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `WISHLIST` ADD UNIQUE INDEX `id_UNIQUE` (`user_id` ASC, `product_id` ASC);
+ALTER TABLE `WISHLIST` ADD INDEX `product_id_INDEX` (`product_id` ASC);
@@ -0,0 +1,7 @@
+-- 0008_coupon_order를 되돌립니다.
+
+DROP TABLE IF EXISTS `COUPON_REDEMPTION`;
+DROP TABLE IF EXISTS `ORDER_ITEM`;
+DROP TABLE IF EXISTS `ORDERS`;
+DROP TABLE IF EXISTS `PRODUCT_DISCOUNT`;
+DROP TABLE IF EXISTS `COUPON`;
@@ -0,0 +1,92 @@
+-- 쿠폰, 상품 할인과 주문을 기록합니다.
+-- 쿠폰 사용 기록이 주문을 참조하므로 주문 테이블을 먼저 만듭니다.
+
+CREATE TABLE `COUPON` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `code` VARCHAR(50) NOT NULL,
+  `name` VARCHAR(100) NOT NULL,
+  `discount_type` VARCHAR(10) NOT NULL,
+  `discount_value` BIGINT NOT NULL,
+  `max_discount` BIGINT NOT NULL,
+  `min_spend` BIGINT NOT NULL,
+  `brand_id` INT NOT NULL,
+  `category_id` INT NOT NULL,
+  `start_time` DATETIME NOT NULL,
+  `end_time` DATETIME NOT NULL,
+  `usage_limit` INT NOT NULL,
+  `per_user_limit` INT NOT NULL,
+  `used_count` INT NOT NULL,
+  `created_by` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_COUPON_0 FOREIGN KEY (`created_by`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `COUPON` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `COUPON` ADD UNIQUE INDEX `code_UNIQUE` (`code` ASC);
+
+CREATE TABLE `PRODUCT_DISCOUNT` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `discount_type` VARCHAR(10) NOT NULL,
+  `discount_value` BIGINT NOT NULL,
+  `start_time` DATETIME NOT NULL,
+  `end_time` DATETIME NOT NULL,
+  `created_by` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_DISCOUNT_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_DISCOUNT_1 FOREIGN KEY (`created_by`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_DISCOUNT` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PRODUCT_DISCOUNT` ADD INDEX `product_time_INDEX` (`product_id` ASC, `start_time` ASC);
+
+CREATE TABLE `ORDERS` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `user_id` INT NOT NULL,
+  `coupon_id` INT NOT NULL,
+  `subtotal` BIGINT NOT NULL,
+  `product_discount` BIGINT NOT NULL,
+  `coupon_discount` BIGINT NOT NULL,
+  `shipping_fee` BIGINT NOT NULL,
+  `total` BIGINT NOT NULL,
+  `status` VARCHAR(20) NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_ORDERS_0 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `ORDERS` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `ORDERS` ADD INDEX `user_id_INDEX` (`user_id` ASC);
+
+CREATE TABLE `ORDER_ITEM` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `order_id` INT NOT NULL,
+  `product_id` INT NOT NULL,
+  `product_name` VARCHAR(200) NOT NULL,
+  `original_price` BIGINT NOT NULL,
+  `unit_price` BIGINT NOT NULL,
+  `amount` BIGINT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_ORDER_ITEM_0 FOREIGN KEY (`order_id`) REFERENCES ORDERS (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_ORDER_ITEM_1 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `ORDER_ITEM` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `ORDER_ITEM` ADD INDEX `order_id_INDEX` (`order_id` ASC);
+ALTER TABLE `ORDER_ITEM` ADD INDEX `product_id_INDEX` (`product_id` ASC);
+
+CREATE TABLE `COUPON_REDEMPTION` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `coupon_id` INT NOT NULL,
+  `user_id` INT NOT NULL,
+  `order_id` INT NOT NULL,
+  `discount_amount` BIGINT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_COUPON_REDEMPTION_0 FOREIGN KEY (`coupon_id`) REFERENCES COUPON (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_COUPON_REDEMPTION_1 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_COUPON_REDEMPTION_2 FOREIGN KEY (`order_id`) REFERENCES ORDERS (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `COUPON_REDEMPTION` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `COUPON_REDEMPTION` ADD INDEX `coupon_user_INDEX` (`coupon_id` ASC, `user_id` ASC);
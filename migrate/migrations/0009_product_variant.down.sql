@@ -0,0 +1,16 @@
+-- 0009_product_variant를 되돌립니다.
+-- 같은 상품을 SKU별로 따로 담은 장바구니가 있다면 유니크 인덱스를 만들 수 없으므로 실패합니다.
+
+DROP TABLE IF EXISTS `PRODUCT_SKU_VALUE`;
+DROP TABLE IF EXISTS `PRODUCT_SKU`;
+DROP TABLE IF EXISTS `PRODUCT_VARIANT_VALUE`;
+DROP TABLE IF EXISTS `PRODUCT_VARIANT`;
+ALTER TABLE `ORDER_ITEM` DROP COLUMN `sku_id`;
+ALTER TABLE `GUEST_CART`
+  DROP INDEX `id_UNIQUE`,
+  ADD UNIQUE INDEX `id_UNIQUE` (`guest_id` ASC, `product_id` ASC),
+  DROP COLUMN `sku_id`;
+ALTER TABLE `CART`
+  DROP INDEX `id_UNIQUE`,
+  ADD UNIQUE INDEX `id_UNIQUE` (`user_id` ASC, `product_id` ASC),
+  DROP COLUMN `sku_id`;
@@ -0,0 +1,64 @@
+-- 상품의 옵션(색상, 사이즈 등)과 옵션 조합별 SKU를 기록하고, 장바구니와 주문에 SKU를 함께 저장합니다.
+-- 이미 저장된 장바구니와 주문은 SKU 아이디가 0이 되며, 0은 옵션이 없는 상품을 뜻합니다.
+-- 같은 상품이라도 SKU가 다르면 따로 담을 수 있도록 장바구니의 유니크 인덱스에 SKU를 추가합니다.
+
+ALTER TABLE `CART`
+  ADD COLUMN `sku_id` INT NOT NULL AFTER `product_id`,
+  DROP INDEX `id_UNIQUE`,
+  ADD UNIQUE INDEX `id_UNIQUE` (`user_id` ASC, `product_id` ASC, `sku_id` ASC);
+ALTER TABLE `GUEST_CART`
+  ADD COLUMN `sku_id` INT NOT NULL AFTER `product_id`,
+  DROP INDEX `id_UNIQUE`,
+  ADD UNIQUE INDEX `id_UNIQUE` (`guest_id` ASC, `product_id` ASC, `sku_id` ASC);
+ALTER TABLE `ORDER_ITEM` ADD COLUMN `sku_id` INT NOT NULL AFTER `product_id`;
+
+CREATE TABLE `PRODUCT_VARIANT` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `name` VARCHAR(50) NOT NULL,
+  `position` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_VARIANT_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_VARIANT` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PRODUCT_VARIANT` ADD UNIQUE INDEX `product_name_UNIQUE` (`product_id` ASC, `name` ASC);
+
+CREATE TABLE `PRODUCT_VARIANT_VALUE` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `variant_id` INT NOT NULL,
+  `value` VARCHAR(100) NOT NULL,
+  `position` INT NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_VARIANT_VALUE_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_VARIANT_VALUE_1 FOREIGN KEY (`variant_id`) REFERENCES PRODUCT_VARIANT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_VARIANT_VALUE` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PRODUCT_VARIANT_VALUE` ADD UNIQUE INDEX `variant_value_UNIQUE` (`variant_id` ASC, `value` ASC);
+
+CREATE TABLE `PRODUCT_SKU` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `code` VARCHAR(64) NOT NULL,
+  `price_delta` BIGINT NOT NULL,
+  `amount` BIGINT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_SKU_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_SKU` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PRODUCT_SKU` ADD UNIQUE INDEX `code_UNIQUE` (`code` ASC);
+ALTER TABLE `PRODUCT_SKU` ADD INDEX `product_id_INDEX` (`product_id` ASC);
+
+CREATE TABLE `PRODUCT_SKU_VALUE` (
+  `sku_id` INT NOT NULL,
+  `value_id` INT NOT NULL,
+  `product_id` INT NOT NULL,
+  PRIMARY KEY (`sku_id`, `value_id`),
+  CONSTRAINT GORN_FK_PRODUCT_SKU_VALUE_0 FOREIGN KEY (`sku_id`) REFERENCES PRODUCT_SKU (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_SKU_VALUE_1 FOREIGN KEY (`value_id`) REFERENCES PRODUCT_VARIANT_VALUE (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_SKU_VALUE_2 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_SKU_VALUE` ADD INDEX `product_id_INDEX` (`product_id` ASC);
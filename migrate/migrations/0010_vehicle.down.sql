@@ -0,0 +1,5 @@
+-- 0010_vehicle을 되돌립니다.
+
+DROP TABLE IF EXISTS `USER_VEHICLE`;
+DROP TABLE IF EXISTS `PRODUCT_VEHICLE_MAP`;
+DROP TABLE IF EXISTS `VEHICLE_MODEL`;
@@ -0,0 +1,34 @@
+-- 차종 목록과 상품이 맞는 차종, 유저가 등록한 내 차고를 기록합니다.
+
+CREATE TABLE `VEHICLE_MODEL` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `maker` VARCHAR(50) NOT NULL,
+  `model` VARCHAR(100) NOT NULL,
+  `year_from` INT NOT NULL,
+  `year_to` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE = InnoDB;
+ALTER TABLE `VEHICLE_MODEL` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `VEHICLE_MODEL` ADD UNIQUE INDEX `model_UNIQUE` (`maker` ASC, `model` ASC, `year_from` ASC);
+
+CREATE TABLE `PRODUCT_VEHICLE_MAP` (
+  `product_id` INT NOT NULL,
+  `vehicle_id` INT NOT NULL,
+  PRIMARY KEY (`product_id`, `vehicle_id`),
+  CONSTRAINT GORN_FK_PRODUCT_VEHICLE_MAP_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_VEHICLE_MAP_1 FOREIGN KEY (`vehicle_id`) REFERENCES VEHICLE_MODEL (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_VEHICLE_MAP` ADD INDEX `vehicle_id_INDEX` (`vehicle_id` ASC);
+
+CREATE TABLE `USER_VEHICLE` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `user_id` INT NOT NULL,
+  `vehicle_id` INT NOT NULL,
+  `nickname` VARCHAR(50) NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_USER_VEHICLE_0 FOREIGN KEY (`user_id`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_USER_VEHICLE_1 FOREIGN KEY (`vehicle_id`) REFERENCES VEHICLE_MODEL (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `USER_VEHICLE` ADD UNIQUE INDEX `id_UNIQUE` (`user_id` ASC, `vehicle_id` ASC);
@@ -0,0 +1,3 @@
+-- 0011_product_image를 되돌립니다.
+
+DROP TABLE IF EXISTS `PRODUCT_IMAGE`;
@@ -0,0 +1,20 @@
+-- 상품 갤러리 이미지와 썸네일의 저장 위치, 순서를 기록합니다.
+
+CREATE TABLE `PRODUCT_IMAGE` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `image_key` VARCHAR(200) NOT NULL,
+  `thumbnail_key` VARCHAR(200) NOT NULL,
+  `content_type` VARCHAR(50) NOT NULL,
+  `size` INT NOT NULL,
+  `width` INT NOT NULL,
+  `height` INT NOT NULL,
+  `position` INT NOT NULL,
+  `created_by` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_IMAGE_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_IMAGE_1 FOREIGN KEY (`created_by`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_IMAGE` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PRODUCT_IMAGE` ADD INDEX `product_id_INDEX` (`product_id` ASC, `position` ASC);
@@ -0,0 +1,4 @@
+-- 0012_product_description을 되돌립니다.
+
+DROP TABLE IF EXISTS `PRODUCT_DESCRIPTION_REVISION`;
+DROP TABLE IF EXISTS `PRODUCT_DESCRIPTION`;
@@ -0,0 +1,33 @@
+-- 구조화된 상품 설명과 수정 기록을 저장합니다.
+
+CREATE TABLE `PRODUCT_DESCRIPTION` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `format` VARCHAR(20) NOT NULL,
+  `content` TEXT NOT NULL,
+  `search_text` TEXT NOT NULL,
+  `revision` INT NOT NULL,
+  `updated_by` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  `updated_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_DESCRIPTION_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_DESCRIPTION_1 FOREIGN KEY (`updated_by`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_DESCRIPTION` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PRODUCT_DESCRIPTION` ADD UNIQUE INDEX `product_id_UNIQUE` (`product_id` ASC);
+
+CREATE TABLE `PRODUCT_DESCRIPTION_REVISION` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `product_id` INT NOT NULL,
+  `revision` INT NOT NULL,
+  `format` VARCHAR(20) NOT NULL,
+  `content` TEXT NOT NULL,
+  `created_by` INT NOT NULL,
+  `created_time` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  CONSTRAINT GORN_FK_PRODUCT_DESCRIPTION_REVISION_0 FOREIGN KEY (`product_id`) REFERENCES PRODUCT (id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT GORN_FK_PRODUCT_DESCRIPTION_REVISION_1 FOREIGN KEY (`created_by`) REFERENCES USER (id) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE = InnoDB;
+ALTER TABLE `PRODUCT_DESCRIPTION_REVISION` ADD UNIQUE INDEX `id_UNIQUE` (`id` ASC);
+ALTER TABLE `PRODUCT_DESCRIPTION_REVISION` ADD UNIQUE INDEX `product_revision_UNIQUE` (`product_id` ASC, `revision` ASC);
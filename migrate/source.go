@@ -0,0 +1,146 @@
+package migrate
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 번호가 붙은 마이그레이션 파일입니다.
+// {번호}_{이름}.up.sql과 {번호}_{이름}.down.sql을 한 쌍으로 만들어야 하며, 번호는 1부터 빠짐없이 이어져야 합니다.
+// 이미 배포된 파일은 고치지 말고 새 번호의 파일을 추가해 주세요.
+//
+//go:embed migrations/*.sql
+var files embed.FS
+
+// 마이그레이션 파일 이름의 형식입니다.
+var fileNameRegex = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// 마이그레이션 하나의 정보입니다.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      []string
+	Down    []string
+}
+
+// SQL 파일을 문장 단위로 나눕니다.
+// -- 로 시작하는 줄은 주석으로 보고 건너뛰며, 줄 끝의 세미콜론으로 문장을 구분합니다.
+func splitStatements(sql string) []string {
+	result := []string{}
+	lines := []string{}
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		lines = append(lines, line)
+		if strings.HasSuffix(trimmed, ";") {
+			result = append(result, strings.TrimSuffix(strings.TrimSpace(strings.Join(lines, "\n")), ";"))
+			lines = lines[:0]
+		}
+	}
+	if len(lines) > 0 {
+		result = append(result, strings.TrimSpace(strings.Join(lines, "\n")))
+	}
+	return result
+}
+
+// 마이그레이션 파일을 모두 불러와 번호 순서대로 반환합니다.
+// 파일 이름이 잘못되었거나, 짝이 맞지 않거나, 번호가 이어지지 않는다면 에러를 반환합니다.
+func Load() ([]*Migration, error) {
+	entries, err := files.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileNameRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+		b, err := files.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s, %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = splitStatements(string(b))
+		} else {
+			m.Down = splitStatements(string(b))
+		}
+	}
+	result := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+	for i, m := range result {
+		if m.Version != int64(i+1) {
+			return nil, fmt.Errorf("migration versions must start at 1 without gaps: missing %d", i+1)
+		}
+		if len(m.Up) == 0 || len(m.Down) == 0 {
+			return nil, fmt.Errorf("migration %d (%s) needs both up and down files", m.Version, m.Name)
+		}
+	}
+	return result, nil
+}
+
+// 마이그레이션 파일의 가장 높은 번호를 반환합니다.
+// 서버가 기대하는 디비 스키마 버전입니다.
+func Latest() (int64, error) {
+	migrations, err := Load()
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// 테이블을 만들거나 컬럼을 추가하는 SQL에서 테이블과 컬럼 이름을 읽는 형식입니다.
+var (
+	createTableRegex = regexp.MustCompile("^CREATE TABLE `([A-Za-z0-9_]+)` \\(")
+	alterTableRegex  = regexp.MustCompile("^ALTER TABLE `([A-Za-z0-9_]+)`")
+	columnLineRegex  = regexp.MustCompile("^\\s*`([A-Za-z0-9_]+)` ")
+	addColumnRegex   = regexp.MustCompile("ADD COLUMN `([A-Za-z0-9_]+)`")
+)
+
+// up 마이그레이션이 만드는 테이블과 추가하는 컬럼을 테이블별로 반환합니다.
+// CREATE TABLE은 모든 컬럼을, ALTER TABLE은 ADD COLUMN으로 추가하는 컬럼만 포함합니다.
+func (m *Migration) addedColumns() map[string][]string {
+	result := make(map[string][]string)
+	for _, statement := range m.Up {
+		if match := createTableRegex.FindStringSubmatch(statement); match != nil {
+			columns := []string{}
+			for _, line := range strings.Split(statement, "\n")[1:] {
+				if column := columnLineRegex.FindStringSubmatch(line); column != nil {
+					columns = append(columns, column[1])
+				}
+			}
+			result[match[1]] = columns
+		} else if match := alterTableRegex.FindStringSubmatch(statement); match != nil {
+			for _, column := range addColumnRegex.FindAllStringSubmatch(statement, -1) {
+				result[match[1]] = append(result[match[1]], column[1])
+			}
+		}
+	}
+	return result
+}
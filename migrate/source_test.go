@@ -0,0 +1,311 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/thak1411/gorn"
+)
+
+// 마이그레이션 SQL을 실행한 결과를 흉내 내는 스키마입니다.
+// 디비 없이 테이블, 컬럼 순서, 키, 인덱스가 어떻게 바뀌는지만 확인합니다.
+type testTable struct {
+	Columns []string
+	Keys    []string
+	Indexes map[string]string
+}
+
+type testSchema struct {
+	tables   map[string]*testTable
+	fkChecks bool
+}
+
+func newTestSchema() *testSchema {
+	return &testSchema{tables: make(map[string]*testTable), fkChecks: true}
+}
+
+var (
+	testCreateRegex    = regexp.MustCompile("^CREATE TABLE (?:IF NOT EXISTS )?`(\\w+)` \\(")
+	testAlterRegex     = regexp.MustCompile("^ALTER TABLE `(\\w+)` ")
+	testDropRegex      = regexp.MustCompile("^DROP TABLE IF EXISTS `(\\w+)`$")
+	testUpdateRegex    = regexp.MustCompile("^UPDATE `(\\w+)` ")
+	testAddColumnRegex = regexp.MustCompile("^ADD COLUMN (`(\\w+)` .*?)(?: AFTER `(\\w+)`)?$")
+	testAddIndexRegex  = regexp.MustCompile("^ADD (UNIQUE )?INDEX `(\\w+)` \\((.*)\\)$")
+	testReferenceRegex = regexp.MustCompile("REFERENCES (\\w+) ")
+	testSpaceRegex     = regexp.MustCompile(`\s+`)
+)
+
+// 괄호 밖의 쉼표로 나누고 공백을 정리합니다.
+func splitTopLevel(s string) []string {
+	result := []string{}
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				result = append(result, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	result = append(result, s[start:])
+	for i, v := range result {
+		result[i] = strings.TrimSpace(testSpaceRegex.ReplaceAllString(v, " "))
+	}
+	return result
+}
+
+func columnName(def string) string {
+	return strings.Trim(strings.SplitN(def, " ", 2)[0], "`")
+}
+
+func (t *testTable) columnIndex(name string) int {
+	for i, v := range t.Columns {
+		if columnName(v) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// SQL 한 문장을 스키마에 적용합니다.
+func (s *testSchema) exec(statement string) error {
+	statement = strings.TrimSpace(testSpaceRegex.ReplaceAllString(strings.TrimRight(statement, "; \n"), " "))
+	switch {
+	case strings.HasPrefix(statement, "SET "):
+		if strings.Contains(statement, "FOREIGN_KEY_CHECKS=0") {
+			s.fkChecks = false
+		} else if strings.Contains(statement, "FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS") {
+			s.fkChecks = true
+		}
+		return nil
+	case testUpdateRegex.MatchString(statement):
+		if name := testUpdateRegex.FindStringSubmatch(statement)[1]; s.tables[name] == nil {
+			return fmt.Errorf("update unknown table %s", name)
+		}
+		return nil
+	case testDropRegex.MatchString(statement):
+		delete(s.tables, testDropRegex.FindStringSubmatch(statement)[1])
+		return nil
+	case testCreateRegex.MatchString(statement):
+		name := testCreateRegex.FindStringSubmatch(statement)[1]
+		if s.tables[name] != nil {
+			return fmt.Errorf("table %s already exists", name)
+		}
+		body := statement[len(testCreateRegex.FindString(statement)):strings.LastIndex(statement, ")")]
+		table := &testTable{Columns: []string{}, Keys: []string{}, Indexes: make(map[string]string)}
+		for _, v := range splitTopLevel(body) {
+			if strings.HasPrefix(v, "`") {
+				table.Columns = append(table.Columns, v)
+				continue
+			}
+			if ref := testReferenceRegex.FindStringSubmatch(v); ref != nil && s.fkChecks && s.tables[ref[1]] == nil && ref[1] != name {
+				return fmt.Errorf("table %s references %s before it is created", name, ref[1])
+			}
+			table.Keys = append(table.Keys, v)
+		}
+		s.tables[name] = table
+		return nil
+	case testAlterRegex.MatchString(statement):
+		name := testAlterRegex.FindStringSubmatch(statement)[1]
+		table := s.tables[name]
+		if table == nil {
+			return fmt.Errorf("alter unknown table %s", name)
+		}
+		for _, clause := range splitTopLevel(statement[len(testAlterRegex.FindString(statement)):]) {
+			if err := table.alter(clause); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown statement: %s", statement)
+}
+
+// ALTER TABLE의 절 하나를 테이블에 적용합니다.
+func (t *testTable) alter(clause string) error {
+	if match := testAddColumnRegex.FindStringSubmatch(clause); match != nil {
+		if t.columnIndex(match[2]) >= 0 {
+			return fmt.Errorf("column %s already exists", match[2])
+		}
+		at := len(t.Columns)
+		if match[3] != "" {
+			if at = t.columnIndex(match[3]) + 1; at == 0 {
+				return fmt.Errorf("no column %s to add after", match[3])
+			}
+		}
+		t.Columns = append(t.Columns[:at], append([]string{match[1]}, t.Columns[at:]...)...)
+		return nil
+	}
+	if strings.HasPrefix(clause, "DROP COLUMN ") {
+		name := strings.Trim(strings.TrimPrefix(clause, "DROP COLUMN "), "`")
+		i := t.columnIndex(name)
+		if i < 0 {
+			return fmt.Errorf("no column %s to drop", name)
+		}
+		t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+		return nil
+	}
+	if match := testAddIndexRegex.FindStringSubmatch(clause); match != nil {
+		if _, ok := t.Indexes[match[2]]; ok {
+			return fmt.Errorf("index %s already exists", match[2])
+		}
+		for _, v := range splitTopLevel(match[3]) {
+			if t.columnIndex(columnName(v)) < 0 {
+				return fmt.Errorf("index %s uses unknown column %s", match[2], v)
+			}
+		}
+		t.Indexes[match[2]] = match[1] + strings.Join(splitTopLevel(match[3]), ", ")
+		return nil
+	}
+	if strings.HasPrefix(clause, "DROP INDEX ") {
+		name := strings.Trim(strings.TrimPrefix(clause, "DROP INDEX "), "`")
+		if _, ok := t.Indexes[name]; !ok {
+			return fmt.Errorf("no index %s to drop", name)
+		}
+		delete(t.Indexes, name)
+		return nil
+	}
+	return fmt.Errorf("unknown alter clause: %s", clause)
+}
+
+func (s *testSchema) execAll(t *testing.T, name string, statements []string) {
+	t.Helper()
+	for i, v := range statements {
+		if err := s.exec(v); err != nil {
+			t.Fatalf("%s statement %d: %v", name, i+1, err)
+		}
+	}
+}
+
+// INFORMATION_SCHEMA.COLUMNS처럼 테이블별 컬럼 이름을 반환합니다.
+func (s *testSchema) columnNames() map[string]map[string]bool {
+	result := make(map[string]map[string]bool)
+	for name, table := range s.tables {
+		result[name] = make(map[string]bool)
+		for _, v := range table.Columns {
+			result[name][columnName(v)] = true
+		}
+	}
+	return result
+}
+
+// 기존 gorn 마이그레이션으로 만든 스키마입니다.
+func legacySchema(t *testing.T) *testSchema {
+	t.Helper()
+	b, err := os.ReadFile("testdata/legacy_schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestSchema()
+	s.execAll(t, "legacy_schema.sql", splitStatements(string(b)))
+	return s
+}
+
+// 지금 dbmodel에 정의된 스키마입니다.
+func dbmodelSchema(t *testing.T) *testSchema {
+	t.Helper()
+	s := newTestSchema()
+	s.fkChecks = false
+	tables, tableNames := dbmodel.GetTables()
+	for i, table := range tables {
+		s.execAll(t, tableNames[i], []string{gorn.NewSql().CreateTable(tableNames[i], table).Query()})
+	}
+	for _, index := range dbmodel.GetIndexes() {
+		columnNames, columnSubParts, columnOrders := []string{}, []sql.NullInt64{}, []string{}
+		for _, v := range index.Columns {
+			columnNames = append(columnNames, v.ColumnName)
+			columnSubParts = append(columnSubParts, v.SubPart)
+			if v.ASC {
+				columnOrders = append(columnOrders, "ASC")
+			} else {
+				columnOrders = append(columnOrders, "DESC")
+			}
+		}
+		query := gorn.NewSql().Alter().Table(index.TableName).
+			AddIndex(index.IndexName, columnNames, columnSubParts, columnOrders, index.IndexType == gorn.DBIndexTypeUnique).
+			Query()
+		s.execAll(t, index.IndexName, []string{query})
+	}
+	return s
+}
+
+func loadMigrations(t *testing.T) []*Migration {
+	t.Helper()
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return migrations
+}
+
+func assertSameSchema(t *testing.T, name string, got, want *testSchema) {
+	t.Helper()
+	for table := range want.tables {
+		if got.tables[table] == nil {
+			t.Errorf("%s: missing table %s", name, table)
+		} else if !reflect.DeepEqual(got.tables[table], want.tables[table]) {
+			t.Errorf("%s: table %s\ngot  %+v\nwant %+v", name, table, got.tables[table], want.tables[table])
+		}
+	}
+	for table := range got.tables {
+		if want.tables[table] == nil {
+			t.Errorf("%s: unexpected table %s", name, table)
+		}
+	}
+}
+
+// 기준 마이그레이션은 기존 gorn 마이그레이션이 만들던 스키마와 같아야 합니다.
+func TestBaselineMatchesLegacySchema(t *testing.T) {
+	migrations := loadMigrations(t)
+	s := newTestSchema()
+	s.execAll(t, "0001_baseline.up.sql", migrations[baselineVersion-1].Up)
+	assertSameSchema(t, "0001_baseline", s, legacySchema(t))
+}
+
+// 기록 없는 기존 디비를 기준 마이그레이션으로 인정한 뒤 최신 버전까지 올리면 dbmodel과 같은 스키마가 되어야 합니다.
+// 다시 기준 버전까지 되돌리면 기존 디비와 같은 스키마가 되어야 합니다.
+func TestMigrateUpFromLegacySchema(t *testing.T) {
+	migrations := loadMigrations(t)
+	s := legacySchema(t)
+	if legacy, err := checkLegacy(migrations, s.columnNames()); err != nil || !legacy {
+		t.Fatalf("checkLegacy() = %v, %v, want the legacy schema to be adopted", legacy, err)
+	}
+	for _, v := range migrations[baselineVersion:] {
+		s.execAll(t, fmt.Sprintf("%04d_%s.up.sql", v.Version, v.Name), v.Up)
+	}
+	assertSameSchema(t, "latest", s, dbmodelSchema(t))
+
+	for i := len(migrations) - 1; i >= baselineVersion; i-- {
+		v := migrations[i]
+		s.execAll(t, fmt.Sprintf("%04d_%s.down.sql", v.Version, v.Name), v.Down)
+	}
+	assertSameSchema(t, "baseline", s, legacySchema(t))
+}
+
+// 빈 디비에서 모든 마이그레이션을 적용하고 되돌릴 수 있어야 합니다.
+func TestMigrateUpDown(t *testing.T) {
+	migrations := loadMigrations(t)
+	s := newTestSchema()
+	for _, v := range migrations {
+		s.execAll(t, fmt.Sprintf("%04d_%s.up.sql", v.Version, v.Name), v.Up)
+	}
+	assertSameSchema(t, "latest", s, dbmodelSchema(t))
+	for i := len(migrations) - 1; i >= 0; i-- {
+		v := migrations[i]
+		s.execAll(t, fmt.Sprintf("%04d_%s.down.sql", v.Version, v.Name), v.Down)
+	}
+	if len(s.tables) > 0 {
+		t.Errorf("tables left after rolling back everything: %v", s.columnNames())
+	}
+}
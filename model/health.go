@@ -0,0 +1,20 @@
+package model
+
+// 준비 상태 검사 하나의 결과입니다.
+// 검사에 실패했다면 Error에 이유가 담겨있습니다.
+type ReadinessCheck struct {
+	Name  string `json:"name"`
+	Ok    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// 배포된 서버의 빌드 정보입니다.
+// MigrationVersion은 서버가 기대하는 디비 스키마 버전(마지막 마이그레이션 번호)입니다.
+// SchemaVersions는 요청 데이터를 검증하는 JSON Schema의 종류별 최신 버전입니다.
+type VersionInfo struct {
+	Commit           string           `json:"commit"`
+	BuildTime        string           `json:"build_time"`
+	GoVersion        string           `json:"go_version"`
+	MigrationVersion int64            `json:"migration_version"`
+	SchemaVersions   map[string]int64 `json:"schema_versions"`
+}
@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/JongGeonClass/JGC-API/version"
+)
+
+// API 명세 파일입니다. (OpenAPI 3)
+// 라우터에 EndPoint를 추가하거나 요청, 응답 형식을 바꿨다면 이 파일도 함께 수정해야 합니다.
+// 빠진 EndPoint는 -openapi-check로 확인할 수 있습니다.
+//
+//go:embed openapi.json
+var spec []byte
+
+// 명세에서 사용하는 HTTP 메소드입니다.
+var methods = []string{"get", "post", "put", "delete"}
+
+// 명세를 파싱합니다.
+// 호출한 쪽에서 값을 바꿀 수 있도록 매번 새로 파싱합니다.
+func parse() (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("openapi.json: %v", err)
+	}
+	return doc, nil
+}
+
+// 서버에서 내려줄 명세를 만듭니다.
+// 버전은 빌드한 커밋 해시로, 쿠키 이름은 환경 설정에 맞게 채워서 반환합니다.
+func Build(sessionName, guestSessionName string) ([]byte, error) {
+	doc, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	info, ok := doc["info"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("openapi.json: no info")
+	}
+	info["version"] = version.Commit
+
+	components, _ := doc["components"].(map[string]interface{})
+	schemes, _ := components["securitySchemes"].(map[string]interface{})
+	for name, cookie := range map[string]string{
+		"cookieAuth":  sessionName,
+		"guestCookie": guestSessionName,
+	} {
+		scheme, ok := schemes[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("openapi.json: no security scheme %s", name)
+		}
+		scheme["name"] = cookie
+	}
+	return json.Marshal(doc)
+}
+
+// 명세에 적힌 모든 EndPoint를 "METHOD /path" 형식으로 반환합니다.
+// 마지막 경로 파라미터("/a/{token}")는 라우터에 등록하는 형식("/a/")으로 바꿉니다.
+func Operations() (map[string]bool, error) {
+	doc, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("openapi.json: no paths")
+	}
+	result := make(map[string]bool)
+	for p, v := range paths {
+		item, _ := v.(map[string]interface{})
+		if i := strings.LastIndex(p, "/{"); i >= 0 && strings.HasSuffix(p, "}") {
+			p = p[:i+1]
+		}
+		for _, m := range methods {
+			if _, ok := item[m]; ok {
+				result[strings.ToUpper(m)+" "+p] = true
+			}
+		}
+	}
+	return result, nil
+}
+
+// 명세의 servers에 적힌 버전별 경로를 긴 것부터 반환합니다.
+func ServerUrls() ([]string, error) {
+	doc, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	servers, _ := doc["servers"].([]interface{})
+	result := make([]string, 0, len(servers))
+	for _, v := range servers {
+		server, _ := v.(map[string]interface{})
+		if url, ok := server["url"].(string); ok {
+			result = append(result, url)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return len(result[i]) > len(result[j])
+	})
+	return result, nil
+}
+
+// 명세의 ErrorCode 스키마에 적힌 에러 코드를 반환합니다.
+func ErrorCodes() ([]string, error) {
+	doc, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	components, _ := doc["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	errorCode, ok := schemas["ErrorCode"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("openapi.json: no ErrorCode schema")
+	}
+	enum, _ := errorCode["enum"].([]interface{})
+	result := make([]string, 0, len(enum))
+	for _, v := range enum {
+		if code, ok := v.(string); ok {
+			result = append(result, code)
+		}
+	}
+	return result, nil
+}
+
+// 라우터에 등록된 EndPoint와 유스케이스의 에러 코드가 명세와 맞는지 확인합니다.
+// routes는 "METHOD /path" 형식이어야 하며, 맞지 않는 항목을 한 줄씩 반환합니다.
+// 명세의 경로는 버전별 경로(servers)를 뺀 경로이므로, 같은 EndPoint가 버전마다 등록되어 있어도 한 번만 적습니다.
+func Check(routes []string) ([]string, error) {
+	operations, err := Operations()
+	if err != nil {
+		return nil, err
+	}
+	servers, err := ServerUrls()
+	if err != nil {
+		return nil, err
+	}
+	problems := []string{}
+	registered := make(map[string]bool)
+	for _, route := range routes {
+		op, ok := trimServer(route, servers)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("route outside spec servers: %s", route))
+			continue
+		}
+		registered[op] = true
+		if !operations[op] {
+			problems = append(problems, fmt.Sprintf("route missing from spec: %s", route))
+		}
+	}
+	for op := range operations {
+		if !registered[op] {
+			problems = append(problems, fmt.Sprintf("spec has unregistered route: %s", op))
+		}
+	}
+
+	codes, err := ErrorCodes()
+	if err != nil {
+		return nil, err
+	}
+	documented := make(map[string]bool)
+	for _, code := range codes {
+		documented[code] = true
+	}
+	defined := make(map[string]bool)
+	for _, e := range usecase.Errors() {
+		defined[e.Code] = true
+		if !documented[e.Code] {
+			problems = append(problems, fmt.Sprintf("error code missing from spec: %s", e.Code))
+		}
+	}
+	for _, code := range codes {
+		if !defined[code] {
+			problems = append(problems, fmt.Sprintf("spec has unknown error code: %s", code))
+		}
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// "METHOD /path" 형식의 EndPoint에서 버전별 경로를 뺍니다.
+// servers는 긴 것부터 정렬되어 있어야 하며, 맞는 경로가 없다면 false를 반환합니다.
+func trimServer(route string, servers []string) (string, bool) {
+	i := strings.Index(route, " ")
+	if i < 0 {
+		return "", false
+	}
+	method, path := route[:i], route[i+1:]
+	for _, server := range servers {
+		if strings.HasPrefix(path, server+"/") {
+			return method + " " + strings.TrimPrefix(path, server), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,315 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/thak1411/gorn"
+	"github.com/thak1411/rnlog"
+)
+
+// 디비 스키마 불일치 하나의 정보입니다.
+// Expected는 dbmodel에 정의된 값이고, Actual은 디비에 만들어진 값입니다.
+// 한 쪽에만 있다면 다른 쪽은 빈 문자열입니다.
+type SchemaDrift struct {
+	Table    string
+	Object   string
+	Expected string
+	Actual   string
+}
+
+// 불일치 정보를 한 줄로 출력합니다.
+func (d *SchemaDrift) String() string {
+	expected, actual := d.Expected, d.Actual
+	if expected == "" {
+		expected = "(none)"
+	}
+	if actual == "" {
+		actual = "(none)"
+	}
+	return fmt.Sprintf("%s %s: %s -> %s", d.Table, d.Object, expected, actual)
+}
+
+// 불일치 정보를 diff 형식으로 출력합니다.
+// - 줄은 dbmodel에 정의된 값, + 줄은 디비에 만들어진 값입니다.
+func (d *SchemaDrift) Diff() string {
+	lines := []string{}
+	if d.Expected != "" {
+		lines = append(lines, fmt.Sprintf("- %s %s %s", d.Table, d.Object, d.Expected))
+	}
+	if d.Actual != "" {
+		lines = append(lines, fmt.Sprintf("+ %s %s %s", d.Table, d.Object, d.Actual))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 비교하기 쉽도록 문자열로 정리한 테이블 하나의 스키마입니다.
+// 컬럼은 이름, 인덱스는 이름(기본 키는 PRIMARY), 외래 키는 컬럼 이름으로 찾습니다.
+type tableSchema struct {
+	columns     map[string]string
+	indexes     map[string]string
+	foreignKeys map[string]string
+}
+
+func newTableSchema() *tableSchema {
+	return &tableSchema{
+		columns:     make(map[string]string),
+		indexes:     make(map[string]string),
+		foreignKeys: make(map[string]string),
+	}
+}
+
+// 정수 타입의 표시 너비입니다. MySQL 8부터는 INFORMATION_SCHEMA에 나오지 않으므로 비교하지 않습니다.
+var intDisplayWidthRegex = regexp.MustCompile(`^(tinyint|smallint|mediumint|int|bigint)\(\d+\)`)
+
+// 디비마다 다르게 보이는 타입 표기를 맞춥니다.
+// MariaDB는 JSON을 LONGTEXT로 저장하므로 같은 타입으로 봅니다.
+func normalizeType(columnType string) string {
+	t := strings.ToLower(strings.TrimSpace(columnType))
+	t = intDisplayWidthRegex.ReplaceAllString(t, "$1")
+	if t == "json" {
+		t = "longtext"
+	}
+	return t
+}
+
+// 컬럼 정의를 한 줄로 만듭니다.
+func columnDef(columnType string, notNull, autoIncrement bool) string {
+	def := normalizeType(columnType)
+	if notNull {
+		def += " NOT NULL"
+	} else {
+		def += " NULL"
+	}
+	if autoIncrement {
+		def += " AUTO_INCREMENT"
+	}
+	return def
+}
+
+// 인덱스 정의를 한 줄로 만듭니다. columns는 "컬럼(길이) ASC" 형식입니다.
+func indexDef(unique bool, columns []string) string {
+	def := "(" + strings.Join(columns, ", ") + ")"
+	if unique {
+		return "UNIQUE " + def
+	}
+	return def
+}
+
+// 인덱스 컬럼 하나를 "컬럼(길이) ASC" 형식으로 만듭니다.
+func indexColumnDef(columnName string, subPart int64, asc bool) string {
+	def := columnName
+	if subPart > 0 {
+		def += fmt.Sprintf("(%d)", subPart)
+	}
+	if asc {
+		return def + " ASC"
+	}
+	return def + " DESC"
+}
+
+// dbmodel에 등록된 테이블과 인덱스로 기대하는 스키마를 만듭니다.
+// 컬럼은 rnsql, rntype, rnopt, FK 태그를 gorn이 테이블을 만들 때와 같은 규칙으로 읽습니다.
+func expectedSchema() map[string]*tableSchema {
+	result := make(map[string]*tableSchema)
+	tables, tableNames := dbmodel.GetTables()
+	for i, table := range tables {
+		schema := newTableSchema()
+		result[tableNames[i]] = schema
+		primaryKey := []string{}
+		target := reflect.TypeOf(table)
+		if target.Kind() == reflect.Ptr {
+			target = target.Elem()
+		}
+		for j := 0; j < target.NumField(); j++ {
+			tag := target.Field(j).Tag
+			name, ok := tag.Lookup("rnsql")
+			if !ok {
+				continue
+			}
+			options := map[string]bool{}
+			for _, v := range strings.Fields(tag.Get("rnopt")) {
+				options[v] = true
+			}
+			columnType := tag.Get("rntype")
+			if options["UN"] {
+				columnType += " unsigned"
+			}
+			schema.columns[name] = columnDef(columnType, options["NN"] || options["PK"], options["AI"])
+			if options["PK"] {
+				primaryKey = append(primaryKey, indexColumnDef(name, 0, true))
+			}
+			if fk, ok := tag.Lookup("FK"); ok {
+				schema.foreignKeys[name] = fk
+			}
+		}
+		if len(primaryKey) > 0 {
+			schema.indexes["PRIMARY"] = indexDef(true, primaryKey)
+		}
+	}
+	for _, index := range dbmodel.GetIndexes() {
+		schema, ok := result[index.TableName]
+		if !ok {
+			continue
+		}
+		columns := []string{}
+		for _, v := range index.Columns {
+			columns = append(columns, indexColumnDef(v.ColumnName, v.SubPart.Int64, v.ASC))
+		}
+		schema.indexes[index.IndexName] = indexDef(index.IndexType == gorn.DBIndexTypeUnique, columns)
+	}
+	return result
+}
+
+// INFORMATION_SCHEMA를 읽어서 디비에 만들어진 스키마를 만듭니다.
+// MySQL은 외래 키 컬럼에 인덱스가 없다면 외래 키 이름으로 인덱스를 만들어주므로, 이 인덱스는 비교하지 않습니다.
+func actualSchema(ctx context.Context, schemadb database.SchemaDatabase) (map[string]*tableSchema, error) {
+	result := make(map[string]*tableSchema)
+	tableNames, err := schemadb.GetSchemaTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range tableNames {
+		result[v] = newTableSchema()
+	}
+
+	columns, err := schemadb.GetSchemaColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range columns {
+		if schema, ok := result[v.TableName]; ok {
+			autoIncrement := strings.Contains(strings.ToLower(v.Extra), "auto_increment")
+			schema.columns[v.ColumnName] = columnDef(v.ColumnType, v.IsNullable == "NO", autoIncrement)
+		}
+	}
+
+	foreignKeys, err := schemadb.GetSchemaForeignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	constraints := make(map[string]bool)
+	for _, v := range foreignKeys {
+		if schema, ok := result[v.TableName]; ok {
+			schema.foreignKeys[v.ColumnName] = v.ReferencedTableName + "." + v.ReferencedColumnName
+			constraints[v.TableName+"."+v.ConstraintName] = true
+		}
+	}
+
+	indexColumns, err := schemadb.GetSchemaIndexColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	type index struct {
+		table   string
+		name    string
+		unique  bool
+		columns []string
+	}
+	indexes := []*index{}
+	byName := make(map[string]*index)
+	for _, v := range indexColumns {
+		if _, ok := result[v.TableName]; !ok || constraints[v.TableName+"."+v.IndexName] {
+			continue
+		}
+		key := v.TableName + "." + v.IndexName
+		idx, ok := byName[key]
+		if !ok {
+			idx = &index{table: v.TableName, name: v.IndexName, unique: v.NonUnique == 0}
+			byName[key] = idx
+			indexes = append(indexes, idx)
+		}
+		asc := !v.Collation.Valid || v.Collation.String != "D"
+		idx.columns = append(idx.columns, indexColumnDef(v.ColumnName, v.SubPart.Int64, asc))
+	}
+	for _, v := range indexes {
+		result[v.table].indexes[v.name] = indexDef(v.unique, v.columns)
+	}
+	return result, nil
+}
+
+// 같은 종류의 정의를 이름별로 비교해서 불일치를 반환합니다.
+func compareDefs(table, kind string, expected, actual map[string]string) []*SchemaDrift {
+	names := []string{}
+	for name := range expected {
+		names = append(names, name)
+	}
+	for name := range actual {
+		if _, ok := expected[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	drifts := []*SchemaDrift{}
+	for _, name := range names {
+		if expected[name] != actual[name] {
+			drifts = append(drifts, &SchemaDrift{
+				Table:    table,
+				Object:   kind + " " + name,
+				Expected: expected[name],
+				Actual:   actual[name],
+			})
+		}
+	}
+	return drifts
+}
+
+// dbmodel에 등록된 테이블, 컬럼, 인덱스, 외래 키를 디비의 INFORMATION_SCHEMA와 비교합니다.
+// 어긋난 항목은 로그로 보고하고 반환합니다. 디비는 읽기만 하며 고치지 않습니다.
+// 마이그레이션 기록 테이블은 dbmodel에 등록하지 않으므로 비교하지 않습니다.
+func Schema(schemadb database.SchemaDatabase) ([]*SchemaDrift, error) {
+	ctx := context.Background()
+	expected := expectedSchema()
+	actual, err := actualSchema(ctx, schemadb)
+	if err != nil {
+		rnlog.Error("Get Schema Error: %+v", err)
+		return nil, err
+	}
+	delete(actual, dbmodel.SchemaMigrationsTable)
+	rnlog.Info("Check Schema... (tables: %d)", len(expected))
+
+	tableNames := []string{}
+	for name := range expected {
+		tableNames = append(tableNames, name)
+	}
+	for name := range actual {
+		if _, ok := expected[name]; !ok {
+			tableNames = append(tableNames, name)
+		}
+	}
+	sort.Strings(tableNames)
+
+	drifts := []*SchemaDrift{}
+	for _, name := range tableNames {
+		e, a := expected[name], actual[name]
+		if e == nil || a == nil {
+			drift := &SchemaDrift{Table: name, Object: "table"}
+			if e != nil {
+				drift.Expected = "exists"
+			} else {
+				drift.Actual = "exists"
+			}
+			drifts = append(drifts, drift)
+			continue
+		}
+		drifts = append(drifts, compareDefs(name, "column", e.columns, a.columns)...)
+		drifts = append(drifts, compareDefs(name, "index", e.indexes, a.indexes)...)
+		drifts = append(drifts, compareDefs(name, "foreign key", e.foreignKeys, a.foreignKeys)...)
+	}
+
+	for _, v := range drifts {
+		rnlog.Warn("Schema Drift: %s", v)
+	}
+	if len(drifts) == 0 {
+		rnlog.Info("Schema Is Consistent")
+	} else {
+		rnlog.Info("Found Schema Drift: %d", len(drifts))
+	}
+	return drifts, nil
+}
@@ -0,0 +1,124 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/thak1411/rnlog"
+)
+
+// 상품 통계 한 건의 불일치 정보입니다.
+// Stored는 PRODUCT_STATISTICS에 저장된 값이고, Actual은 원본 테이블에서 다시 계산한 값입니다.
+// Missing이 true라면 PRODUCT_STATISTICS에 행이 존재하지 않습니다.
+type StatisticsDrift struct {
+	ProductId int64
+	Missing   bool
+	Stored    *dbmodel.ProductStatistics
+	Actual    *dbmodel.ProductStatistics
+}
+
+// 불일치 정보를 한 줄로 출력합니다.
+func (d *StatisticsDrift) String() string {
+	if d.Missing {
+		return fmt.Sprintf("product %d: missing statistics row (review_count=%d, sum_review_score=%d, sold_quantity=%d, favorite_count=%d)",
+			d.ProductId, d.Actual.ReviewCount, d.Actual.SumReviewScore, d.Actual.SoldQuantity, d.Actual.FavoriteCount)
+	}
+	return fmt.Sprintf("product %d: review_count %d -> %d, sum_review_score %d -> %d, sold_quantity %d -> %d, favorite_count %d -> %d",
+		d.ProductId,
+		d.Stored.ReviewCount, d.Actual.ReviewCount,
+		d.Stored.SumReviewScore, d.Actual.SumReviewScore,
+		d.Stored.SoldQuantity, d.Actual.SoldQuantity,
+		d.Stored.FavoriteCount, d.Actual.FavoriteCount,
+	)
+}
+
+// 원본 테이블을 기준으로 상품 통계를 다시 계산합니다.
+// 리뷰 개수와 별점 합은 REVIEW 테이블에서, 찜 개수는 WISHLIST 테이블에서,
+// 판매량은 ORDER_ITEM 테이블에서 계산합니다.
+func calcStatistics(ctx context.Context, txdb database.ProductDatabase, productId int64) (*dbmodel.ProductStatistics, error) {
+	summary, err := txdb.GetReviewScoreSummary(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	favoriteCount, err := txdb.GetWishlistCount(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	soldQuantity, err := txdb.GetSoldQuantity(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	actual := &dbmodel.ProductStatistics{
+		ProductId:      productId,
+		ReviewCount:    summary.Count,
+		SumReviewScore: summary.SumScore,
+		SoldQuantity:   soldQuantity,
+		FavoriteCount:  favoriteCount,
+	}
+	return actual, nil
+}
+
+// 모든 상품의 PRODUCT_STATISTICS를 원본 테이블과 비교합니다.
+// 어긋난 상품은 로그로 보고하며, fix가 true라면 통계 행을 만들거나 원본 값으로 수정합니다.
+// 상품 하나마다 트랜잭션을 나눠서 실행하므로 중간에 실패하더라도 앞서 수정한 상품은 유지됩니다.
+func Statistics(productdb database.ProductDatabase, fix bool) ([]*StatisticsDrift, error) {
+	ctx := context.Background()
+	productIds, err := productdb.GetProductIds(ctx)
+	if err != nil {
+		rnlog.Error("Get Product Ids Error: %+v", err)
+		return nil, err
+	}
+	rnlog.Info("Reconcile Product Statistics... (products: %d, fix: %v)", len(productIds), fix)
+
+	drifts := []*StatisticsDrift{}
+	for _, productId := range productIds {
+		err := productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+			var stored *dbmodel.ProductStatistics
+			if exists, err := txdb.CheckProductStatisticsExists(ctx, productId); err != nil {
+				return err
+			} else if exists {
+				if stored, err = txdb.GetProductStatistics(ctx, productId); err != nil {
+					return err
+				}
+			}
+			actual, err := calcStatistics(ctx, txdb, productId)
+			if err != nil {
+				return err
+			}
+			if stored != nil && *stored == *actual {
+				return nil
+			}
+
+			drift := &StatisticsDrift{
+				ProductId: productId,
+				Missing:   stored == nil,
+				Stored:    stored,
+				Actual:    actual,
+			}
+			drifts = append(drifts, drift)
+			rnlog.Warn("Statistics Drift: %s", drift)
+			if !fix {
+				return nil
+			}
+			if drift.Missing {
+				return txdb.AddProductStatistics(ctx, actual)
+			}
+			return txdb.UpdateProductStatistics(ctx, actual)
+		})
+		if err != nil {
+			rnlog.Error("Reconcile Product Statistics Error(product %d): %+v", productId, err)
+			return drifts, err
+		}
+	}
+
+	if len(drifts) == 0 {
+		rnlog.Info("Product Statistics Are Consistent")
+	} else if fix {
+		rnlog.Info("Fixed Product Statistics: %d", len(drifts))
+	} else {
+		rnlog.Info("Found Product Statistics Drift: %d (run with -fix to apply)", len(drifts))
+	}
+	return drifts, nil
+}
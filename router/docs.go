@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/JongGeonClass/JGC-API/handler"
+	"github.com/thak1411/gorn"
+)
+
+// API 문서 EndPoint를 묶어서 제공합니다.
+// 문서는 누구나 볼 수 있어야 하므로 인증 미들웨어를 거치지 않습니다.
+func NewDocs(spec []byte) *gorn.Router {
+	router := gorn.NewRouter()
+
+	hd := handler.NewDocs(spec)
+
+	router.Get("/openapi.json", hd.OpenApi)
+	router.Get("/docs", hd.SwaggerUi)
+	return router
+}
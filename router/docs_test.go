@@ -0,0 +1,35 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/JongGeonClass/JGC-API/openapi"
+)
+
+// 라우터에 EndPoint나 에러 코드를 추가하고 명세를 고치지 않았다면 실패합니다.
+// 설정이나 디비 없이 라우터만 만들어서 확인합니다.
+func TestOpenApiCoversRoutes(t *testing.T) {
+	routes := NewApi(nil, nil, nil).Routes()
+	if len(routes) == 0 {
+		t.Fatal("no routes registered")
+	}
+	problems, err := openapi.Check(routes)
+	if err != nil {
+		t.Fatalf("openapi.Check() error = %v", err)
+	}
+	for _, v := range problems {
+		t.Error(v)
+	}
+}
+
+func TestOpenApiCheckFindsMissingRoute(t *testing.T) {
+	routes := append(NewApi(nil, nil, nil).Routes(), "GET /api/v2/product/not-documented")
+	problems, err := openapi.Check(routes)
+	if err != nil {
+		t.Fatalf("openapi.Check() error = %v", err)
+	}
+	want := "route missing from spec: GET /api/v2/product/not-documented"
+	if len(problems) != 1 || problems[0] != want {
+		t.Errorf("openapi.Check() = %q, want [%q]", problems, want)
+	}
+}
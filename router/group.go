@@ -0,0 +1,38 @@
+package router
+
+import "github.com/thak1411/gorn"
+
+// EndPoint를 등록할 때마다 같은 미들웨어를 앞에 붙여주는 라우터입니다.
+// v1의 Deprecation 헤더처럼 버전마다 붙여야 하는 미들웨어를 EndPoint마다 적지 않도록 사용합니다.
+type group struct {
+	*gorn.Router
+	before []func(c *gorn.Context)
+}
+
+// before 미들웨어를 handler 앞에 붙인 새 리스트를 반환합니다.
+func (g *group) chain(handler []func(c *gorn.Context)) []func(c *gorn.Context) {
+	result := make([]func(c *gorn.Context), 0, len(g.before)+len(handler))
+	result = append(result, g.before...)
+	return append(result, handler...)
+}
+
+func (g *group) Get(path string, handler ...func(c *gorn.Context)) {
+	g.Router.Get(path, g.chain(handler)...)
+}
+
+func (g *group) Post(path string, handler ...func(c *gorn.Context)) {
+	g.Router.Post(path, g.chain(handler)...)
+}
+
+func (g *group) Put(path string, handler ...func(c *gorn.Context)) {
+	g.Router.Put(path, g.chain(handler)...)
+}
+
+func (g *group) Delete(path string, handler ...func(c *gorn.Context)) {
+	g.Router.Delete(path, g.chain(handler)...)
+}
+
+// 모든 EndPoint 앞에 before 미들웨어를 붙이는 라우터를 반환합니다.
+func newGroup(before ...func(c *gorn.Context)) *group {
+	return &group{gorn.NewRouter(), before}
+}
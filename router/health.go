@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/handler"
+	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/thak1411/gorn"
+)
+
+// 서버 상태 확인과 메트릭 EndPoint를 묶어서 제공합니다.
+// 배포 파이프라인과 모니터링에서 호출하므로 /api 밖에 두며, 인증 미들웨어를 거치지 않습니다.
+func NewHealth(healthdb database.HealthDatabase) *gorn.Router {
+	router := gorn.NewRouter()
+
+	uc := usecase.NewHealth(healthdb)
+	hd := handler.NewHealth(uc)
+
+	router.Get("/healthz", hd.Healthz)
+	router.Get("/readyz", hd.Readyz)
+	router.Get("/version", hd.Version)
+	router.Get("/metrics", hd.Metrics)
+	return router
+}
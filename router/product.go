@@ -1,40 +1,80 @@
 package router
 
 import (
-	"github.com/JongGeonClass/JGC-API/database"
 	"github.com/JongGeonClass/JGC-API/handler"
 	"github.com/JongGeonClass/JGC-API/middleware"
-	"github.com/JongGeonClass/JGC-API/usecase"
 	"github.com/thak1411/gorn"
 )
 
 // Product 관련 EndPoint를 묶어서 제공합니다.
+// before는 모든 EndPoint 앞에 붙일 버전 미들웨어이며, 반환한 라우터에 덮어쓴 EndPoint에도 붙습니다.
 func NewProduct(
-	userdb database.UserDatabase,
-	productdb database.ProductDatabase,
-) *gorn.Router {
-	router := gorn.NewRouter()
-
-	md := middleware.NewAuth(userdb)
-	uc := usecase.NewProduct(userdb, productdb)
-	hd := handler.NewProduct(uc)
+	md *middleware.AuthMiddleware,
+	hd *handler.ProductHandler,
+	before ...func(c *gorn.Context),
+) *group {
+	router := newGroup(before...)
 
 	decode := md.TokenDecode
+	decodeWithGuest := md.TokenDecodeWithGuest
+	guest := md.GuestDecode
 
-	router.Get("/product", hd.GetProduct)
-	router.Get("/products", hd.GetProducts)
-	router.Get("/carts", decode, hd.GetCartProducts)
-	router.Post("/add-to-cart", decode, hd.AddToCart)
-	router.Post("/update-cart-amount", decode, hd.UpdateCartAmount)
-	router.Delete("/delete-cart-product", decode, hd.DeleteFromCart)
+	router.Get("/product", decodeWithGuest, hd.GetProduct)
+	router.Get("/products", decodeWithGuest, hd.GetProducts)
+	router.Get("/carts", decodeWithGuest, guest, hd.GetCartProducts)
+	router.Post("/add-to-cart", decodeWithGuest, guest, hd.AddToCart)
+	router.Post("/update-cart-amount", decodeWithGuest, guest, hd.UpdateCartAmount)
+	router.Delete("/delete-cart-product", decodeWithGuest, guest, hd.DeleteFromCart)
+	router.Delete("/delete-cart-products", decodeWithGuest, guest, hd.DeleteSelectedFromCart)
+	router.Post("/update-cart-amounts", decodeWithGuest, guest, hd.UpdateCartAmounts)
+	router.Delete("/clear-cart", decodeWithGuest, guest, hd.ClearCart)
 	router.Post("/add-review", decode, hd.AddReview)
 	router.Get("/reviews", hd.GetReviews)
+	router.Get("/product-stats", hd.GetProductStatistics)
 	router.Get("/categories", hd.GetCategories)
 	router.Post("/add-pbv", decode, hd.AddPbvOption)
 	router.Get("/pbv", decode, hd.GetPbvOption)
+	router.Get("/pbv-options", decode, hd.GetPbvOptions)
 	router.Post("update-pbv", decode, hd.UpdatePbvOption)
+	router.Post("/rename-pbv", decode, hd.RenamePbvOption)
+	router.Post("/duplicate-pbv", decode, hd.DuplicatePbvOption)
 	router.Delete("/delete-pbv", decode, hd.DeletePbvOption)
+	router.Get("/pbv-versions", decode, hd.GetPbvOptionVersions)
+	router.Get("/pbv-version", decode, hd.GetPbvOptionVersion)
+	router.Post("/restore-pbv", decode, hd.RestorePbvOption)
+	router.Get("/pbv-quote", decode, hd.GetPbvQuote)
+	router.Post("/add-pbv-to-cart", decode, hd.AddPbvOptionToCart)
+	router.Post("/share-pbv", decode, hd.SharePbvOption)
+	router.Delete("/unshare-pbv", decode, hd.UnsharePbvOption)
+	router.Get("/pbv/shared/", decodeWithGuest, hd.GetSharedPbvOption)
+	router.Post("/clone-shared-pbv", decode, hd.CloneSharedPbvOption)
 	router.Get("/brands", decode, hd.GetBrands)
+	router.Get("/wishlist", decode, hd.GetWishlist)
+	router.Post("/add-to-wishlist", decode, hd.AddToWishlist)
+	router.Delete("/delete-from-wishlist", decode, hd.DeleteFromWishlist)
+	router.Post("/move-wishlist-to-cart", decode, hd.MoveWishlistToCart)
+	router.Post("/add-coupon", decode, hd.AddCoupon)
+	router.Post("/add-product-discount", decode, hd.AddProductDiscount)
+	router.Post("/apply-coupon", decode, hd.ApplyCoupon)
+	router.Post("/checkout", decode, hd.Checkout)
+	router.Get("/orders", decode, hd.GetOrders)
+	router.Post("/add-product-variant", decode, hd.AddProductVariant)
+	router.Post("/add-product-sku", decode, hd.AddProductSku)
+	router.Post("/update-product-sku", decode, hd.UpdateProductSku)
+	router.Get("/vehicles", hd.GetVehicleModels)
+	router.Post("/add-vehicle", decode, hd.AddVehicleModel)
+	router.Post("/add-product-fitment", decode, hd.AddProductFitment)
+	router.Delete("/delete-product-fitment", decode, hd.DeleteProductFitment)
+	router.Get("/garage", decode, hd.GetGarage)
+	router.Post("/add-to-garage", decode, hd.AddToGarage)
+	router.Delete("/delete-from-garage", decode, hd.DeleteFromGarage)
+	router.Post("/upload-product-image", decode, hd.UploadProductImage)
+	router.Post("/reorder-product-images", decode, hd.ReorderProductImages)
+	router.Delete("/delete-product-image", decode, hd.DeleteProductImage)
+	router.Post("/update-product-description", decode, hd.UpdateProductDescription)
+	router.Get("/product-description-revisions", decode, hd.GetProductDescriptionRevisions)
+	router.Get("/product-description-revision", decode, hd.GetProductDescriptionRevision)
+	router.Post("/restore-product-description", decode, hd.RestoreProductDescription)
 
 	return router
 }
@@ -3,22 +3,33 @@ package router
 import (
 	"github.com/JongGeonClass/JGC-API/config"
 	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/media"
+	"github.com/JongGeonClass/JGC-API/middleware"
+	"github.com/JongGeonClass/JGC-API/openapi"
+	"github.com/JongGeonClass/JGC-API/usecase"
 	"github.com/thak1411/gorn"
+	"github.com/thak1411/rnlog"
 )
 
 // 전체 EndPoint를 묶어서 제공합니다.
 func New(
 	userdb database.UserDatabase,
 	productdb database.ProductDatabase,
+	healthdb database.HealthDatabase,
+	storage media.Storage,
 ) *gorn.Router {
 	conf := config.Get()
-	router := gorn.NewRouter()
+	router := NewApi(userdb, productdb, storage)
 
-	auth := NewAuth(userdb)
-	product := NewProduct(userdb, productdb)
+	spec, err := openapi.Build(conf.Cookies.SessionName, conf.Cookies.GuestSessionName)
+	if err != nil {
+		rnlog.Fatal("OpenAPI spec Error: %+v\n", err)
+	}
+	docs := NewDocs(spec)
+	health := NewHealth(healthdb)
 
-	router.Extends("/api/auth", auth)
-	router.Extends("/api/product", product)
+	router.Extends("/api", docs)
+	router.Extends("", health)
 
 	options := &gorn.RouterOptions{
 		AllowedOrigins:   conf.CorsOrigin,
@@ -30,3 +41,27 @@ func New(
 
 	return router
 }
+
+// API 명세(openapi/openapi.json)에 적어야 하는 EndPoint를 버전별로 묶어서 제공합니다.
+// 버전이 없는 /api는 기존 프론트엔드를 위해 남겨둔 v1과 같은 EndPoint입니다.
+// 유스케이스는 한 번만 만들어서 모든 버전이 함께 사용합니다.
+// 설정을 읽지 않으므로 디비 없이 만들어서 명세와 비교할 수 있습니다.
+func NewApi(
+	userdb database.UserDatabase,
+	productdb database.ProductDatabase,
+	storage media.Storage,
+) *gorn.Router {
+	router := gorn.NewRouter()
+
+	md := middleware.NewAuth(userdb)
+	authUc := usecase.NewAuth(userdb, productdb)
+	productUc := usecase.NewProduct(userdb, productdb, storage)
+
+	v1 := NewV1(md, authUc, productUc)
+	v2 := NewV2(md, authUc, productUc)
+
+	router.Extends("/api", v1)
+	router.Extends("/api/v1", v1)
+	router.Extends("/api/v2", v2)
+	return router
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"github.com/JongGeonClass/JGC-API/handler"
+	"github.com/JongGeonClass/JGC-API/middleware"
+	"github.com/JongGeonClass/JGC-API/usecase"
+	"github.com/thak1411/gorn"
+)
+
+// 지금 권장하는 API 버전의 경로입니다.
+const latestApiPath = "/api/v2"
+
+// v1 EndPoint를 묶어서 제공합니다.
+// 실패 응답을 포함해서 기존 프론트엔드가 사용하던 응답 형식 그대로이며, 모든 응답에 Deprecation 헤더를 붙입니다.
+func NewV1(
+	md *middleware.AuthMiddleware,
+	authUc usecase.AuthUsecase,
+	productUc usecase.ProductUsecase,
+) *gorn.Router {
+	router := gorn.NewRouter()
+
+	deprecated := middleware.Deprecated(latestApiPath)
+	auth := NewAuth(handler.NewAuth(authUc), deprecated)
+	product := NewProduct(md, handler.NewProduct(productUc), deprecated)
+
+	router.Extends("/auth", auth)
+	router.Extends("/product", product.Router)
+	return router
+}
+
+// v2 EndPoint를 묶어서 제공합니다.
+// v1과 같은 유스케이스를 사용하며, 응답 형식이 바뀐 EndPoint만 v2 핸들러로 덮어씁니다.
+// 실패 응답은 모든 EndPoint가 에러 코드를 담은 ErrorResponse 형식입니다.
+func NewV2(
+	md *middleware.AuthMiddleware,
+	authUc usecase.AuthUsecase,
+	productUc usecase.ProductUsecase,
+) *gorn.Router {
+	router := gorn.NewRouter()
+
+	productHd := handler.NewProductV2(productUc)
+	auth := NewAuth(handler.NewAuth(authUc), handler.TypedErrors)
+	product := NewProduct(md, productHd.ProductHandler, handler.TypedErrors)
+
+	// 응답 형식이 바뀐 EndPoint입니다.
+	product.Get("/reviews", productHd.GetReviews)
+
+	router.Extends("/auth", auth)
+	router.Extends("/product", product.Router)
+	return router
+}
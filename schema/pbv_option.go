@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/JongGeonClass/JGC-API/util"
+)
+
+// PBV 옵션 스키마의 최신 버전입니다.
+// 스키마를 바꿀 때는 pbv_option/v{버전}.json 파일을 추가하고,
+// 이전 버전에서 올라오는 업그레이드 함수를 pbvOptionUpgrades에 등록해야 합니다.
+const PbvOptionVersion = 2
+
+var pbvOption *versionedSchema
+
+// 버전별 업그레이드 함수입니다.
+// 키 버전의 데이터를 받아서 키+1 버전의 데이터로 변환합니다.
+var pbvOptionUpgrades = map[int64]func(data map[string]interface{}) map[string]interface{}{
+	0: upgradePbvOptionV0,
+	1: upgradePbvOptionV1,
+}
+
+// 스키마가 없던 시절(v0)의 데이터를 v1으로 변환합니다.
+// vehicle, color, memo는 그대로 옮기고, 나머지 값들은 options 아래로 옮깁니다.
+// 문자열, 숫자, bool이 아닌 값은 JSON 문자열로 바꿔서 보관합니다.
+// v0 데이터는 길이 제한 없이 저장되었으므로, 변환한 데이터를 그대로 다시 저장할 수 있도록 v1 스키마의 제한에 맞게 잘라냅니다.
+// 문자열은 최대 길이까지만 남기고, options는 키 순서로 최대 개수까지만 남깁니다.
+func upgradePbvOptionV0(data map[string]interface{}) map[string]interface{} {
+	v1 := pbvOption.versions[1]
+	optionSchema := v1.Properties["options"]
+	result := map[string]interface{}{"vehicle": ""}
+	options := map[string]interface{}{}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := data[key]
+		switch key {
+		case "vehicle", "color", "memo":
+			if str, ok := value.(string); ok {
+				result[key] = truncateRunes(str, *v1.Properties[key].MaxLength)
+				continue
+			}
+		}
+		if len(options) >= *optionSchema.MaxProperties {
+			continue
+		}
+		switch w := value.(type) {
+		case float64, bool:
+			options[key] = value
+		case string:
+			options[key] = truncateRunes(w, *optionSchema.AdditionalProperties.Schema.MaxLength)
+		default:
+			pbyte, _ := json.Marshal(value)
+			options[key] = truncateRunes(string(pbyte), *optionSchema.AdditionalProperties.Schema.MaxLength)
+		}
+	}
+	if len(options) > 0 {
+		result["options"] = options
+	}
+	return result
+}
+
+// 문자열을 최대 n글자까지만 남깁니다.
+// 스키마의 maxLength와 같이 바이트가 아닌 글자 수로 셉니다.
+func truncateRunes(str string, n int) string {
+	if utf8.RuneCountInString(str) <= n {
+		return str
+	}
+	return string([]rune(str)[:n])
+}
+
+// v1 데이터를 v2로 변환합니다.
+// v1에는 상품을 참조하는 값이 없으므로 빈 parts를 추가합니다.
+func upgradePbvOptionV1(data map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, value := range data {
+		result[key] = value
+	}
+	if _, ok := result["parts"]; !ok {
+		result["parts"] = []interface{}{}
+	}
+	return result
+}
+
+// PBV 옵션 데이터를 최신 스키마로 검증합니다.
+// 검증에 실패한 필드 목록을 반환하며, 통과했다면 빈 슬라이스를 반환합니다.
+func ValidatePbvOption(data map[string]interface{}) []*util.JsonSchemaError {
+	return pbvOption.validate(data)
+}
+
+// from 버전으로 저장된 PBV 옵션 데이터를 최신 스키마 버전으로 변환합니다.
+// 이미 최신 버전이라면 그대로 반환합니다.
+func UpgradePbvOption(data map[string]interface{}, from int64) (map[string]interface{}, error) {
+	if from > PbvOptionVersion {
+		return nil, fmt.Errorf("pbv_option: unknown schema version %d", from)
+	}
+	for v := from; v < PbvOptionVersion; v++ {
+		data = pbvOptionUpgrades[v](data)
+	}
+	return data, nil
+}
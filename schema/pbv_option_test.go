@@ -0,0 +1,185 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := Init(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestUpgradePbvOption(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		from int64
+		want map[string]interface{}
+	}{
+		{
+			"v0 empty",
+			map[string]interface{}{},
+			0,
+			map[string]interface{}{"vehicle": "", "parts": []interface{}{}},
+		},
+		{
+			"v0 moves options",
+			map[string]interface{}{
+				"vehicle": "PV5",
+				"color":   "white",
+				"memo":    1.0,
+				"wheel":   "18inch",
+				"seats":   7.0,
+				"roof":    true,
+				"extras":  []interface{}{"a", "b"},
+			},
+			0,
+			map[string]interface{}{
+				"vehicle": "PV5",
+				"color":   "white",
+				"parts":   []interface{}{},
+				"options": map[string]interface{}{
+					"memo":   1.0,
+					"wheel":  "18inch",
+					"seats":  7.0,
+					"roof":   true,
+					"extras": `["a","b"]`,
+				},
+			},
+		},
+		{
+			"v1 adds parts",
+			map[string]interface{}{"vehicle": "PV5", "memo": "hi"},
+			1,
+			map[string]interface{}{"vehicle": "PV5", "memo": "hi", "parts": []interface{}{}},
+		},
+		{
+			"latest is unchanged",
+			map[string]interface{}{"vehicle": "PV5", "parts": []interface{}{"x"}},
+			PbvOptionVersion,
+			map[string]interface{}{"vehicle": "PV5", "parts": []interface{}{"x"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UpgradePbvOption(tt.data, tt.from)
+			if err != nil {
+				t.Fatalf("UpgradePbvOption() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpgradePbvOption() = %v, want %v", got, tt.want)
+			}
+			if errs := ValidatePbvOption(got); tt.from < PbvOptionVersion && len(errs) > 0 {
+				t.Errorf("upgraded data must be valid: %v", errs)
+			}
+		})
+	}
+}
+
+func TestUpgradePbvOptionUnknownVersion(t *testing.T) {
+	if _, err := UpgradePbvOption(map[string]interface{}{}, PbvOptionVersion+1); err == nil {
+		t.Errorf("UpgradePbvOption() from v%d must fail", PbvOptionVersion+1)
+	}
+}
+
+func TestUpgradePbvOptionV0Truncates(t *testing.T) {
+	manyOptions := map[string]interface{}{"vehicle": "PV5"}
+	for i := 0; i < 150; i++ {
+		manyOptions[fmt.Sprintf("option%03d", i)] = true
+	}
+	tests := []struct {
+		name  string
+		data  map[string]interface{}
+		check func(t *testing.T, got map[string]interface{})
+	}{
+		{
+			"long top level strings",
+			map[string]interface{}{
+				"vehicle": strings.Repeat("가", 150),
+				"color":   strings.Repeat("c", 60),
+				"memo":    strings.Repeat("m", 1200),
+			},
+			func(t *testing.T, got map[string]interface{}) {
+				if got["vehicle"] != strings.Repeat("가", 100) || got["color"] != strings.Repeat("c", 50) || got["memo"] != strings.Repeat("m", 1000) {
+					t.Errorf("strings must be cut to the v1 limits: %v", got)
+				}
+			},
+		},
+		{
+			"long option values",
+			map[string]interface{}{
+				"vehicle": "PV5",
+				"note":    strings.Repeat("n", 300),
+				"extras":  []interface{}{strings.Repeat("e", 300)},
+			},
+			func(t *testing.T, got map[string]interface{}) {
+				options := got["options"].(map[string]interface{})
+				if options["note"] != strings.Repeat("n", 200) {
+					t.Errorf("note = %v, want 200 characters", options["note"])
+				}
+				if extras := options["extras"].(string); len(extras) != 200 || !strings.HasPrefix(extras, `["eee`) {
+					t.Errorf("extras = %v, want 200 characters of JSON", extras)
+				}
+			},
+		},
+		{
+			"too many options",
+			manyOptions,
+			func(t *testing.T, got map[string]interface{}) {
+				options := got["options"].(map[string]interface{})
+				if len(options) != 100 {
+					t.Errorf("options = %d, want 100", len(options))
+				}
+				if _, ok := options["option099"]; !ok {
+					t.Errorf("options must keep the first 100 keys in order")
+				}
+				if _, ok := options["option100"]; ok {
+					t.Errorf("options must drop keys after the first 100")
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UpgradePbvOption(tt.data, 0)
+			if err != nil {
+				t.Fatalf("UpgradePbvOption() error = %v", err)
+			}
+			if errs := ValidatePbvOption(got); len(errs) > 0 {
+				t.Errorf("upgraded data must be valid: %v", errs)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestValidatePbvOptionParts(t *testing.T) {
+	tests := []struct {
+		name    string
+		part    map[string]interface{}
+		wantErr bool
+	}{
+		{"valid", map[string]interface{}{"slot": "roof", "product_id": 1.0, "amount": 2.0}, false},
+		{"without amount", map[string]interface{}{"slot": "roof", "product_id": 1.0}, false},
+		{"zero product", map[string]interface{}{"slot": "roof", "product_id": 0.0}, true},
+		{"product out of range", map[string]interface{}{"slot": "roof", "product_id": 1e300}, true},
+		{"fraction product", map[string]interface{}{"slot": "roof", "product_id": 1.5}, true},
+		{"too many", map[string]interface{}{"slot": "roof", "product_id": 1.0, "amount": 101.0}, true},
+		{"empty slot", map[string]interface{}{"slot": "", "product_id": 1.0}, true},
+		{"unknown field", map[string]interface{}{"slot": "roof", "product_id": 1.0, "price": 1.0}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]interface{}{"vehicle": "PV5", "parts": []interface{}{tt.part}}
+			if errs := ValidatePbvOption(data); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("ValidatePbvOption(%v) = %v, wantErr %v", tt.part, errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package schema
+
+import (
+	"github.com/JongGeonClass/JGC-API/util"
+)
+
+// 블록 형식 상품 상세 설명 스키마의 최신 버전입니다.
+// 스키마를 바꿀 때는 product_description/v{버전}.json 파일을 추가해야 합니다.
+const ProductDescriptionVersion = 1
+
+var productDescription *versionedSchema
+
+// 블록 형식 상품 상세 설명을 최신 스키마로 검증합니다.
+// 검증에 실패한 필드 목록을 반환하며, 통과했다면 빈 슬라이스를 반환합니다.
+func ValidateProductDescription(data map[string]interface{}) []*util.JsonSchemaError {
+	return productDescription.validate(data)
+}
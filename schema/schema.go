@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/JongGeonClass/JGC-API/util"
+)
+
+// 버전별 JSON Schema 파일입니다.
+// {종류}/v{버전}.json 형식으로 저장합니다.
+//
+//go:embed pbv_option/*.json product_description/*.json
+var files embed.FS
+
+// 버전별로 파싱된 스키마를 담는 객체입니다.
+type versionedSchema struct {
+	name     string
+	latest   int64
+	versions map[int64]*util.JsonSchema
+}
+
+// 1번부터 latest번까지의 스키마 파일을 모두 불러옵니다.
+func loadVersionedSchema(name string, latest int64) (*versionedSchema, error) {
+	result := &versionedSchema{
+		name:     name,
+		latest:   latest,
+		versions: make(map[int64]*util.JsonSchema),
+	}
+	for v := int64(1); v <= latest; v++ {
+		b, err := files.ReadFile(fmt.Sprintf("%s/v%d.json", name, v))
+		if err != nil {
+			return nil, err
+		}
+		schema, err := util.ParseJsonSchema(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s v%d: %v", name, v, err)
+		}
+		result.versions[v] = schema
+	}
+	return result, nil
+}
+
+// 최신 버전의 스키마로 검증합니다.
+func (s *versionedSchema) validate(data map[string]interface{}) []*util.JsonSchemaError {
+	return s.versions[s.latest].Validate(data)
+}
+
+// 스키마 파일을 불러오고 업그레이드 함수가 모두 등록되어 있는지 확인합니다.
+// 메인에서 최초 한 번만 호출되어야 합니다.
+func Init() error {
+	var err error
+	if pbvOption, err = loadVersionedSchema("pbv_option", PbvOptionVersion); err != nil {
+		return err
+	}
+	for v := int64(0); v < PbvOptionVersion; v++ {
+		if _, ok := pbvOptionUpgrades[v]; !ok {
+			return fmt.Errorf("pbv_option: no upgrade from v%d", v)
+		}
+	}
+	if productDescription, err = loadVersionedSchema("product_description", ProductDescriptionVersion); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package server
+
+import "net/http"
+
+// 요청 바디를 maxBodySize까지만 읽을 수 있도록 handler를 감싸줍니다.
+// gorn의 BindJsonBody는 바디를 끝까지 읽어서 메모리에 올리므로, 핸들러에서 크기를 확인하기 전에 제한해야 합니다.
+// Content-Length가 이미 maxBodySize보다 크다면 바디를 읽지 않고 413을 응답합니다.
+// 길이를 알리지 않은 바디는 maxBodySize를 넘는 순간 읽기에 실패하므로, 핸들러는 400을 응답합니다.
+func limitBody(handler http.Handler, maxBodySize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.ContentLength > maxBodySize {
+			w.Header().Set("Connection", "close")
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		req.Body = http.MaxBytesReader(w, req.Body, maxBodySize)
+		handler.ServeHTTP(w, req)
+	})
+}
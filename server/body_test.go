@@ -0,0 +1,46 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		unknownLen bool
+		wantStatus int
+	}{
+		{"within limit", "12345678", false, http.StatusOK},
+		{"content length too large", "123456789", false, http.StatusRequestEntityTooLarge},
+		{"unknown length within limit", "12345678", true, http.StatusOK},
+		{"unknown length too large", "123456789", true, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := limitBody(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				called = true
+				if _, err := io.ReadAll(req.Body); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}), 8)
+			req := httptest.NewRequest(http.MethodPost, "/api/product/image", strings.NewReader(tt.body))
+			if tt.unknownLen {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if called == (tt.wantStatus == http.StatusRequestEntityTooLarge) {
+				t.Errorf("handler called = %v", called)
+			}
+		})
+	}
+}
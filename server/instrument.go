@@ -0,0 +1,148 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/logger"
+	"github.com/JongGeonClass/JGC-API/metrics"
+	"github.com/JongGeonClass/JGC-API/tracing"
+	"github.com/JongGeonClass/JGC-API/util"
+)
+
+// 요청 아이디를 주고받는 헤더입니다.
+const requestIdHeader = "X-Request-ID"
+
+// 클라이언트가 보낸 요청 아이디로 허용할 형식입니다.
+// 로그에 그대로 남기므로 길이와 글자를 제한하고, 맞지 않다면 새로 발급합니다.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// 접근 로그를 남기지 않을 라우트입니다.
+// 배포 도구와 메트릭 수집기가 주기적으로 호출하는 경로라서 로그만 늘어나기 때문입니다.
+var quietRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/version": true,
+	"/metrics": true,
+}
+
+// 메트릭 레이블로 그대로 사용할 HTTP 메소드입니다.
+// 그 외의 메소드는 OTHER로 묶어서 레이블 값이 끝없이 늘어나지 않도록 합니다.
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+	http.MethodHead:    true,
+}
+
+// 핸들러가 보낸 상태 코드와 응답 크기를 기록하는 ResponseWriter입니다.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// 요청 아이디를 정합니다.
+// 클라이언트나 로드밸런서가 보낸 아이디가 올바르다면 그대로 사용하고, 아니라면 새로 발급합니다.
+func requestId(req *http.Request) string {
+	if id := req.Header.Get(requestIdHeader); requestIdPattern.MatchString(id) {
+		return id
+	}
+	return util.NewUuid()
+}
+
+// 로그와 스팬에 남길 요청 경로를 정합니다.
+// 서브트리 라우트("/a/")의 나머지 경로는 공유 토큰 같은 비밀 값이므로 "/a/*"로 가려서 남깁니다.
+func loggedPath(route, path string) string {
+	if len(route) > 1 && strings.HasSuffix(route, "/") && len(path) > len(route) {
+		return route + "*"
+	}
+	return path
+}
+
+// 모든 요청을 추적하고 기록하도록 mux를 감싸줍니다.
+// 요청마다 아이디를 정해 응답 헤더와 요청 컨텍스트에 넣어주므로, 핸들러 아래에서 남긴 로그도 요청 아이디로 묶을 수 있습니다.
+// 배포 도구와 메트릭 수집기의 요청이 아니라면 traceparent 헤더를 이어받아 요청 전체를 감싸는 서버 스팬을 시작합니다.
+// 요청이 끝나면 요청 수, 처리 시간, 상태 코드를 라우트별 메트릭으로 기록하고 접근 로그를 한 줄 남깁니다.
+// 라우트는 mux에 등록된 경로이며, 등록되지 않은 경로는 unmatched로 묶습니다.
+func instrumentHandler(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info := &logger.RequestInfo{RequestId: requestId(req)}
+		w.Header().Set(requestIdHeader, info.RequestId)
+		ctx := logger.WithRequest(req.Context(), info)
+		req = req.WithContext(ctx)
+
+		_, route := mux.Handler(req)
+		if route == "" {
+			route = "unmatched"
+		}
+		path := loggedPath(route, req.URL.Path)
+		method := req.Method
+		if !knownMethods[method] {
+			method = "OTHER"
+		}
+		// 배포 도구와 메트릭 수집기의 요청은 추적하지 않습니다.
+		var span *tracing.Span
+		if !quietRoutes[route] {
+			ctx = tracing.Extract(ctx, req.Header)
+			ctx, span = tracing.Start(ctx, method+" "+route, tracing.KindServer,
+				tracing.String("http.method", req.Method),
+				tracing.String("http.route", route),
+				tracing.String("http.target", path),
+				tracing.String("http.request_id", info.RequestId),
+			)
+			if sc := span.SpanContext(); sc.IsValid() {
+				info.TraceId = sc.TraceId.String()
+			}
+			req = req.WithContext(ctx)
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		start := time.Now()
+		mux.ServeHTTP(recorder, req)
+		elapsed := time.Since(start)
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		metrics.ObserveHttpRequest(method, route, recorder.status, elapsed)
+		if quietRoutes[route] {
+			return
+		}
+		span.SetAttributes(tracing.Int("http.status_code", recorder.status))
+		if userId := info.UserId(); userId > 0 {
+			span.SetAttributes(tracing.Int64("enduser.id", userId))
+		}
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(tracing.StatusError, http.StatusText(recorder.status))
+		}
+		span.End()
+		logger.Access(ctx, &logger.AccessEntry{
+			Method:  method,
+			Route:   route,
+			Path:    path,
+			Status:  recorder.status,
+			Latency: elapsed,
+			Bytes:   recorder.bytes,
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thak1411/gorn"
+)
+
+func TestLoggedPath(t *testing.T) {
+	tests := []struct {
+		route string
+		path  string
+		want  string
+	}{
+		{"/api/v2/product/list", "/api/v2/product/list", "/api/v2/product/list"},
+		{"/api/v2/product/pbv/shared/", "/api/v2/product/pbv/shared/secret", "/api/v2/product/pbv/shared/*"},
+		{"/api/v2/product/pbv/shared/", "/api/v2/product/pbv/shared/", "/api/v2/product/pbv/shared/"},
+		{"unmatched", "/unknown", "/unknown"},
+	}
+	for _, tt := range tests {
+		if got := loggedPath(tt.route, tt.path); got != tt.want {
+			t.Errorf("loggedPath(%q, %q) = %q, want %q", tt.route, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSubtreeRoute(t *testing.T) {
+	product := gorn.NewRouter()
+	product.Get("/pbv/shared/", func(c *gorn.Context) {
+		c.SendPlainText(http.StatusOK, c.PathRest())
+	})
+	router := gorn.NewRouter()
+	router.Extends("/api/v2/product", product)
+	mux := router.Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/product/pbv/shared/abc123", nil)
+	if _, route := mux.Handler(req); route != "/api/v2/product/pbv/shared/" {
+		t.Errorf("route = %q, want the subtree pattern", route)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != "abc123" {
+		t.Errorf("response = %d %q, want 200 abc123", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/logger"
+	"github.com/thak1411/gorn"
+)
+
+// gorn 라우터를 실행하는 HTTP 서버입니다.
+// gorn의 Run은 종료 신호를 받으면 처리 중인 요청을 기다리지 않고 바로 반환하므로,
+// 배포 중에 결제 같은 트랜잭션이 중간에 끊기지 않도록 net/http 서버를 직접 실행합니다.
+// SIGTERM이나 SIGINT를 받으면 새로운 연결을 받지 않고, 처리 중인 요청을 shutdownTimeout까지 기다린 뒤
+// 백그라운드 작업을 멈추고 workerStopTimeout까지 기다린 뒤 반환합니다. 디비와 로거는 Run이 반환된 뒤 메인에서 닫아야 합니다.
+type Server struct {
+	httpServer      *http.Server
+	shutdownTimeout time.Duration
+
+	// 백그라운드 작업에 넘겨주는 컨텍스트입니다. 서버가 종료될 때 취소됩니다.
+	workerCtx   context.Context
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
+}
+
+// 백그라운드 작업이 멈추기를 기다릴 최대 시간입니다.
+// 요청을 기다리느라 shutdownTimeout을 다 썼더라도 남은 스팬을 내보내는 것처럼 정리할 시간을 따로 줍니다.
+const workerStopTimeout = 5 * time.Second
+
+// 백그라운드 작업을 실행합니다.
+// fn은 넘겨받은 컨텍스트가 취소되면 정리한 뒤 반환해야 하며, 서버는 반환될 때까지 종료를 기다립니다.
+func (s *Server) Go(name string, fn func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		fn(s.workerCtx)
+		logger.Info(context.Background(), "Background worker stopped: %s", name)
+	}()
+}
+
+// 백그라운드 작업을 멈추고, ctx가 끝날 때까지 모든 작업이 반환되기를 기다립니다.
+func (s *Server) waitWorkers(ctx context.Context) error {
+	s.stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background workers did not stop: %w", ctx.Err())
+	}
+}
+
+// 서버를 실행하고 종료 신호를 받을 때까지 기다립니다.
+// 종료 신호를 받아 정상적으로 종료했다면 nil을, 서버를 실행할 수 없거나 기한 안에 요청을 다 처리하지 못했다면 에러를 반환합니다.
+// 종료를 기다리는 중에 신호를 한 번 더 받으면 기다리지 않고 바로 연결을 끊습니다.
+func (s *Server) Run() error {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- s.httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-served:
+		s.stopWorkers()
+		return err
+	case sig := <-signals:
+		logger.Info(context.Background(), "Received %v, draining requests for up to %v...", sig, s.shutdownTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case sig := <-signals:
+			logger.Warn(context.Background(), "Received %v again, closing connections without draining", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Shutdown은 리스너를 먼저 닫고, 처리 중인 요청이 모두 끝날 때까지 기다립니다.
+	err := s.httpServer.Shutdown(ctx)
+	if err != nil {
+		logger.Error(context.Background(), "Failed to drain requests: %+v", err)
+		s.httpServer.Close()
+	}
+	<-served // Shutdown을 호출하면 ListenAndServe는 http.ErrServerClosed를 반환합니다.
+
+	wctx, wcancel := context.WithTimeout(context.Background(), workerStopTimeout)
+	defer wcancel()
+	if werr := s.waitWorkers(wctx); werr != nil {
+		logger.Error(context.Background(), "Failed to stop background workers: %+v", werr)
+		if err == nil {
+			err = werr
+		}
+	}
+	return err
+}
+
+// 라우터를 port에서 실행할 서버를 만들어줍니다.
+// shutdownTimeout은 종료 신호를 받은 뒤 처리 중인 요청을 기다릴 최대 시간입니다.
+// maxBodySize는 요청 바디의 최대 크기이며, 핸들러가 바디를 읽기 전에 제한합니다.
+func New(router *gorn.Router, port int, shutdownTimeout time.Duration, maxBodySize int64) *Server {
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           limitBody(instrumentHandler(router.Handler()), maxBodySize),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		shutdownTimeout: shutdownTimeout,
+		workerCtx:       workerCtx,
+		stopWorkers:     stopWorkers,
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/thak1411/gorn
+
+go 1.17
+
+require github.com/go-sql-driver/mysql v1.7.0
@@ -0,0 +1,178 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/version"
+)
+
+// OTLP/HTTP로 스팬을 내보내는 익스포터입니다.
+// 추가 의존성 없이 사용할 수 있도록 protobuf 대신 OTLP의 JSON 인코딩을 사용하며,
+// OpenTelemetry Collector나 Jaeger, Tempo 같은 OTLP 수집기의 /v1/traces로 보냅니다.
+type OtlpExporter struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	client      *http.Client
+}
+
+// OTLP 익스포터를 만듭니다.
+// endpoint는 수집기 주소(예: http://otel-collector:4318)이며, /v1/traces로 끝나지 않는다면 붙여서 사용합니다.
+// headers는 인증 토큰처럼 요청마다 보낼 헤더입니다.
+func NewOtlpExporter(endpoint string, headers map[string]string, serviceName string) *OtlpExporter {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+	return &OtlpExporter{
+		endpoint:    endpoint,
+		headers:     headers,
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: exportTimeout},
+	}
+}
+
+// OTLP JSON의 속성 값입니다.
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    StatusCode `json:"code"`
+	Message string     `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	TraceState        string          `json:"traceState,omitempty"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+// 속성을 OTLP JSON 형식으로 바꿔줍니다.
+// OTLP JSON은 64비트 정수를 문자열로 표현합니다.
+func toOtlpAttributes(attrs []Attribute) []otlpAttribute {
+	result := make([]otlpAttribute, 0, len(attrs))
+	for _, v := range attrs {
+		value := otlpValue{}
+		switch val := v.Value.(type) {
+		case string:
+			value.StringValue = &val
+		case int64:
+			s := strconv.FormatInt(val, 10)
+			value.IntValue = &s
+		case float64:
+			value.DoubleValue = &val
+		case bool:
+			value.BoolValue = &val
+		default:
+			s := fmt.Sprint(val)
+			value.StringValue = &s
+		}
+		result = append(result, otlpAttribute{Key: v.Key, Value: value})
+	}
+	return result
+}
+
+// 유닉스 나노초를 OTLP JSON 형식의 문자열로 바꿔줍니다.
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// 스팬들을 OTLP JSON 요청으로 만들어줍니다.
+func (e *OtlpExporter) makeRequest(spans []*Span) *otlpRequest {
+	scope := &otlpScopeSpans{Spans: make([]*otlpSpan, 0, len(spans))}
+	scope.Scope.Name = "github.com/JongGeonClass/JGC-API"
+	for _, v := range spans {
+		v.mu.Lock()
+		span := &otlpSpan{
+			TraceId:           v.sc.TraceId.String(),
+			SpanId:            v.sc.SpanId.String(),
+			TraceState:        v.sc.TraceState,
+			Name:              v.name,
+			Kind:              v.kind,
+			StartTimeUnixNano: unixNano(v.start),
+			EndTimeUnixNano:   unixNano(v.end),
+			Attributes:        toOtlpAttributes(v.attrs),
+			Status:            otlpStatus{Code: v.status, Message: v.statusMsg},
+		}
+		v.mu.Unlock()
+		if v.parentId.IsValid() {
+			span.ParentSpanId = v.parentId.String()
+		}
+		scope.Spans = append(scope.Spans, span)
+	}
+	resource := &otlpResourceSpans{ScopeSpans: []*otlpScopeSpans{scope}}
+	resource.Resource.Attributes = toOtlpAttributes([]Attribute{
+		String("service.name", e.serviceName),
+		String("service.version", version.Commit),
+	})
+	return &otlpRequest{ResourceSpans: []*otlpResourceSpans{resource}}
+}
+
+// 스팬들을 수집기로 보냅니다.
+func (e *OtlpExporter) Export(ctx context.Context, spans []*Span) error {
+	body, err := json.Marshal(e.makeRequest(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("otlp export failed with %s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+	io.Copy(io.Discard, res.Body)
+	return nil
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// W3C Trace Context 헤더입니다.
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+)
+
+// 다른 서비스에서 전달받은 traceparent 헤더를 읽어 ctx에 넣어줍니다.
+// 이후 Start로 만드는 스팬은 전달받은 스팬의 자식이 됩니다.
+// 헤더가 없거나 형식이 올바르지 않다면 ctx를 그대로 반환하며, 새로운 트레이스가 시작됩니다.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	sc, ok := parseTraceparent(header.Get(traceparentHeader))
+	if !ok {
+		return ctx
+	}
+	sc.TraceState = header.Get(tracestateHeader)
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// 현재 스팬을 traceparent 헤더로 만들어 다른 서비스로 보내는 요청에 넣어줍니다.
+// 전달할 트레이스가 없다면 아무것도 하지 않습니다.
+func Inject(ctx context.Context, header http.Header) {
+	sc := parentFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	header.Set(traceparentHeader, fmt.Sprintf("00-%s-%s-%s", sc.TraceId, sc.SpanId, flags))
+	if sc.TraceState != "" {
+		header.Set(tracestateHeader, sc.TraceState)
+	}
+}
+
+// traceparent 헤더를 읽습니다.
+// 형식은 version-traceid-parentid-flags이며, 알 수 없는 버전이라도 앞의 네 부분은 같은 형식으로 읽습니다.
+func parseTraceparent(value string) (SpanContext, bool) {
+	sc := SpanContext{}
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return sc, false
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.TraceId[:], []byte(parts[1])); err != nil {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.SpanId[:], []byte(parts[2])); err != nil {
+		return sc, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return sc, false
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, sc.IsValid()
+}
@@ -0,0 +1,171 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// 트레이스 아이디입니다. W3C Trace Context와 같은 16바이트를 사용합니다.
+type TraceId [16]byte
+
+// 스팬 아이디입니다. W3C Trace Context와 같은 8바이트를 사용합니다.
+type SpanId [8]byte
+
+func (t TraceId) IsValid() bool  { return t != TraceId{} }
+func (t TraceId) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanId) IsValid() bool   { return s != SpanId{} }
+func (s SpanId) String() string  { return hex.EncodeToString(s[:]) }
+
+// 새로운 트레이스 아이디를 만듭니다.
+func newTraceId() TraceId {
+	id := TraceId{}
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+// 새로운 스팬 아이디를 만듭니다.
+func newSpanId() SpanId {
+	id := SpanId{}
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+// 다른 서비스에 전달하거나 전달받는 스팬의 정보입니다.
+type SpanContext struct {
+	TraceId    TraceId
+	SpanId     SpanId
+	Sampled    bool
+	TraceState string
+}
+
+// 트레이스 아이디와 스팬 아이디가 모두 있는지 확인합니다.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceId.IsValid() && sc.SpanId.IsValid()
+}
+
+// 스팬의 종류입니다. 값은 OTLP의 SpanKind와 같습니다.
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// 스팬의 상태입니다. 값은 OTLP의 StatusCode와 같습니다.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOk    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// 스팬에 붙이는 속성입니다.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Attribute      { return Attribute{key, value} }
+func Int64(key string, value int64) Attribute { return Attribute{key, value} }
+func Int(key string, value int) Attribute     { return Attribute{key, int64(value)} }
+func Bool(key string, value bool) Attribute   { return Attribute{key, value} }
+
+// 작업 하나의 시작과 끝을 기록하는 스팬입니다.
+// 트레이싱을 사용하지 않거나 샘플링되지 않은 요청이라면 Start가 nil을 반환하므로,
+// 모든 메소드는 nil 스팬에서 호출해도 아무것도 하지 않도록 만들어져 있습니다.
+type Span struct {
+	provider *Provider
+	sc       SpanContext
+	parentId SpanId
+	name     string
+	kind     SpanKind
+	start    time.Time
+
+	mu        sync.Mutex
+	end       time.Time
+	attrs     []Attribute
+	status    StatusCode
+	statusMsg string
+	ended     bool
+}
+
+// 스팬의 정보를 가져옵니다.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// 스팬에 속성을 추가합니다.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// 스팬을 실패로 기록합니다. err가 nil이라면 무시합니다.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.SetStatus(StatusError, err.Error())
+}
+
+// 스팬의 상태를 정합니다.
+func (s *Span) SetStatus(code StatusCode, msg string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = code
+	s.statusMsg = msg
+}
+
+// 스팬을 끝내고 내보낼 목록에 넣습니다. 두 번 호출하면 처음 호출만 기록합니다.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+	s.provider.enqueue(s)
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// ctx에서 현재 스팬을 가져옵니다. 없다면 nil입니다.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ctx의 부모 스팬 정보를 가져옵니다.
+// 현재 스팬이 있다면 현재 스팬을, 없다면 다른 서비스에서 전달받은 스팬을 사용합니다.
+func parentFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.sc
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
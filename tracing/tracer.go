@@ -0,0 +1,172 @@
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/logger"
+)
+
+// OpenTelemetry 형식의 트레이스를 만들고 내보냅니다.
+// Init으로 익스포터를 설정하기 전까지는 Start가 아무것도 기록하지 않으므로,
+// 로컬에서 실행하거나 트레이스 수집기가 없는 환경에서는 비용 없이 그대로 사용할 수 있습니다.
+// 끝난 스팬은 큐에 모아두었다가 Run이 주기적으로 한 번에 내보냅니다.
+//
+// 최근 OpenTelemetry SDK(go.opentelemetry.io/otel)는 Go 1.17을 지원하지 않고 grpc, protobuf 같은 의존성이 함께 들어오므로,
+// 서버, 유스케이스, DB, 스토리지 스팬을 기록하는 데 필요한 만큼만 직접 구현했습니다.
+// 스팬 형식과 traceparent 전파는 OpenTelemetry 명세를 따르므로 어떤 OTLP 수집기로도 보낼 수 있습니다.
+// Go 버전을 올리면 Start, Extract, Inject를 SDK로 바꿔도 호출하는 쪽은 수정하지 않아도 됩니다.
+
+const (
+	// 내보내기 전에 모아둘 수 있는 최대 스팬 수입니다. 넘치는 스팬은 버립니다.
+	queueSize = 4096
+	// 한 번에 내보낼 최대 스팬 수입니다.
+	batchSize = 512
+	// 스팬을 내보내는 주기입니다.
+	exportInterval = 5 * time.Second
+	// 한 번 내보낼 때 기다리는 최대 시간입니다.
+	exportTimeout = 10 * time.Second
+)
+
+// 끝난 스팬을 받아 트레이스 수집기로 보내는 익스포터입니다.
+type Exporter interface {
+	Export(ctx context.Context, spans []*Span) error
+}
+
+// 스팬을 만들고 내보내는 트레이서입니다.
+type Provider struct {
+	exporter    Exporter
+	sampleRatio float64
+	queue       chan *Span
+	dropped     uint64
+}
+
+// 현재 사용하는 트레이서입니다. 설정하지 않았다면 nil이며, 이때는 아무것도 기록하지 않습니다.
+var current atomic.Value
+
+// 트레이서를 설정합니다. exporter가 nil이라면 트레이싱을 끕니다.
+// sampleRatio는 새로 시작하는 트레이스 중 기록할 비율이며, 다른 서비스에서 전달받은 트레이스는 그쪽의 샘플링 여부를 따릅니다.
+// 스팬을 실제로 내보내려면 반환된 트레이서의 Run을 백그라운드에서 실행해야 합니다.
+func Init(exporter Exporter, sampleRatio float64) *Provider {
+	if exporter == nil {
+		current.Store((*Provider)(nil))
+		return nil
+	}
+	p := &Provider{
+		exporter:    exporter,
+		sampleRatio: sampleRatio,
+		queue:       make(chan *Span, queueSize),
+	}
+	current.Store(p)
+	return p
+}
+
+// 트레이싱을 사용하고 있는지 확인합니다.
+func Enabled() bool {
+	p, _ := current.Load().(*Provider)
+	return p != nil
+}
+
+// 새로운 트레이스를 기록할지 정합니다.
+// 트레이스 아이디의 뒤쪽 8바이트로 정하므로, 같은 트레이스는 어느 서버에서나 같은 결과가 나옵니다.
+func (p *Provider) shouldSample(traceId TraceId) bool {
+	if p.sampleRatio >= 1 {
+		return true
+	}
+	if p.sampleRatio <= 0 {
+		return false
+	}
+	bound := uint64(p.sampleRatio * (1 << 63))
+	return binary.BigEndian.Uint64(traceId[8:])>>1 < bound
+}
+
+// 새로운 스팬을 시작하고, 스팬을 넣은 ctx를 반환합니다.
+// 스팬이 끝나면 반드시 End를 호출해야 합니다.
+// 트레이싱을 사용하지 않거나 기록하지 않는 트레이스라면 nil 스팬을 반환하며, nil 스팬의 메소드는 아무것도 하지 않습니다.
+func Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	p, _ := current.Load().(*Provider)
+	if p == nil {
+		return ctx, nil
+	}
+	parent := parentFromContext(ctx)
+	sc := SpanContext{SpanId: newSpanId()}
+	if parent.IsValid() {
+		sc.TraceId = parent.TraceId
+		sc.Sampled = parent.Sampled
+		sc.TraceState = parent.TraceState
+	} else {
+		sc.TraceId = newTraceId()
+		sc.Sampled = p.shouldSample(sc.TraceId)
+	}
+	if !sc.Sampled {
+		// 기록하지 않더라도 트레이스 아이디는 다음 서비스로 전달해야 하므로 ctx에는 남겨둡니다.
+		return context.WithValue(ctx, remoteKey{}, sc), nil
+	}
+	span := &Span{
+		provider: p,
+		sc:       sc,
+		parentId: parent.SpanId,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+		attrs:    attrs,
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// 끝난 스팬을 큐에 넣습니다. 큐가 가득 찼다면 요청이 느려지지 않도록 버립니다.
+func (p *Provider) enqueue(span *Span) {
+	select {
+	case p.queue <- span:
+	default:
+		atomic.AddUint64(&p.dropped, 1)
+	}
+}
+
+// 큐에 모인 스팬을 batchSize씩 내보냅니다. all이 false라면 한 번만 내보냅니다.
+func (p *Provider) flush(ctx context.Context, all bool) {
+	for {
+		batch := make([]*Span, 0, batchSize)
+	collect:
+		for len(batch) < batchSize {
+			select {
+			case span := <-p.queue:
+				batch = append(batch, span)
+			default:
+				break collect
+			}
+		}
+		if len(batch) == 0 {
+			return
+		}
+		exportCtx, cancel := context.WithTimeout(ctx, exportTimeout)
+		if err := p.exporter.Export(exportCtx, batch); err != nil {
+			logger.Warn(ctx, "Failed to export %d spans: %+v", len(batch), err)
+		}
+		cancel()
+		if dropped := atomic.SwapUint64(&p.dropped, 0); dropped > 0 {
+			logger.Warn(ctx, "Dropped %d spans because the export queue was full", dropped)
+		}
+		if !all || len(batch) < batchSize {
+			return
+		}
+	}
+}
+
+// 끝난 스팬을 주기적으로 내보냅니다. ctx가 취소되면 남은 스팬을 모두 내보낸 뒤 반환합니다.
+// 서버의 백그라운드 작업으로 실행해야 처리 중인 요청의 스팬까지 내보낸 뒤 종료할 수 있습니다.
+func (p *Provider) Run(ctx context.Context) {
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.flush(context.Background(), false)
+		case <-ctx.Done():
+			p.flush(context.Background(), true)
+			return
+		}
+	}
+}
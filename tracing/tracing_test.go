@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantOk      bool
+		wantSampled bool
+	}{
+		{"sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"surrounding spaces", " 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01 ", true, true},
+		{"future version with extra fields", "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what", true, true},
+		{"empty", "", false, false},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"version 00 with extra fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what", false, false},
+		{"short trace id", "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", false, false},
+		{"short span id", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01", false, false},
+		{"not hex", "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01", false, false},
+		{"bad flags", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz", false, false},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"zero span id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := parseTraceparent(tt.value)
+			if ok != tt.wantOk {
+				t.Fatalf("parseTraceparent(%q) ok = %v, want %v", tt.value, ok, tt.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if sc.TraceId.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanId.String() != "00f067aa0ba902b7" {
+				t.Errorf("parseTraceparent(%q) = %s-%s", tt.value, sc.TraceId, sc.SpanId)
+			}
+			if sc.Sampled != tt.wantSampled {
+				t.Errorf("parseTraceparent(%q) sampled = %v, want %v", tt.value, sc.Sampled, tt.wantSampled)
+			}
+		})
+	}
+}
+
+func TestExtractInject(t *testing.T) {
+	in := http.Header{}
+	in.Set(traceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	in.Set(tracestateHeader, "vendor=value")
+	out := http.Header{}
+	Inject(Extract(context.Background(), in), out)
+	if out.Get(traceparentHeader) != in.Get(traceparentHeader) || out.Get(tracestateHeader) != "vendor=value" {
+		t.Errorf("Inject(Extract()) = %v, want %v", out, in)
+	}
+
+	out = http.Header{}
+	Inject(context.Background(), out)
+	if len(out) != 0 {
+		t.Errorf("Inject() without trace = %v, want no headers", out)
+	}
+}
+
+// 내보낸 스팬 수를 묶음마다 기록하는 익스포터입니다.
+type recordExporter struct {
+	mu      sync.Mutex
+	batches []int
+}
+
+func (e *recordExporter) Export(ctx context.Context, spans []*Span) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.batches = append(e.batches, len(spans))
+	return nil
+}
+
+func TestFlushBatches(t *testing.T) {
+	defer Init(nil, 0)
+	tests := []struct {
+		name  string
+		spans int
+		all   bool
+		want  []int
+	}{
+		{"nothing to export", 0, true, nil},
+		{"one batch", 10, true, []int{10}},
+		{"all batches", 2*batchSize + 3, true, []int{batchSize, batchSize, 3}},
+		{"one batch per tick", 2*batchSize + 3, false, []int{batchSize}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := &recordExporter{}
+			p := Init(exporter, 1)
+			for i := 0; i < tt.spans; i++ {
+				_, span := Start(context.Background(), "span", KindInternal)
+				span.End()
+			}
+			p.flush(context.Background(), tt.all)
+			if len(exporter.batches) != len(tt.want) {
+				t.Fatalf("batches = %v, want %v", exporter.batches, tt.want)
+			}
+			for i := range tt.want {
+				if exporter.batches[i] != tt.want[i] {
+					t.Fatalf("batches = %v, want %v", exporter.batches, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEnqueueDropsWhenFull(t *testing.T) {
+	defer Init(nil, 0)
+	exporter := &recordExporter{}
+	p := Init(exporter, 1)
+	for i := 0; i < queueSize+10; i++ {
+		_, span := Start(context.Background(), "span", KindInternal)
+		span.End()
+	}
+	if p.dropped != 10 {
+		t.Errorf("dropped = %d, want 10", p.dropped)
+	}
+}
+
+func TestOtlpExporter(t *testing.T) {
+	var got otlpRequest
+	var gotPath, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotPath = req.URL.Path
+		gotAuth = req.Header.Get("Authorization")
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	defer Init(nil, 0)
+	Init(&recordExporter{}, 1)
+	ctx, parent := Start(context.Background(), "parent", KindServer, String("http.route", "/a"))
+	_, child := Start(ctx, "child", KindClient, Int("count", 3))
+	child.End()
+	parent.End()
+
+	exporter := NewOtlpExporter(server.URL+"/", map[string]string{"Authorization": "Bearer token"}, "jgc-api")
+	if err := exporter.Export(context.Background(), []*Span{child, parent}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if gotPath != "/v1/traces" || gotAuth != "Bearer token" {
+		t.Errorf("request = %s with %q, want /v1/traces with the configured header", gotPath, gotAuth)
+	}
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("request = %+v, want one resource and scope", got)
+	}
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+	if spans[0].ParentSpanId != spans[1].SpanId || spans[1].ParentSpanId != "" {
+		t.Errorf("child parent = %s, want %s", spans[0].ParentSpanId, spans[1].SpanId)
+	}
+	if v := spans[0].Attributes[0].Value.IntValue; v == nil || *v != "3" {
+		t.Errorf("int attribute must be encoded as a string: %+v", spans[0].Attributes)
+	}
+}
+
+func TestOtlpExporterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	exporter := NewOtlpExporter(server.URL+"/v1/traces", nil, "jgc-api")
+	if err := exporter.Export(context.Background(), nil); err == nil {
+		t.Errorf("Export() must fail when the collector rejects the request")
+	}
+}
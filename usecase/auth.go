@@ -2,10 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	"github.com/JongGeonClass/JGC-API/config"
 	"github.com/JongGeonClass/JGC-API/database"
 	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/metrics"
+	"github.com/JongGeonClass/JGC-API/tracing"
 	"github.com/JongGeonClass/JGC-API/util"
 )
 
@@ -13,18 +16,22 @@ import (
 type AuthUsecase interface {
 	SignUp(ctx context.Context, email, nickname, username, password string) (int64, error)
 	Login(ctx context.Context, username, password string) (string, error)
+	MergeGuestCart(ctx context.Context, userId int64, guestId string) (*dbmodel.CartMergeResult, error)
 }
 
 // Auth Usecase의 구현체입니다.
 type AuthUC struct {
-	userdb database.UserDatabase
+	userdb    database.UserDatabase
+	productdb database.ProductDatabase
 }
 
 // 회원가입합니다.
 // 리턴 타입의 int64는 생성된 유저의 id입니다.
-// 만약 이미 존재하는 닉네임을 가진 유저라면 -1을 반환합니다.
-// 만약 이미 존재하는 아이디(유저네임)을 가진 유저라면 -2를 반환합니다.
+// 만약 이미 존재하는 닉네임을 가진 유저라면 ErrNicknameTaken을 반환합니다.
+// 만약 이미 존재하는 아이디(유저네임)을 가진 유저라면 ErrUsernameTaken을 반환합니다.
 func (uc *AuthUC) SignUp(ctx context.Context, email, nickname, username, password string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthUC.SignUp", tracing.KindInternal)
+	defer span.End()
 	user := &dbmodel.User{
 		Email:    email,
 		Nickname: nickname,
@@ -43,16 +50,14 @@ func (uc *AuthUC) SignUp(ctx context.Context, email, nickname, username, passwor
 		if exist, err := txdb.CheckUserExistsByNickname(ctx, nickname); err != nil {
 			return err
 		} else if exist {
-			user.Id = -1
-			return nil
+			return ErrNicknameTaken
 		}
 
 		// 같은 username을 가진 아이디가 존재하는지 검사합니다.
 		if exist, err := txdb.CheckUserExistsByUsername(ctx, username); err != nil {
 			return err
 		} else if exist {
-			user.Id = -2
-			return nil
+			return ErrUsernameTaken
 		}
 
 		// user를 추가합니다.
@@ -63,17 +68,23 @@ func (uc *AuthUC) SignUp(ctx context.Context, email, nickname, username, passwor
 		user.Id = uid
 		return nil
 	})
-	return user.Id, err
+	if err != nil {
+		return 0, err
+	}
+	metrics.Signups.Inc()
+	return user.Id, nil
 }
 
 // 로그인합니다.
 // 로그인에 성공한다면, 토큰을 발급합니다.
-// 로그인에 실패했을 경우 빈 문자열을 반환합니다.
+// 아이디가 없거나 비밀번호가 틀렸다면 ErrInvalidCredentials을 반환합니다.
 // TODO: 현재는 항상 토큰을 재발급합니다.
 // 하지만 추후에는 유효한 토큰이 있다면, 해당 토큰을 반환하는 형태로 변경하거나, 유연하게 처리하도록 변경해야 합니다.
 // 하지만 로그인을 요청한다는 것 자체가 토큰이 없다는 가정이 될 수도 있으므로 반영하지 않을 수도 있습니다.
 // 다중 기기에서의 로그인 처리에 따라 어떻게 할지 추후에 고민해보도록 합시다.
 func (uc *AuthUC) Login(ctx context.Context, username, password string) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthUC.Login", tracing.KindInternal)
+	defer span.End()
 	type Result struct {
 		Token string `json:"token"`
 	}
@@ -88,7 +99,7 @@ func (uc *AuthUC) Login(ctx context.Context, username, password string) (string,
 			return err
 		}
 		if !exist { // 아이디가 없음
-			return nil
+			return ErrInvalidCredentials
 		}
 
 		// 유저의 패스워드 정보를 위해 유저 정보를 불러옵니다.
@@ -97,7 +108,7 @@ func (uc *AuthUC) Login(ctx context.Context, username, password string) (string,
 			return err
 		}
 		if user.Password != util.Encrypt256(password, user.Salt) { // 비밀번호 불일치
-			return nil
+			return ErrInvalidCredentials
 		}
 
 		// 유저를 인증하는 토큰을 생성합니다.
@@ -108,10 +119,35 @@ func (uc *AuthUC) Login(ctx context.Context, username, password string) (string,
 		res.Token = tok
 		return nil
 	})
+	if err == nil {
+		metrics.Logins.Inc("success")
+	} else if errors.Is(err, ErrInvalidCredentials) {
+		metrics.Logins.Inc("failure")
+	}
 	return res.Token, err
 }
 
+// 로그인하기 전에 담아둔 게스트 장바구니를 유저 장바구니로 합칩니다.
+// 같은 상품은 개수를 더하되 재고를 넘지 않도록 줄이며, 합친 뒤 게스트 장바구니는 비웁니다.
+func (uc *AuthUC) MergeGuestCart(ctx context.Context, userId int64, guestId string) (*dbmodel.CartMergeResult, error) {
+	ctx, span := tracing.Start(ctx, "AuthUC.MergeGuestCart", tracing.KindInternal)
+	defer span.End()
+	var res *dbmodel.CartMergeResult
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		result, err := mergeGuestCart(ctx, txdb, userId, guestId)
+		if err != nil {
+			return err
+		}
+		res = result
+		return nil
+	})
+	return res, err
+}
+
 // Auth Usecase를 반환합니다.
-func NewAuth(userdb database.UserDatabase) AuthUsecase {
-	return &AuthUC{userdb}
+func NewAuth(
+	userdb database.UserDatabase,
+	productdb database.ProductDatabase,
+) AuthUsecase {
+	return &AuthUC{userdb, productdb}
 }
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// 장바구니에서 선택한 줄들을 한 번에 삭제합니다.
+// 줄은 상품 아이디와 SKU 아이디로 고르므로, 같은 상품이라도 고르지 않은 SKU는 남습니다.
+// 장바구니에 없는 줄은 무시합니다.
+func (uc *ProductUC) DeleteSelectedFromCart(ctx context.Context, userId int64, items []*dbmodel.CartItem) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteSelectedFromCart", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		return txdb.DeleteCartProducts(ctx, userId, items)
+	})
+	return err
+}
+
+// 장바구니에 담긴 여러 상품의 개수를 한 번에 변경합니다.
+// 모두 바꾸거나, 하나도 바꾸지 않습니다.
+// 장바구니에 없는 상품은 무시합니다.
+func (uc *ProductUC) UpdateCartAmounts(ctx context.Context, userId int64, amounts []*dbmodel.CartAmount) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.UpdateCartAmounts", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		for _, v := range amounts {
+			if err := updateCartAmount(ctx, txdb, userId, v.ProductId, v.SkuId, v.Amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// 장바구니를 모두 비웁니다.
+func (uc *ProductUC) ClearCart(ctx context.Context, userId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.ClearCart", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.DeleteCart(ctx, userId)
+}
+
+// 장바구니 상품 리스트로 상품별 금액, 브랜드별 배송 그룹, 전체 금액을 계산합니다.
+// 담은 가격이 기록되지 않은 상품(PriceAtAdd가 0)은 가격이 바뀐 것으로 보지 않습니다.
+func summarizeCart(carts []*dbmodel.PublicCart) *dbmodel.CartSummary {
+	summary := &dbmodel.CartSummary{
+		Carts:  carts,
+		Groups: []*dbmodel.CartShippingGroup{},
+	}
+	groups := map[int64]*dbmodel.CartShippingGroup{}
+	for _, v := range carts {
+		v.Subtotal = v.ProductPrice * v.Amount
+		v.PriceChanged = v.PriceAtAdd != 0 && v.PriceAtAdd != v.ProductPrice
+		v.StockShortage = v.Amount > v.Stock
+		if v.PriceChanged || v.StockShortage {
+			summary.HasChanges = true
+		}
+		group, ok := groups[v.BrandId]
+		if !ok {
+			group = &dbmodel.CartShippingGroup{
+				BrandId:   v.BrandId,
+				BrandName: v.BrandName,
+				Carts:     []*dbmodel.PublicCart{},
+			}
+			groups[v.BrandId] = group
+			summary.Groups = append(summary.Groups, group)
+		}
+		group.Carts = append(group.Carts, v)
+		group.Subtotal += v.Subtotal
+	}
+	for _, v := range summary.Groups {
+		v.ShippingFee = shippingFee(v.Subtotal)
+		summary.Subtotal += v.Subtotal
+		summary.ShippingFee += v.ShippingFee
+	}
+	summary.Total = summary.Subtotal + summary.ShippingFee
+	return summary
+}
+
+// 브랜드 하나의 합계로 배송비를 계산합니다.
+// 합계가 무료 배송 기준 이상이면 배송비가 붙지 않습니다.
+func shippingFee(subtotal int64) int64 {
+	conf := config.Get()
+	if subtotal < conf.Cart.FreeShippingThreshold {
+		return conf.Cart.ShippingFee
+	}
+	return 0
+}
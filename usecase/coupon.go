@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// 새로운 쿠폰을 만듭니다.
+// 관리자는 모든 쿠폰을 만들 수 있고, 브랜드 주인은 자기 브랜드에만 적용되는 쿠폰을 만들 수 있습니다.
+// 권한이 없다면 ErrPermissionDenied, 이미 존재하는 쿠폰 코드라면 ErrCouponCodeTaken,
+// 적용 대상 브랜드나 카테고리가 없다면 ErrCouponTargetNotFound을 반환합니다.
+// 성공하면 만들어진 쿠폰 아이디를 반환합니다.
+func (uc *ProductUC) AddCoupon(ctx context.Context, userId int64, coupon *dbmodel.Coupon) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddCoupon", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 관리자가 아니라면 자기 브랜드 쿠폰만 만들 수 있습니다.
+		if !config.Get().IsAdmin(userId) {
+			if coupon.BrandId == 0 {
+				return ErrPermissionDenied
+			}
+			if owner, err := txdb.CheckBrandOwner(ctx, userId, coupon.BrandId); err != nil {
+				return err
+			} else if !owner {
+				return ErrPermissionDenied
+			}
+		}
+		if exists, err := txdb.CheckCouponCodeExists(ctx, coupon.Code); err != nil {
+			return err
+		} else if exists {
+			return ErrCouponCodeTaken
+		}
+		if coupon.BrandId != 0 {
+			if exists, err := txdb.CheckBrandExists(ctx, coupon.BrandId); err != nil {
+				return err
+			} else if !exists {
+				return ErrCouponTargetNotFound
+			}
+		}
+		if coupon.CategoryId != 0 {
+			if exists, err := txdb.CheckCategoryExists(ctx, coupon.CategoryId); err != nil {
+				return err
+			} else if !exists {
+				return ErrCouponTargetNotFound
+			}
+		}
+		coupon.UsedCount = 0
+		coupon.CreatedBy = userId
+		couponId, err := txdb.AddCoupon(ctx, coupon)
+		if err != nil {
+			return err
+		}
+		res = couponId
+		return nil
+	})
+	return res, err
+}
+
+// 상품에 기간 할인을 등록합니다.
+// 관리자나 상품 브랜드의 주인만 등록할 수 있습니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied을 반환합니다.
+// 성공하면 등록된 상품 할인 아이디를 반환합니다.
+func (uc *ProductUC) AddProductDiscount(ctx context.Context, userId int64, discount *dbmodel.ProductDiscount) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddProductDiscount", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductExists(ctx, discount.ProductId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, discount.ProductId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		discount.CreatedBy = userId
+		discountId, err := txdb.AddProductDiscount(ctx, discount)
+		if err != nil {
+			return err
+		}
+		res = discountId
+		return nil
+	})
+	return res, err
+}
+
+// 현재 장바구니에 쿠폰을 적용했을 때의 가격을 계산합니다.
+// 쿠폰을 실제로 사용하지는 않으며, 쿠폰 관련 에러는 checkCoupon, priceCart와 같습니다.
+// 장바구니가 비어있다면 ErrCartEmpty를 반환합니다.
+func (uc *ProductUC) ApplyCoupon(ctx context.Context, userId int64, code string) (*dbmodel.PricedCart, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.ApplyCoupon", tracing.KindInternal)
+	defer span.End()
+	var result *dbmodel.PricedCart
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		carts, err := txdb.GetCartProducts(ctx, userId)
+		if err != nil {
+			return err
+		}
+		if len(carts) == 0 {
+			return ErrCartEmpty
+		}
+		ntime := time.Now()
+		coupon, err := checkCoupon(ctx, txdb, userId, code, ntime, false)
+		if err != nil {
+			return err
+		}
+		result, err = priceCart(ctx, txdb, carts, coupon, ntime)
+		return err
+	})
+	return result, err
+}
+
+// 쿠폰 코드를 현재 유저가 사용할 수 있는지 확인합니다.
+// 존재하지 않는 쿠폰이라면 ErrCouponNotFound, 사용 기간이 아니라면 ErrCouponNotActive,
+// 전체 사용 횟수를 다 썼다면 ErrCouponExhausted, 유저의 사용 횟수를 다 썼다면 ErrCouponUserLimitReached을 반환합니다.
+// 쿠폰을 실제로 사용할 때는 lock을 true로 넘겨야 합니다. 쿠폰을 잠근 뒤 사용 횟수를 세므로,
+// 같은 유저가 동시에 결제해도 유저의 사용 횟수 제한을 넘지 않습니다.
+func checkCoupon(ctx context.Context, txdb database.ProductDatabase, userId int64, code string, at time.Time, lock bool) (*dbmodel.Coupon, error) {
+	if exists, err := txdb.CheckCouponCodeExists(ctx, code); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrCouponNotFound
+	}
+	getCoupon := txdb.GetCouponByCode
+	getRedemptionCount := txdb.GetCouponRedemptionCount
+	if lock {
+		getCoupon = txdb.GetCouponByCodeForUpdate
+		getRedemptionCount = txdb.GetCouponRedemptionCountForUpdate
+	}
+	coupon, err := getCoupon(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if at.Before(coupon.StartTime) || !at.Before(coupon.EndTime) {
+		return nil, ErrCouponNotActive
+	}
+	if coupon.UsageLimit > 0 && coupon.UsedCount >= coupon.UsageLimit {
+		return nil, ErrCouponExhausted
+	}
+	if coupon.PerUserLimit > 0 {
+		count, err := getRedemptionCount(ctx, coupon.Id, userId)
+		if err != nil {
+			return nil, err
+		}
+		if count >= coupon.PerUserLimit {
+			return nil, ErrCouponUserLimitReached
+		}
+	}
+	return coupon, nil
+}
+
+// 할인 방식에 따라 price에서 깎아줄 금액을 계산합니다.
+// 깎아주는 금액은 price를 넘지 않습니다.
+func discountAmount(discountType string, discountValue, price int64) int64 {
+	amount := int64(0)
+	switch discountType {
+	case dbmodel.DiscountTypeFixed:
+		amount = discountValue
+	case dbmodel.DiscountTypePercent:
+		amount = price * discountValue / 100
+	}
+	if amount > price {
+		amount = price
+	}
+	if amount < 0 {
+		amount = 0
+	}
+	return amount
+}
+
+// 쿠폰이 장바구니 상품에 적용될 수 있는지 확인합니다.
+func isCouponEligible(coupon *dbmodel.Coupon, cart *dbmodel.PublicCart) bool {
+	if coupon.BrandId != 0 && coupon.BrandId != cart.BrandId {
+		return false
+	}
+	if coupon.CategoryId == 0 {
+		return true
+	}
+	for _, v := range cart.Categories {
+		if v.Id == coupon.CategoryId {
+			return true
+		}
+	}
+	return false
+}
+
+// 장바구니 상품 리스트에 상품 할인과 쿠폰을 적용해 가격을 계산합니다.
+// 상품 할인은 장바구니 줄마다 가장 많이 깎아주는 것 하나만 적용하고, 쿠폰은 상품 할인이 적용된 금액에 적용합니다.
+// 배송비는 쿠폰을 적용하기 전의 브랜드별 합계로 계산합니다.
+// coupon이 nil이라면 쿠폰 없이 계산하며, 쿠폰을 적용할 상품이 없거나 최소 주문 금액에 못 미친다면 ErrCouponNotApplicable를 반환합니다.
+func priceCart(ctx context.Context, txdb database.ProductDatabase, carts []*dbmodel.PublicCart, coupon *dbmodel.Coupon, at time.Time) (*dbmodel.PricedCart, error) {
+	productIds := make([]int64, 0, len(carts))
+	for _, v := range carts {
+		productIds = append(productIds, v.ProductId)
+	}
+	discounts, err := txdb.GetActiveProductDiscounts(ctx, productIds, at)
+	if err != nil {
+		return nil, err
+	}
+	productDiscounts := map[int64][]*dbmodel.ProductDiscount{}
+	for _, v := range discounts {
+		productDiscounts[v.ProductId] = append(productDiscounts[v.ProductId], v)
+	}
+
+	result := &dbmodel.PricedCart{
+		Lines: []*dbmodel.PricedCartLine{},
+	}
+	brandSubtotals := map[int64]int64{}
+	brandIds := []int64{}
+	eligibleSubtotal := int64(0)
+	for _, v := range carts {
+		// SKU마다 가격이 다를 수 있으므로 장바구니 줄마다 가장 많이 깎아주는 할인 금액을 구합니다.
+		bestDiscount := int64(0)
+		for _, d := range productDiscounts[v.ProductId] {
+			if amount := discountAmount(d.DiscountType, d.DiscountValue, v.ProductPrice); amount > bestDiscount {
+				bestDiscount = amount
+			}
+		}
+		line := &dbmodel.PricedCartLine{
+			ProductId:     v.ProductId,
+			SkuId:         v.SkuId,
+			ProductName:   v.ProductName,
+			BrandId:       v.BrandId,
+			OriginalPrice: v.ProductPrice,
+			UnitPrice:     v.ProductPrice - bestDiscount,
+			Amount:        v.Amount,
+		}
+		line.Subtotal = line.UnitPrice * line.Amount
+		if coupon != nil && isCouponEligible(coupon, v) {
+			line.CouponEligible = true
+			eligibleSubtotal += line.Subtotal
+		}
+		if _, ok := brandSubtotals[v.BrandId]; !ok {
+			brandIds = append(brandIds, v.BrandId)
+		}
+		brandSubtotals[v.BrandId] += line.Subtotal
+		result.Lines = append(result.Lines, line)
+		result.Subtotal += line.Subtotal
+		result.ProductDiscount += bestDiscount * line.Amount
+	}
+	for _, v := range brandIds {
+		result.ShippingFee += shippingFee(brandSubtotals[v])
+	}
+
+	if coupon != nil {
+		if eligibleSubtotal == 0 || eligibleSubtotal < coupon.MinSpend {
+			return nil, ErrCouponNotApplicable
+		}
+		result.CouponDiscount = discountAmount(coupon.DiscountType, coupon.DiscountValue, eligibleSubtotal)
+		if coupon.DiscountType == dbmodel.DiscountTypePercent && coupon.MaxDiscount > 0 && result.CouponDiscount > coupon.MaxDiscount {
+			result.CouponDiscount = coupon.MaxDiscount
+		}
+		result.Coupon = &dbmodel.PublicCoupon{
+			Code:          coupon.Code,
+			Name:          coupon.Name,
+			DiscountType:  coupon.DiscountType,
+			DiscountValue: coupon.DiscountValue,
+			MaxDiscount:   coupon.MaxDiscount,
+			MinSpend:      coupon.MinSpend,
+			BrandId:       coupon.BrandId,
+			CategoryId:    coupon.CategoryId,
+			StartTime:     coupon.StartTime,
+			EndTime:       coupon.EndTime,
+		}
+	}
+	result.Total = result.Subtotal - result.CouponDiscount + result.ShippingFee
+	return result, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+)
+
+// 상품 할인만 돌려주는 가짜 디비입니다.
+// 그 외의 메소드를 호출하면 nil 인터페이스를 호출하므로 패닉이 납니다.
+type discountDB struct {
+	database.ProductDatabase
+	discounts []*dbmodel.ProductDiscount
+}
+
+func (d *discountDB) GetActiveProductDiscounts(ctx context.Context, productIds []int64, at time.Time) ([]*dbmodel.ProductDiscount, error) {
+	return d.discounts, nil
+}
+
+func TestDiscountAmount(t *testing.T) {
+	tests := []struct {
+		name          string
+		discountType  string
+		discountValue int64
+		price         int64
+		want          int64
+	}{
+		{"fixed", dbmodel.DiscountTypeFixed, 1000, 5000, 1000},
+		{"fixed over price", dbmodel.DiscountTypeFixed, 7000, 5000, 5000},
+		{"fixed negative", dbmodel.DiscountTypeFixed, -1000, 5000, 0},
+		{"percent", dbmodel.DiscountTypePercent, 10, 5000, 500},
+		{"percent rounds down", dbmodel.DiscountTypePercent, 15, 999, 149},
+		{"percent over 100", dbmodel.DiscountTypePercent, 150, 5000, 5000},
+		{"zero price", dbmodel.DiscountTypePercent, 10, 0, 0},
+		{"unknown type", "bogo", 10, 5000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := discountAmount(tt.discountType, tt.discountValue, tt.price); got != tt.want {
+				t.Errorf("discountAmount(%q, %d, %d) = %d, want %d", tt.discountType, tt.discountValue, tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCouponEligible(t *testing.T) {
+	cart := &dbmodel.PublicCart{
+		BrandId:    2,
+		Categories: dbmodel.CategoryList{{Id: 3}, {Id: 4}},
+	}
+	tests := []struct {
+		name   string
+		coupon *dbmodel.Coupon
+		want   bool
+	}{
+		{"all products", &dbmodel.Coupon{}, true},
+		{"same brand", &dbmodel.Coupon{BrandId: 2}, true},
+		{"other brand", &dbmodel.Coupon{BrandId: 1}, false},
+		{"same category", &dbmodel.Coupon{CategoryId: 4}, true},
+		{"other category", &dbmodel.Coupon{CategoryId: 5}, false},
+		{"same brand and category", &dbmodel.Coupon{BrandId: 2, CategoryId: 3}, true},
+		{"same brand other category", &dbmodel.Coupon{BrandId: 2, CategoryId: 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCouponEligible(tt.coupon, cart); got != tt.want {
+				t.Errorf("isCouponEligible(%+v) = %v, want %v", tt.coupon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceCart(t *testing.T) {
+	// 배송비는 브랜드별 합계가 50000원 미만일 때 3000원이므로, 1번 브랜드는 배송비가 없습니다.
+	carts := []*dbmodel.PublicCart{
+		{ProductId: 1, BrandId: 1, ProductPrice: 20000, Amount: 2, Categories: dbmodel.CategoryList{{Id: 1}}},
+		{ProductId: 1, SkuId: 7, BrandId: 1, ProductPrice: 30000, Amount: 1, Categories: dbmodel.CategoryList{{Id: 1}}},
+		{ProductId: 2, BrandId: 2, ProductPrice: 10000, Amount: 1, Categories: dbmodel.CategoryList{{Id: 2}}},
+	}
+	discounts := []*dbmodel.ProductDiscount{
+		{ProductId: 1, DiscountType: dbmodel.DiscountTypeFixed, DiscountValue: 2500},
+		{ProductId: 1, DiscountType: dbmodel.DiscountTypePercent, DiscountValue: 10},
+	}
+	tests := []struct {
+		name      string
+		discounts []*dbmodel.ProductDiscount
+		coupon    *dbmodel.Coupon
+		want      *dbmodel.PricedCart
+		wantErr   error
+	}{
+		{
+			name: "no discount",
+			want: &dbmodel.PricedCart{Subtotal: 80000, ShippingFee: 3000, Total: 83000},
+		},
+		{
+			// 20000원 줄은 정액 2500원이, 30000원 줄은 10%인 3000원이 더 많이 깎아줍니다.
+			name:      "best product discount per line",
+			discounts: discounts,
+			want:      &dbmodel.PricedCart{Subtotal: 72000, ProductDiscount: 8000, ShippingFee: 3000, Total: 75000},
+		},
+		{
+			name:      "percent coupon capped",
+			discounts: discounts,
+			coupon:    &dbmodel.Coupon{DiscountType: dbmodel.DiscountTypePercent, DiscountValue: 50, MaxDiscount: 5000},
+			want:      &dbmodel.PricedCart{Subtotal: 72000, ProductDiscount: 8000, CouponDiscount: 5000, ShippingFee: 3000, Total: 70000},
+		},
+		{
+			name:   "coupon on brand only",
+			coupon: &dbmodel.Coupon{DiscountType: dbmodel.DiscountTypePercent, DiscountValue: 10, BrandId: 2},
+			want:   &dbmodel.PricedCart{Subtotal: 80000, CouponDiscount: 1000, ShippingFee: 3000, Total: 82000},
+		},
+		{
+			name:   "fixed coupon over eligible subtotal",
+			coupon: &dbmodel.Coupon{DiscountType: dbmodel.DiscountTypeFixed, DiscountValue: 20000, CategoryId: 2},
+			want:   &dbmodel.PricedCart{Subtotal: 80000, CouponDiscount: 10000, ShippingFee: 3000, Total: 73000},
+		},
+		{
+			name:    "min spend not reached",
+			coupon:  &dbmodel.Coupon{DiscountType: dbmodel.DiscountTypeFixed, DiscountValue: 1000, BrandId: 2, MinSpend: 20000},
+			wantErr: ErrCouponNotApplicable,
+		},
+		{
+			name:    "no eligible line",
+			coupon:  &dbmodel.Coupon{DiscountType: dbmodel.DiscountTypeFixed, DiscountValue: 1000, CategoryId: 9},
+			wantErr: ErrCouponNotApplicable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := priceCart(context.Background(), &discountDB{discounts: tt.discounts}, carts, tt.coupon, time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("priceCart() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Subtotal != tt.want.Subtotal || got.ProductDiscount != tt.want.ProductDiscount ||
+				got.CouponDiscount != tt.want.CouponDiscount || got.ShippingFee != tt.want.ShippingFee || got.Total != tt.want.Total {
+				t.Errorf("priceCart() = subtotal %d, product discount %d, coupon discount %d, shipping %d, total %d, want %+v",
+					got.Subtotal, got.ProductDiscount, got.CouponDiscount, got.ShippingFee, got.Total, tt.want)
+			}
+			if len(got.Lines) != len(carts) {
+				t.Fatalf("priceCart() lines = %d, want %d", len(got.Lines), len(carts))
+			}
+			for i, v := range got.Lines {
+				if v.Subtotal != v.UnitPrice*v.Amount {
+					t.Errorf("line %d subtotal = %d, want %d", i, v.Subtotal, v.UnitPrice*v.Amount)
+				}
+				if v.CouponEligible != (tt.coupon != nil && isCouponEligible(tt.coupon, carts[i])) {
+					t.Errorf("line %d coupon eligible = %v", i, v.CouponEligible)
+				}
+			}
+		})
+	}
+}
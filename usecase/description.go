@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/schema"
+	"github.com/JongGeonClass/JGC-API/tracing"
+	"github.com/JongGeonClass/JGC-API/util"
+)
+
+// 블록 형식 상세 설명의 블록 하나입니다.
+// 어떤 필드를 사용하는지는 Type에 따라 다릅니다.
+type descriptionBlock struct {
+	Type    string   `json:"type"`
+	Text    string   `json:"text"`
+	Level   int64    `json:"level"`
+	Ordered bool     `json:"ordered"`
+	Items   []string `json:"items"`
+	Url     string   `json:"url"`
+	Alt     string   `json:"alt"`
+}
+
+// 블록 형식 상세 설명 전체입니다.
+type descriptionBlocks struct {
+	Blocks []*descriptionBlock `json:"blocks"`
+}
+
+// 요청으로 받은 상품 상세 설명을 검증합니다.
+// 크기 제한을 넘었다면 ErrDescriptionTooLarge를, NUL 문자가 들어있거나 블록 형식인데 스키마에 맞지 않는다면 검증에 실패한 필드 목록을 담은 ErrInvalidDescription을 반환합니다.
+func checkProductDescriptionContent(format, content string) error {
+	conf := config.Get()
+	if len(content) > conf.Product.MaxDescriptionSize {
+		return ErrDescriptionTooLarge
+	}
+	if strings.ContainsRune(content, 0) {
+		return ErrInvalidDescription.WithDetails([]*util.JsonSchemaError{
+			{Path: "", Message: "must not contain NUL characters"},
+		})
+	}
+	if format != dbmodel.DescriptionFormatBlocks {
+		return nil
+	}
+	dest := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(content), &dest); err != nil || dest == nil {
+		return ErrInvalidDescription.WithDetails([]*util.JsonSchemaError{
+			{Path: "", Message: "must be a JSON object"},
+		})
+	}
+	if errs := schema.ValidateProductDescription(dest); len(errs) > 0 {
+		return ErrInvalidDescription.WithDetails(errs)
+	}
+	return nil
+}
+
+// 블록 형식 상세 설명을 안전한 HTML과 검색용 글자로 바꿔줍니다.
+// 블록의 글자는 모두 이스케이프하며, 이미지 주소는 http, https만 허용합니다.
+func renderDescriptionBlocks(content string) (string, string, error) {
+	dest := &descriptionBlocks{}
+	if err := json.Unmarshal([]byte(content), dest); err != nil {
+		return "", "", err
+	}
+	sb := &strings.Builder{}
+	texts := []string{}
+	for _, v := range dest.Blocks {
+		switch v.Type {
+		case "heading":
+			level := v.Level
+			if level < 1 || level > 6 {
+				level = 2
+			}
+			fmt.Fprintf(sb, "<h%d>%s</h%d>\n", level, html.EscapeString(v.Text), level)
+			texts = append(texts, v.Text)
+		case "paragraph":
+			sb.WriteString("<p>" + html.EscapeString(v.Text) + "</p>\n")
+			texts = append(texts, v.Text)
+		case "quote":
+			sb.WriteString("<blockquote><p>" + html.EscapeString(v.Text) + "</p></blockquote>\n")
+			texts = append(texts, v.Text)
+		case "list":
+			tag := "ul"
+			if v.Ordered {
+				tag = "ol"
+			}
+			sb.WriteString("<" + tag + ">")
+			for _, item := range v.Items {
+				sb.WriteString("<li>" + html.EscapeString(item) + "</li>")
+			}
+			sb.WriteString("</" + tag + ">\n")
+			texts = append(texts, v.Items...)
+		case "image":
+			lower := strings.ToLower(v.Url)
+			if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+				continue
+			}
+			sb.WriteString(`<figure><img src="` + html.EscapeString(v.Url) + `" alt="` + html.EscapeString(v.Alt) + `"></figure>` + "\n")
+			texts = append(texts, v.Alt)
+		}
+	}
+	return sb.String(), strings.Join(texts, "\n"), nil
+}
+
+// 상품 상세 설명을 안전한 HTML과 검색용 글자로 바꿔줍니다.
+func renderProductDescription(format, content string) (string, string, error) {
+	if format == dbmodel.DescriptionFormatBlocks {
+		return renderDescriptionBlocks(content)
+	}
+	return util.RenderMarkdown(content), util.MarkdownPlainText(content), nil
+}
+
+// 상품의 현재 상세 설명을 유저에게 보여줄 형태로 가져옵니다.
+// 상세 설명이 작성되지 않은 상품이라면 nil을 반환합니다.
+func getPublicProductDescription(ctx context.Context, productdb database.ProductDatabase, productId int64) (*dbmodel.PublicProductDescription, error) {
+	if exists, err := productdb.CheckProductDescriptionExists(ctx, productId); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, nil
+	}
+	description, err := productdb.GetProductDescription(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	rendered, _, err := renderProductDescription(description.Format, description.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &dbmodel.PublicProductDescription{
+		Format:      description.Format,
+		Content:     description.Content,
+		Html:        rendered,
+		Revision:    description.Revision,
+		UpdatedTime: description.UpdatedTime.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// 트랜잭션 안에서 상품 상세 설명을 새로운 수정 기록으로 저장합니다.
+// 현재 상세 설명과 검색용 글자를 함께 바꾸며, 저장한 수정 번호를 반환합니다.
+func saveProductDescription(ctx context.Context, txdb database.ProductDatabase, userId, productId int64, format, content string) (int64, error) {
+	_, searchText, err := renderProductDescription(format, content)
+	if err != nil {
+		return 0, err
+	}
+	exists, err := txdb.CheckProductDescriptionExists(ctx, productId)
+	if err != nil {
+		return 0, err
+	}
+	description := &dbmodel.ProductDescription{ProductId: productId}
+	if exists {
+		if description, err = txdb.GetProductDescription(ctx, productId); err != nil {
+			return 0, err
+		}
+	}
+	description.Format = format
+	description.Content = content
+	description.SearchText = searchText
+	description.Revision++
+	description.UpdatedBy = userId
+	if exists {
+		err = txdb.UpdateProductDescription(ctx, description)
+	} else {
+		err = txdb.AddProductDescription(ctx, description)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := txdb.AddProductDescriptionRevision(ctx, &dbmodel.ProductDescriptionRevision{
+		ProductId: productId,
+		Revision:  description.Revision,
+		Format:    format,
+		Content:   content,
+		CreatedBy: userId,
+	}); err != nil {
+		return 0, err
+	}
+	return description.Revision, nil
+}
+
+// 트랜잭션 안에서 상품이 존재하고 유저가 상품을 관리할 수 있는지 확인합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied을 반환합니다.
+func checkProductDescriptionManager(ctx context.Context, txdb database.ProductDatabase, userId, productId int64) error {
+	if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+		return err
+	} else if !exists {
+		return ErrProductNotFound
+	}
+	if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+		return err
+	} else if !ok {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// 상품 상세 설명을 작성하거나 수정합니다.
+// 관리자나 상품 브랜드의 주인만 수정할 수 있으며, 바뀐 내용은 새로운 수정 기록으로 남습니다.
+// 내용이 올바르지 않다면 ErrInvalidDescription이나 ErrDescriptionTooLarge를 반환합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied을 반환하며, 성공했다면 새로운 수정 번호를 반환합니다.
+func (uc *ProductUC) UpdateProductDescription(ctx context.Context, userId, productId int64, format, content string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.UpdateProductDescription", tracing.KindInternal)
+	defer span.End()
+	if err := checkProductDescriptionContent(format, content); err != nil {
+		return 0, err
+	}
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if err := checkProductDescriptionManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		}
+		var err error
+		res, err = saveProductDescription(ctx, txdb, userId, productId, format, content)
+		return err
+	})
+	return res, err
+}
+
+// 상품 상세 설명의 수정 기록을 최신 기록부터 가져옵니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied을 반환합니다.
+func (uc *ProductUC) GetProductDescriptionRevisions(ctx context.Context, userId, productId int64) ([]*dbmodel.PublicProductDescriptionRevision, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetProductDescriptionRevisions", tracing.KindInternal)
+	defer span.End()
+	if err := checkProductDescriptionManager(ctx, uc.productdb, userId, productId); err != nil {
+		return nil, err
+	}
+	return uc.productdb.GetProductDescriptionRevisions(ctx, productId)
+}
+
+// 상품 상세 설명의 특정 수정 기록을 렌더링해서 가져옵니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied, 수정 기록이 없다면 ErrDescriptionRevisionNotFound을 반환합니다.
+func (uc *ProductUC) GetProductDescriptionRevision(ctx context.Context, userId, productId, revision int64) (*dbmodel.PublicProductDescription, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetProductDescriptionRevision", tracing.KindInternal)
+	defer span.End()
+	if err := checkProductDescriptionManager(ctx, uc.productdb, userId, productId); err != nil {
+		return nil, err
+	}
+	if exists, err := uc.productdb.CheckProductDescriptionRevisionExists(ctx, productId, revision); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrDescriptionRevisionNotFound
+	}
+	record, err := uc.productdb.GetProductDescriptionRevision(ctx, productId, revision)
+	if err != nil {
+		return nil, err
+	}
+	rendered, _, err := renderProductDescription(record.Format, record.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &dbmodel.PublicProductDescription{
+		Format:      record.Format,
+		Content:     record.Content,
+		Html:        rendered,
+		Revision:    record.Revision,
+		UpdatedTime: record.CreatedTime.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// 상품 상세 설명을 특정 수정 기록의 내용으로 되돌립니다.
+// 이전 기록을 지우지 않고, 그 내용을 새로운 수정 기록으로 저장합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied, 수정 기록이 없다면 ErrDescriptionRevisionNotFound을 반환하며,
+// 성공했다면 새로운 수정 번호를 반환합니다.
+func (uc *ProductUC) RestoreProductDescription(ctx context.Context, userId, productId, revision int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.RestoreProductDescription", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if err := checkProductDescriptionManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		}
+		if exists, err := txdb.CheckProductDescriptionRevisionExists(ctx, productId, revision); err != nil {
+			return err
+		} else if !exists {
+			return ErrDescriptionRevisionNotFound
+		}
+		record, err := txdb.GetProductDescriptionRevision(ctx, productId, revision)
+		if err != nil {
+			return err
+		}
+		res, err = saveProductDescription(ctx, txdb, userId, productId, record.Format, record.Content)
+		return err
+	})
+	return res, err
+}
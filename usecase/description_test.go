@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+)
+
+func TestRenderDescriptionBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantHtml string
+		wantText string
+	}{
+		{"empty", `{"blocks": []}`, "", ""},
+		{"heading", `{"blocks": [{"type": "heading", "text": "Title", "level": 3}]}`, "<h3>Title</h3>\n", "Title"},
+		{"heading default level", `{"blocks": [{"type": "heading", "text": "Title", "level": 9}]}`, "<h2>Title</h2>\n", "Title"},
+		{"paragraph", `{"blocks": [{"type": "paragraph", "text": "a & b"}]}`, "<p>a &amp; b</p>\n", "a & b"},
+		{"quote", `{"blocks": [{"type": "quote", "text": "said"}]}`, "<blockquote><p>said</p></blockquote>\n", "said"},
+		{"unordered list", `{"blocks": [{"type": "list", "items": ["a", "b"]}]}`, "<ul><li>a</li><li>b</li></ul>\n", "a\nb"},
+		{"ordered list", `{"blocks": [{"type": "list", "ordered": true, "items": ["a"]}]}`, "<ol><li>a</li></ol>\n", "a"},
+		{
+			"image",
+			`{"blocks": [{"type": "image", "url": "https://a.com/a.png", "alt": "front"}]}`,
+			`<figure><img src="https://a.com/a.png" alt="front"></figure>` + "\n",
+			"front",
+		},
+		{"unknown block", `{"blocks": [{"type": "video", "text": "x"}]}`, "", ""},
+		{
+			"blocks in order",
+			`{"blocks": [{"type": "heading", "text": "T", "level": 1}, {"type": "paragraph", "text": "p"}]}`,
+			"<h1>T</h1>\n<p>p</p>\n",
+			"T\np",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, text, err := renderDescriptionBlocks(tt.content)
+			if err != nil {
+				t.Fatalf("renderDescriptionBlocks() error = %v", err)
+			}
+			if html != tt.wantHtml {
+				t.Errorf("renderDescriptionBlocks() html = %q, want %q", html, tt.wantHtml)
+			}
+			if text != tt.wantText {
+				t.Errorf("renderDescriptionBlocks() text = %q, want %q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestRenderDescriptionBlocksEscapesHtml(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		deny    []string
+	}{
+		{"script in paragraph", `{"blocks": [{"type": "paragraph", "text": "<script>alert(1)</script>"}]}`, []string{"<script"}},
+		{"tag in heading", `{"blocks": [{"type": "heading", "text": "<iframe>", "level": 1}]}`, []string{"<iframe"}},
+		{"tag in list", `{"blocks": [{"type": "list", "items": ["<svg onload=alert(1)>"]}]}`, []string{"<svg"}},
+		{"javascript image", `{"blocks": [{"type": "image", "url": "javascript:alert(1)"}]}`, []string{"<img", "javascript:"}},
+		{"data image", `{"blocks": [{"type": "image", "url": "data:image/png;base64,AAAA"}]}`, []string{"<img"}},
+		{"quote in image url", `{"blocks": [{"type": "image", "url": "https://a.com/\" onerror=\"alert(1)"}]}`, []string{`" onerror`}},
+		{"quote in image alt", `{"blocks": [{"type": "image", "url": "https://a.com", "alt": "\" onerror=\"alert(1)"}]}`, []string{`" onerror`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, _, err := renderDescriptionBlocks(tt.content)
+			if err != nil {
+				t.Fatalf("renderDescriptionBlocks() error = %v", err)
+			}
+			for _, v := range tt.deny {
+				if strings.Contains(html, v) {
+					t.Errorf("renderDescriptionBlocks(%s) = %q, must not contain %q", tt.content, html, v)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckProductDescriptionContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		content string
+		wantErr error
+	}{
+		{"markdown", dbmodel.DescriptionFormatMarkdown, "# Title\n<script>", nil},
+		{"markdown with nul", dbmodel.DescriptionFormatMarkdown, "a\x00b", ErrInvalidDescription},
+		{"markdown too large", dbmodel.DescriptionFormatMarkdown, strings.Repeat("a", 32*1024+1), ErrDescriptionTooLarge},
+		{"blocks", dbmodel.DescriptionFormatBlocks, `{"blocks": [{"type": "paragraph", "text": "a"}]}`, nil},
+		{"blocks not json", dbmodel.DescriptionFormatBlocks, `{"blocks": [`, ErrInvalidDescription},
+		{"blocks null", dbmodel.DescriptionFormatBlocks, `null`, ErrInvalidDescription},
+		{"blocks unknown type", dbmodel.DescriptionFormatBlocks, `{"blocks": [{"type": "video"}]}`, ErrInvalidDescription},
+		{"blocks javascript image", dbmodel.DescriptionFormatBlocks, `{"blocks": [{"type": "image", "url": "javascript:alert(1)"}]}`, ErrInvalidDescription},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkProductDescriptionContent(tt.format, tt.content); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkProductDescriptionContent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
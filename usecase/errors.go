@@ -0,0 +1,154 @@
+package usecase
+
+import "sort"
+
+// 유스케이스가 요청을 처리하지 못한 이유를 알려주는 에러입니다.
+// 디비 오류 같은 예상하지 못한 에러와 구분하기 위해, 유저가 고칠 수 있는 실패는 모두 이 타입으로 반환합니다.
+// Code는 프론트엔드가 실패 이유를 구분할 때 사용하는 고정된 문자열이므로 한 번 정한 뒤에는 바꾸면 안 됩니다.
+// 핸들러는 Kind로 HTTP 상태 코드를 정하고, Code와 Message를 그대로 응답합니다.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+
+	// 실패 이유를 자세히 알려줄 데이터입니다. (예: JSON Schema 검증에 실패한 필드 목록)
+	Details interface{}
+}
+
+// 에러의 종류입니다. 핸들러는 종류에 따라 HTTP 상태 코드를 정합니다.
+type ErrorKind string
+
+const (
+	KindInvalid      ErrorKind = "invalid"      // 요청 데이터가 올바르지 않습니다.
+	KindUnauthorized ErrorKind = "unauthorized" // 인증에 실패했습니다.
+	KindForbidden    ErrorKind = "forbidden"    // 권한이 없습니다.
+	KindNotFound     ErrorKind = "not_found"    // 대상이 존재하지 않습니다.
+	KindConflict     ErrorKind = "conflict"     // 현재 상태와 충돌해서 처리할 수 없습니다.
+	KindTooLarge     ErrorKind = "too_large"    // 요청 데이터가 너무 큽니다.
+)
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// errors.Is로 비교할 때 Details와 상관없이 같은 Code라면 같은 에러로 봅니다.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Details를 붙인 새로운 에러를 반환합니다. 원래 에러는 바꾸지 않습니다.
+func (e *Error) WithDetails(details interface{}) *Error {
+	err := *e
+	err.Details = details
+	return &err
+}
+
+// 정의된 모든 에러입니다.
+var registry = map[string]*Error{}
+
+// 새로운 에러를 정의합니다. 같은 Code를 두 번 정의하면 패닉이 발생합니다.
+func newError(kind ErrorKind, code, message string) *Error {
+	if _, ok := registry[code]; ok {
+		panic("duplicated error code: " + code)
+	}
+	err := &Error{Kind: kind, Code: code, Message: message}
+	registry[code] = err
+	return err
+}
+
+// 정의된 모든 에러를 Code 순서로 반환합니다. 에러 코드 목록을 만들 때 사용합니다.
+func Errors() []*Error {
+	result := make([]*Error, 0, len(registry))
+	for _, v := range registry {
+		result = append(result, v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// 공통
+var (
+	ErrPermissionDenied = newError(KindForbidden, "PERMISSION_DENIED", "you do not have permission to manage this resource")
+	ErrProductNotFound  = newError(KindNotFound, "PRODUCT_NOT_FOUND", "product does not exist")
+	ErrInvalidSku       = newError(KindInvalid, "INVALID_SKU", "sku is required for this product or does not belong to it")
+)
+
+// 회원가입, 로그인
+var (
+	ErrNicknameTaken      = newError(KindConflict, "NICKNAME_TAKEN", "nickname is already in use")
+	ErrUsernameTaken      = newError(KindConflict, "USERNAME_TAKEN", "username is already in use")
+	ErrInvalidCredentials = newError(KindUnauthorized, "INVALID_CREDENTIALS", "username or password is incorrect")
+)
+
+// 리뷰
+var (
+	ErrReviewNotFound = newError(KindNotFound, "REVIEW_NOT_FOUND", "parent review does not exist")
+)
+
+// pbv 옵션
+var (
+	ErrPbvOptionNotFound        = newError(KindNotFound, "PBV_OPTION_NOT_FOUND", "pbv option does not exist")
+	ErrPbvOptionVersionNotFound = newError(KindNotFound, "PBV_OPTION_VERSION_NOT_FOUND", "pbv option version does not exist")
+	ErrPbvOptionLimitReached    = newError(KindConflict, "PBV_OPTION_LIMIT_REACHED", "you cannot add more pbv options")
+	ErrInvalidPbvOption         = newError(KindInvalid, "INVALID_PBV_OPTION", "pbv option data is not valid")
+	ErrPbvOptionTooLarge        = newError(KindTooLarge, "PBV_OPTION_TOO_LARGE", "pbv option data is too large")
+	ErrPbvPartsUnavailable      = newError(KindConflict, "PBV_PARTS_UNAVAILABLE", "some parts are no longer available or out of stock")
+	ErrPbvOptionNotShared       = newError(KindConflict, "PBV_OPTION_NOT_SHARED", "pbv option is not shared")
+	ErrSharedPbvOptionNotFound  = newError(KindNotFound, "SHARED_PBV_OPTION_NOT_FOUND", "share token does not exist or has been revoked")
+)
+
+// 찜
+var (
+	ErrNotInWishlist = newError(KindNotFound, "NOT_IN_WISHLIST", "product is not in your wishlist")
+)
+
+// 쿠폰, 결제
+var (
+	ErrCouponCodeTaken        = newError(KindConflict, "COUPON_CODE_TAKEN", "coupon code is already in use")
+	ErrCouponTargetNotFound   = newError(KindNotFound, "COUPON_TARGET_NOT_FOUND", "brand or category of the coupon does not exist")
+	ErrCouponNotFound         = newError(KindNotFound, "COUPON_NOT_FOUND", "coupon does not exist")
+	ErrCouponNotActive        = newError(KindConflict, "COUPON_NOT_ACTIVE", "coupon is not in its valid period")
+	ErrCouponExhausted        = newError(KindConflict, "COUPON_EXHAUSTED", "coupon has reached its usage limit")
+	ErrCouponUserLimitReached = newError(KindConflict, "COUPON_USER_LIMIT_REACHED", "you have reached the usage limit of this coupon")
+	ErrCouponNotApplicable    = newError(KindConflict, "COUPON_NOT_APPLICABLE", "coupon cannot be applied to your cart")
+	ErrCartEmpty              = newError(KindConflict, "CART_EMPTY", "cart is empty")
+	ErrOutOfStock             = newError(KindConflict, "OUT_OF_STOCK", "some products in your cart are out of stock")
+)
+
+// 상품 옵션, SKU
+var (
+	ErrVariantNameTaken    = newError(KindConflict, "VARIANT_NAME_TAKEN", "variant with the same name already exists")
+	ErrProductHasSkus      = newError(KindConflict, "PRODUCT_HAS_SKUS", "variants cannot be added to a product that already has skus")
+	ErrSkuNotFound         = newError(KindNotFound, "SKU_NOT_FOUND", "sku does not exist")
+	ErrSkuCodeTaken        = newError(KindConflict, "SKU_CODE_TAKEN", "sku code is already in use")
+	ErrInvalidSkuValues    = newError(KindInvalid, "INVALID_SKU_VALUES", "variant values do not form a valid combination or the price becomes negative")
+	ErrSkuCombinationTaken = newError(KindConflict, "SKU_COMBINATION_TAKEN", "sku with the same combination already exists")
+	ErrNegativeSkuPrice    = newError(KindInvalid, "NEGATIVE_SKU_PRICE", "price of the sku becomes negative")
+)
+
+// 차종, 내 차고
+var (
+	ErrVehicleModelTaken      = newError(KindConflict, "VEHICLE_MODEL_TAKEN", "vehicle model with the same maker, model and start year already exists")
+	ErrVehicleNotFound        = newError(KindNotFound, "VEHICLE_NOT_FOUND", "vehicle model does not exist")
+	ErrVehicleAlreadyInGarage = newError(KindConflict, "VEHICLE_ALREADY_IN_GARAGE", "vehicle is already in your garage")
+	ErrGarageFull             = newError(KindConflict, "GARAGE_FULL", "you cannot add more vehicles to your garage")
+)
+
+// 상품 이미지
+var (
+	ErrUnsupportedImage   = newError(KindInvalid, "UNSUPPORTED_IMAGE", "image format is not supported or does not match content_type")
+	ErrImageTooLarge      = newError(KindTooLarge, "IMAGE_TOO_LARGE", "image file or pixel size is too large")
+	ErrImageLimitReached  = newError(KindConflict, "IMAGE_LIMIT_REACHED", "product already has the maximum number of images")
+	ErrImageOrderMismatch = newError(KindInvalid, "IMAGE_ORDER_MISMATCH", "image_ids must contain every image of the product exactly once")
+	ErrImageNotFound      = newError(KindNotFound, "IMAGE_NOT_FOUND", "image does not exist for this product")
+)
+
+// 상품 상세 설명
+var (
+	ErrInvalidDescription          = newError(KindInvalid, "INVALID_DESCRIPTION", "product description is not valid")
+	ErrDescriptionTooLarge         = newError(KindTooLarge, "DESCRIPTION_TOO_LARGE", "product description is too large")
+	ErrDescriptionRevisionNotFound = newError(KindNotFound, "DESCRIPTION_REVISION_NOT_FOUND", "product description revision does not exist")
+)
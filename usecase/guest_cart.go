@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/metrics"
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// 게스트 장바구니에 담긴 상품 리스트와 금액을 가져옵니다.
+func (uc *ProductUC) GetGuestCartProducts(ctx context.Context, guestId string) (*dbmodel.CartSummary, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetGuestCartProducts", tracing.KindInternal)
+	defer span.End()
+	carts, err := uc.productdb.GetGuestCartProducts(ctx, guestId)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeCart(carts), nil
+}
+
+// 게스트 장바구니에 상품을 추가합니다.
+// 존재하지 않는 상품이라면 무시합니다.
+// 장바구니에 이미 상품이 담겨있다면, 기존의 개수에 추가로 개수를 더해줍니다.
+// 유저 장바구니와 마찬가지로 잘못 고른 SKU라면 ErrInvalidSku를 반환합니다.
+func (uc *ProductUC) AddToGuestCart(ctx context.Context, guestId string, productId, skuId, amount int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddToGuestCart", tracing.KindInternal)
+	defer span.End()
+	// 존재하는 상품인지 확인합니다.
+	if exists, err := uc.productdb.CheckProductExists(ctx, productId); err != nil {
+		return err
+	} else if !exists {
+		// 존재하지 않는 상품이라면 무시합니다.
+		return nil
+	}
+
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		price, _, ok, err := getCartLineStock(ctx, txdb, productId, skuId)
+		if err != nil {
+			return err
+		} else if !ok {
+			return ErrInvalidSku
+		}
+		// 장바구니에 이미 상품이 담겨있는지 확인합니다.
+		if isExists, err := txdb.CheckGuestCartHasProduct(ctx, guestId, productId, skuId); err != nil {
+			return err
+		} else if !isExists {
+			// 존재하지 않는다면 추가하고 종료합니다.
+			return txdb.AddGuestCart(ctx, &dbmodel.GuestCart{
+				GuestId:    guestId,
+				ProductId:  productId,
+				SkuId:      skuId,
+				Amount:     amount,
+				PriceAtAdd: price,
+			})
+		}
+		// 존재한다면, 개수를 더해줍니다.
+		cart, err := txdb.GetGuestCartProduct(ctx, guestId, productId, skuId)
+		if err != nil {
+			return err
+		}
+		cart.Amount += amount
+		cart.PriceAtAdd = price
+		return txdb.UpdateGuestCart(ctx, cart)
+	})
+	if err == nil {
+		metrics.CartAdds.Inc("guest")
+	}
+	return err
+}
+
+// 게스트 장바구니에 담긴 상품의 개수를 변경합니다.
+// 유저 장바구니와 마찬가지로 담은 가격을 현재 가격으로 갱신합니다.
+func (uc *ProductUC) UpdateGuestCartAmount(ctx context.Context, guestId string, productId, skuId, amount int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.UpdateGuestCartAmount", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		return updateGuestCartAmount(ctx, txdb, guestId, productId, skuId, amount)
+	})
+	return err
+}
+
+// 트랜잭션 안에서 게스트 장바구니에 담긴 상품(SKU)의 개수를 변경합니다.
+// 장바구니에 담겨있지 않은 상품이라면 무시합니다.
+func updateGuestCartAmount(ctx context.Context, txdb database.ProductDatabase, guestId string, productId, skuId, amount int64) error {
+	// 장바구니에 이미 상품이 담겨있는지 확인합니다.
+	if isExists, err := txdb.CheckGuestCartHasProduct(ctx, guestId, productId, skuId); err != nil {
+		return err
+	} else if !isExists {
+		// 존재하지 않는다면 무시합니다.
+		return nil
+	}
+	cart, err := txdb.GetGuestCartProduct(ctx, guestId, productId, skuId)
+	if err != nil {
+		return err
+	}
+	price, _, _, err := getCartLineStock(ctx, txdb, productId, skuId)
+	if err != nil {
+		return err
+	}
+	cart.Amount = amount
+	cart.PriceAtAdd = price
+	return txdb.UpdateGuestCart(ctx, cart)
+}
+
+// 게스트 장바구니에서 상품(SKU)을 삭제합니다.
+// 만약 장바구니에 상품이 없다면 무시합니다.
+func (uc *ProductUC) DeleteFromGuestCart(ctx context.Context, guestId string, productId, skuId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteFromGuestCart", tracing.KindInternal)
+	defer span.End()
+	if exists, err := uc.productdb.CheckGuestCartHasProduct(ctx, guestId, productId, skuId); err != nil {
+		return err
+	} else if exists {
+		return uc.productdb.DeleteGuestCartProduct(ctx, guestId, productId, skuId)
+	}
+	return nil
+}
+
+// 게스트 장바구니에서 선택한 줄들을 한 번에 삭제합니다.
+// 줄은 상품 아이디와 SKU 아이디로 고르므로, 같은 상품이라도 고르지 않은 SKU는 남습니다.
+// 장바구니에 없는 줄은 무시합니다.
+func (uc *ProductUC) DeleteSelectedFromGuestCart(ctx context.Context, guestId string, items []*dbmodel.CartItem) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteSelectedFromGuestCart", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		return txdb.DeleteGuestCartProducts(ctx, guestId, items)
+	})
+	return err
+}
+
+// 게스트 장바구니에 담긴 여러 상품의 개수를 한 번에 변경합니다.
+// 모두 바꾸거나, 하나도 바꾸지 않습니다.
+// 장바구니에 없는 상품은 무시합니다.
+func (uc *ProductUC) UpdateGuestCartAmounts(ctx context.Context, guestId string, amounts []*dbmodel.CartAmount) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.UpdateGuestCartAmounts", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		for _, v := range amounts {
+			if err := updateGuestCartAmount(ctx, txdb, guestId, v.ProductId, v.SkuId, v.Amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// 게스트 장바구니를 모두 비웁니다.
+func (uc *ProductUC) ClearGuestCart(ctx context.Context, guestId string) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.ClearGuestCart", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.DeleteGuestCart(ctx, guestId)
+}
+
+// 게스트 장바구니를 유저 장바구니로 합치고 게스트 장바구니를 비웁니다.
+// 같은 상품(SKU)이 양쪽에 있다면 개수를 더하지만, 재고를 넘는 만큼은 담지 않습니다.
+// 유저가 원래 담아둔 개수는 재고가 모자라더라도 줄이지 않습니다.
+// 담은 뒤 SKU 구성이 바뀌어 더 이상 고를 수 없는 상품은 담지 않습니다.
+// 트랜잭션 안에서 호출해야 합니다.
+func mergeGuestCart(ctx context.Context, txdb database.ProductDatabase, userId int64, guestId string) (*dbmodel.CartMergeResult, error) {
+	result := &dbmodel.CartMergeResult{AdjustedProductIds: []int64{}}
+	carts, err := txdb.GetGuestCarts(ctx, guestId)
+	if err != nil {
+		return nil, err
+	}
+	if len(carts) == 0 {
+		return result, nil
+	}
+	for _, v := range carts {
+		if exists, err := txdb.CheckProductExists(ctx, v.ProductId); err != nil {
+			return nil, err
+		} else if !exists {
+			result.AdjustedProductIds = append(result.AdjustedProductIds, v.ProductId)
+			continue
+		}
+		price, stock, ok, err := getCartLineStock(ctx, txdb, v.ProductId, v.SkuId)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			result.AdjustedProductIds = append(result.AdjustedProductIds, v.ProductId)
+			continue
+		}
+		cartAmount, err := getCartAmount(ctx, txdb, userId, v.ProductId, v.SkuId)
+		if err != nil {
+			return nil, err
+		}
+		amount := v.Amount
+		if cartAmount+amount > stock {
+			result.AdjustedProductIds = append(result.AdjustedProductIds, v.ProductId)
+			amount = stock - cartAmount
+		}
+		if amount <= 0 {
+			continue
+		}
+		if err := addCartProduct(ctx, txdb, userId, v.ProductId, v.SkuId, amount, price); err != nil {
+			return nil, err
+		}
+		result.MergedCount++
+	}
+	if err := txdb.DeleteGuestCart(ctx, guestId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
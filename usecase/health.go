@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/migrate"
+	"github.com/JongGeonClass/JGC-API/model"
+	"github.com/JongGeonClass/JGC-API/schema"
+	"github.com/JongGeonClass/JGC-API/version"
+)
+
+// 준비 상태를 검사할 때 디비 쿼리 하나를 기다릴 최대 시간입니다.
+const readinessCheckTimeout = 2 * time.Second
+
+// Health Usecase의 인터페이스입니다.
+type HealthUsecase interface {
+	CheckReadiness(ctx context.Context) ([]*model.ReadinessCheck, bool)
+	GetVersion() *model.VersionInfo
+}
+
+// Health Usecase의 구현체입니다.
+type HealthUC struct {
+	healthdb database.HealthDatabase
+}
+
+// 검사 함수를 실행하고 결과를 만들어줍니다.
+func runReadinessCheck(name string, check func() error) *model.ReadinessCheck {
+	result := &model.ReadinessCheck{Name: name, Ok: true}
+	if err := check(); err != nil {
+		result.Ok = false
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// 디비에 마이그레이션 파일이 모두 적용되어 있는지 확인합니다.
+// 마이그레이션을 실행하지 않은 디비에 새 버전의 서버가 붙는 것을 막기 위한 검사입니다.
+// 디비가 더 최신인 경우는 배포를 되돌리는 중일 수 있으므로 통과시킵니다.
+func (uc *HealthUC) checkMigrations(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+	latest, err := migrate.Latest()
+	if err != nil {
+		return err
+	}
+	current, err := uc.healthdb.GetMigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if current < latest {
+		return fmt.Errorf("schema version %d is behind migration %d", current, latest)
+	}
+	return nil
+}
+
+// 서버가 요청을 받을 준비가 되었는지 검사합니다.
+// 디비 연결, 마이그레이션, 설정을 차례로 확인하며, 모든 검사를 통과했다면 true를 함께 반환합니다.
+func (uc *HealthUC) CheckReadiness(ctx context.Context) ([]*model.ReadinessCheck, bool) {
+	checks := []*model.ReadinessCheck{
+		runReadinessCheck("database", func() error {
+			ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+			defer cancel()
+			return uc.healthdb.Ping(ctx)
+		}),
+		runReadinessCheck("migrations", func() error {
+			return uc.checkMigrations(ctx)
+		}),
+		runReadinessCheck("config", func() error {
+			return config.Get().Validate()
+		}),
+	}
+	ready := true
+	for _, v := range checks {
+		ready = ready && v.Ok
+	}
+	return checks, ready
+}
+
+// 배포된 서버의 빌드 정보를 가져옵니다.
+// MigrationVersion은 이 서버가 기대하는 디비 스키마 버전(마지막 마이그레이션 번호)입니다.
+func (uc *HealthUC) GetVersion() *model.VersionInfo {
+	// 마이그레이션 파일은 빌드에 포함되므로 실패하지 않으며, 실패했다면 0으로 응답합니다.
+	migrationVersion, _ := migrate.Latest()
+	return &model.VersionInfo{
+		Commit:           version.Commit,
+		BuildTime:        version.BuildTime,
+		GoVersion:        runtime.Version(),
+		MigrationVersion: migrationVersion,
+		SchemaVersions: map[string]int64{
+			"pbv_option":          schema.PbvOptionVersion,
+			"product_description": schema.ProductDescriptionVersion,
+		},
+	}
+}
+
+// Health Usecase를 반환합니다.
+func NewHealth(healthdb database.HealthDatabase) HealthUsecase {
+	return &HealthUC{healthdb}
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/logger"
+	"github.com/JongGeonClass/JGC-API/media"
+	"github.com/JongGeonClass/JGC-API/tracing"
+	"github.com/JongGeonClass/JGC-API/util"
+)
+
+// 디비에 저장된 상품 이미지 정보를 유저에게 보여줄 형태로 바꿔줍니다.
+func (uc *ProductUC) publicProductImage(image *dbmodel.ProductImage) *dbmodel.PublicProductImage {
+	return &dbmodel.PublicProductImage{
+		Id:           image.Id,
+		Url:          uc.storage.Url(image.ImageKey),
+		ThumbnailUrl: uc.storage.Url(image.ThumbnailKey),
+		Width:        image.Width,
+		Height:       image.Height,
+		Position:     image.Position,
+	}
+}
+
+// 상품의 갤러리 이미지 리스트를 보여줄 순서대로 가져옵니다.
+func (uc *ProductUC) getProductGallery(ctx context.Context, productId int64) ([]*dbmodel.PublicProductImage, error) {
+	images, err := uc.productdb.GetProductImages(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*dbmodel.PublicProductImage, 0, len(images))
+	for _, v := range images {
+		result = append(result, uc.publicProductImage(v))
+	}
+	return result, nil
+}
+
+// 상품의 대표 이미지를 갤러리의 첫 번째 이미지로 맞춰줍니다.
+// 기존 클라이언트는 title_image_s3만 보므로 갤러리가 바뀔 때마다 호출해야 합니다.
+// 갤러리가 비어있다면 대표 이미지를 비웁니다.
+func (uc *ProductUC) syncProductTitleImage(ctx context.Context, txdb database.ProductDatabase, productId int64) error {
+	images, err := txdb.GetProductImages(ctx, productId)
+	if err != nil {
+		return err
+	}
+	titleImage := ""
+	if len(images) > 0 {
+		titleImage = uc.storage.Url(images[0].ImageKey)
+	}
+	return txdb.UpdateProductTitleImage(ctx, productId, titleImage)
+}
+
+// 저장소에서 파일들을 삭제합니다.
+// 디비 작업이 끝난 뒤 정리하는 용도이므로, 실패하더라도 로그만 남기고 넘어갑니다.
+func (uc *ProductUC) deleteMediaFiles(ctx context.Context, keys ...string) {
+	for _, v := range keys {
+		if err := uc.storage.Delete(ctx, v); err != nil {
+			logger.Error(ctx, "media delete error(%s): %+v", v, err)
+		}
+	}
+}
+
+// 상품 갤러리에 이미지를 업로드합니다.
+// 관리자나 상품 브랜드의 주인만 업로드할 수 있으며, 업로드한 이미지는 갤러리의 맨 뒤에 추가됩니다.
+// 원본과 썸네일을 저장소에 먼저 저장한 뒤 디비에 기록하며, 디비 기록에 실패하면 저장한 파일을 지웁니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied,
+// 지원하지 않거나 선언한 타입과 다른 이미지라면 ErrUnsupportedImage, 상품의 이미지가 이미 가득 찼다면 ErrImageLimitReached,
+// 이미지의 크기나 픽셀 수가 너무 크다면 ErrImageTooLarge를 반환합니다.
+func (uc *ProductUC) UploadProductImage(ctx context.Context, userId, productId int64, contentType string, data []byte) (*dbmodel.PublicProductImage, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.UploadProductImage", tracing.KindInternal)
+	defer span.End()
+	conf := config.Get()
+	if exists, err := uc.productdb.CheckProductExists(ctx, productId); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrProductNotFound
+	}
+	if ok, err := checkProductManager(ctx, uc.productdb, userId, productId); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrPermissionDenied
+	}
+	if count, err := uc.productdb.GetProductImageCount(ctx, productId); err != nil {
+		return nil, err
+	} else if count >= conf.Media.MaxImagesPerProduct {
+		return nil, ErrImageLimitReached
+	}
+
+	img, err := media.DecodeImage(data, contentType, conf.Media.MaxUploadSize, conf.Media.MaxImagePixels)
+	if errors.Is(err, media.ErrUnsupportedImage) {
+		return nil, ErrUnsupportedImage
+	} else if errors.Is(err, media.ErrImageTooLarge) {
+		return nil, ErrImageTooLarge
+	} else if err != nil {
+		return nil, err
+	}
+	thumbnail, err := img.Thumbnail(conf.Media.ThumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+
+	name := util.NewUuid()
+	imageKey := fmt.Sprintf("products/%d/%s.%s", productId, name, img.Extension)
+	thumbnailKey := fmt.Sprintf("products/%d/%s_thumb.%s", productId, name, img.Extension)
+	if err := uc.storage.Put(ctx, imageKey, data, img.ContentType); err != nil {
+		return nil, err
+	}
+	if err := uc.storage.Put(ctx, thumbnailKey, thumbnail, img.ContentType); err != nil {
+		uc.deleteMediaFiles(ctx, imageKey)
+		return nil, err
+	}
+
+	image := &dbmodel.ProductImage{
+		ProductId:    productId,
+		ImageKey:     imageKey,
+		ThumbnailKey: thumbnailKey,
+		ContentType:  img.ContentType,
+		Size:         int64(len(data)),
+		Width:        int64(img.Width),
+		Height:       int64(img.Height),
+		CreatedBy:    userId,
+	}
+	err = uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 파일을 저장하는 동안 다른 이미지가 추가되었을 수 있으므로 다시 확인합니다.
+		images, err := txdb.GetProductImages(ctx, productId)
+		if err != nil {
+			return err
+		}
+		if int64(len(images)) >= conf.Media.MaxImagesPerProduct {
+			return ErrImageLimitReached
+		}
+		if len(images) > 0 {
+			image.Position = images[len(images)-1].Position + 1
+		}
+		imageId, err := txdb.AddProductImage(ctx, image)
+		if err != nil {
+			return err
+		}
+		image.Id = imageId
+		return uc.syncProductTitleImage(ctx, txdb, productId)
+	})
+	if err != nil {
+		uc.deleteMediaFiles(ctx, imageKey, thumbnailKey)
+		return nil, err
+	}
+	return uc.publicProductImage(image), nil
+}
+
+// 상품 갤러리의 이미지 순서를 변경합니다.
+// imageIds는 상품의 모든 이미지 아이디를 보여줄 순서대로 담아야 하며, 첫 번째 이미지가 대표 이미지가 됩니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied, imageIds가 상품의 이미지 목록과 다르다면 ErrImageOrderMismatch를 반환합니다.
+func (uc *ProductUC) ReorderProductImages(ctx context.Context, userId, productId int64, imageIds []int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.ReorderProductImages", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		images, err := txdb.GetProductImages(ctx, productId)
+		if err != nil {
+			return err
+		}
+		if len(images) != len(imageIds) {
+			return ErrImageOrderMismatch
+		}
+		remains := map[int64]bool{}
+		for _, v := range images {
+			remains[v.Id] = true
+		}
+		for _, v := range imageIds {
+			if !remains[v] {
+				return ErrImageOrderMismatch
+			}
+			delete(remains, v)
+		}
+		for i, v := range imageIds {
+			if err := txdb.UpdateProductImagePosition(ctx, v, int64(i)); err != nil {
+				return err
+			}
+		}
+		return uc.syncProductTitleImage(ctx, txdb, productId)
+	})
+	return err
+}
+
+// 상품 갤러리에서 이미지를 삭제합니다.
+// 디비에서 먼저 삭제한 뒤 저장소의 파일을 지웁니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied, 상품의 이미지가 아니라면 ErrImageNotFound을 반환합니다.
+func (uc *ProductUC) DeleteProductImage(ctx context.Context, userId, productId, imageId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteProductImage", tracing.KindInternal)
+	defer span.End()
+	var image *dbmodel.ProductImage
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		if exists, err := txdb.CheckProductImageExists(ctx, productId, imageId); err != nil {
+			return err
+		} else if !exists {
+			return ErrImageNotFound
+		}
+		var err error
+		image, err = txdb.GetProductImage(ctx, imageId)
+		if err != nil {
+			return err
+		}
+		if err := txdb.DeleteProductImage(ctx, imageId); err != nil {
+			return err
+		}
+		return uc.syncProductTitleImage(ctx, txdb, productId)
+	})
+	if err != nil {
+		return err
+	}
+	uc.deleteMediaFiles(ctx, image.ImageKey, image.ThumbnailKey)
+	return nil
+}
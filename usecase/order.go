@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// 유저의 주문 리스트를 가져옵니다.
+func (uc *ProductUC) GetOrders(ctx context.Context, userId int64) ([]*dbmodel.PublicOrder, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetOrders", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.GetOrders(ctx, userId)
+}
+
+// 장바구니에 담긴 모든 상품을 결제합니다.
+// 재고 차감, 주문 생성, 쿠폰 사용, 판매량 갱신, 장바구니 비우기는 모두 함께 성공하거나 함께 실패합니다.
+// code가 빈 문자열이라면 쿠폰 없이 결제하며, 쿠폰 관련 에러는 ApplyCoupon과 같습니다.
+// 장바구니가 비어있다면 ErrCartEmpty, 재고가 부족한 상품이 있다면 ErrOutOfStock을 반환합니다.
+// 성공하면 만들어진 주문 아이디를 반환합니다.
+func (uc *ProductUC) Checkout(ctx context.Context, userId int64, code string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.Checkout", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		carts, err := txdb.GetCartProducts(ctx, userId)
+		if err != nil {
+			return err
+		}
+		if len(carts) == 0 {
+			return ErrCartEmpty
+		}
+		for _, v := range carts {
+			if v.Amount > v.Stock {
+				return ErrOutOfStock
+			}
+		}
+		ntime := time.Now()
+		var coupon *dbmodel.Coupon
+		if code != "" {
+			// 쿠폰을 잠가서, 같은 쿠폰을 쓰는 다른 결제는 이 결제가 끝난 뒤에 검사합니다.
+			coupon, err = checkCoupon(ctx, txdb, userId, code, ntime, true)
+			if err != nil {
+				return err
+			}
+		}
+		priced, err := priceCart(ctx, txdb, carts, coupon, ntime)
+		if err != nil {
+			return err
+		}
+
+		order := &dbmodel.Order{
+			UserId:          userId,
+			Subtotal:        priced.Subtotal + priced.ProductDiscount,
+			ProductDiscount: priced.ProductDiscount,
+			CouponDiscount:  priced.CouponDiscount,
+			ShippingFee:     priced.ShippingFee,
+			Total:           priced.Total,
+			Status:          dbmodel.OrderStatusPaid,
+		}
+		if coupon != nil {
+			order.CouponId = coupon.Id
+		}
+		orderId, err := txdb.AddOrder(ctx, order)
+		if err != nil {
+			return err
+		}
+		for _, v := range priced.Lines {
+			// 위에서 재고를 확인했더라도 동시에 결제될 수 있으므로 디비에서 다시 확인하며 차감합니다.
+			// SKU가 있는 상품은 SKU의 재고를 차감합니다.
+			decrease := txdb.DecreaseProductAmount
+			target := v.ProductId
+			if v.SkuId != 0 {
+				decrease = txdb.DecreaseProductSkuAmount
+				target = v.SkuId
+			}
+			if ok, err := decrease(ctx, target, v.Amount); err != nil {
+				return err
+			} else if !ok {
+				return ErrOutOfStock
+			}
+			if err := txdb.AddOrderItem(ctx, &dbmodel.OrderItem{
+				OrderId:       orderId,
+				ProductId:     v.ProductId,
+				SkuId:         v.SkuId,
+				ProductName:   v.ProductName,
+				OriginalPrice: v.OriginalPrice,
+				UnitPrice:     v.UnitPrice,
+				Amount:        v.Amount,
+			}); err != nil {
+				return err
+			}
+			amount := v.Amount
+			if err := updateProductStatistics(ctx, txdb, v.ProductId, func(statistics *dbmodel.ProductStatistics) {
+				statistics.SoldQuantity += amount
+			}); err != nil {
+				return err
+			}
+		}
+		if coupon != nil {
+			// 동시에 결제되어도 전체 사용 횟수를 넘지 않도록 디비에서 조건을 걸고 올립니다.
+			if ok, err := txdb.IncreaseCouponUsedCount(ctx, coupon.Id); err != nil {
+				return err
+			} else if !ok {
+				return ErrCouponExhausted
+			}
+			if err := txdb.AddCouponRedemption(ctx, &dbmodel.CouponRedemption{
+				CouponId:       coupon.Id,
+				UserId:         userId,
+				OrderId:        orderId,
+				DiscountAmount: priced.CouponDiscount,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := txdb.DeleteCart(ctx, userId); err != nil {
+			return err
+		}
+		res = orderId
+		return nil
+	})
+	return res, err
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/schema"
+	"github.com/JongGeonClass/JGC-API/util"
+)
+
+// 요청으로 받은 pbv 옵션 데이터를 파싱하고 최신 스키마로 검증합니다.
+// 크기 제한을 넘었다면 ErrPbvOptionTooLarge를, 스키마에 맞지 않는다면 검증에 실패한 필드 목록을 담은 ErrInvalidPbvOption을 반환합니다.
+func parsePbvOptionData(dataStr string) (dbmodel.DataJson, error) {
+	conf := config.Get()
+	if len(dataStr) > conf.Pbv.MaxDataSize {
+		return nil, ErrPbvOptionTooLarge
+	}
+	dest := dbmodel.DataJson{}
+	if err := json.Unmarshal([]byte(dataStr), &dest); err != nil || dest == nil {
+		return nil, ErrInvalidPbvOption.WithDetails([]*util.JsonSchemaError{
+			{Path: "", Message: "must be a JSON object"},
+		})
+	}
+	if errs := schema.ValidatePbvOption(dest); len(errs) > 0 {
+		return nil, ErrInvalidPbvOption.WithDetails(errs)
+	}
+	return dest, nil
+}
+
+// 이전 스키마 버전으로 저장된 pbv 옵션 데이터를 최신 스키마 버전으로 변환합니다.
+func upgradePbvOptionData(data dbmodel.DataJson, schemaVersion int64) (dbmodel.DataJson, error) {
+	return schema.UpgradePbvOption(data, schemaVersion)
+}
+
+// pbv 옵션 데이터의 parts에 담긴 부품 하나입니다.
+// 부품 하나는 슬롯에 끼워지는 실제 상품 하나를 가리킵니다.
+type pbvOptionPart struct {
+	Slot      string `json:"slot"`
+	ProductId int64  `json:"product_id"`
+	Amount    int64  `json:"amount"`
+}
+
+// 최신 스키마로 검증된 pbv 옵션 데이터에서 부품 목록을 꺼냅니다.
+// 개수가 없는 부품은 1개로 취급합니다.
+// 스키마를 통과했더라도 정수로 읽을 수 없는 값이 있다면 ErrInvalidPbvOption을 반환합니다.
+func pbvOptionParts(data dbmodel.DataJson) ([]*pbvOptionPart, error) {
+	parts := []*pbvOptionPart{}
+	raw, ok := data["parts"]
+	if !ok {
+		return parts, nil
+	}
+	pbyte, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(pbyte, &parts); err != nil {
+		return nil, ErrInvalidPbvOption.WithDetails([]*util.JsonSchemaError{
+			{Path: "/parts", Message: "must contain integer product_id and amount"},
+		})
+	}
+	for _, v := range parts {
+		if v.Amount == 0 {
+			v.Amount = 1
+		}
+	}
+	return parts, nil
+}
+
+// 부품 목록에 있는 상품 아이디로 상품들을 가져와 아이디별로 묶어줍니다.
+func getPbvPartProducts(ctx context.Context, txdb database.ProductDatabase, parts []*pbvOptionPart) (map[int64]*dbmodel.Product, error) {
+	productIds := []int64{}
+	for _, v := range parts {
+		productIds = append(productIds, v.ProductId)
+	}
+	products, err := txdb.GetProductsByIds(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+	result := map[int64]*dbmodel.Product{}
+	for _, v := range products {
+		result[v.Id] = v
+	}
+	return result, nil
+}
+
+// 부품으로 고른 상품이 모두 존재하는지 확인합니다.
+// 존재하지 않는 상품이 있다면 해당 필드를 가리키는 ErrInvalidPbvOption을 반환합니다.
+func checkPbvOptionParts(ctx context.Context, txdb database.ProductDatabase, data dbmodel.DataJson) error {
+	parts, err := pbvOptionParts(data)
+	if err != nil {
+		return err
+	}
+	products, err := getPbvPartProducts(ctx, txdb, parts)
+	if err != nil {
+		return err
+	}
+	errs := []*util.JsonSchemaError{}
+	for i, v := range parts {
+		if _, ok := products[v.ProductId]; !ok {
+			errs = append(errs, &util.JsonSchemaError{
+				Path:    fmt.Sprintf("/parts/%d/product_id", i),
+				Message: "product does not exist",
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return ErrInvalidPbvOption.WithDetails(errs)
+	}
+	return nil
+}
+
+// 현재 상품 가격과 재고로 pbv 옵션의 견적을 계산합니다.
+// 사라진 상품, 재고가 모자란 상품, SKU를 골라야 하는 상품이 있다면 구매할 수 없는 견적이 됩니다.
+// 부품이 하나도 없는 옵션도 구매할 수 없습니다.
+func quotePbvOption(ctx context.Context, txdb database.ProductDatabase, option *dbmodel.PbvOption) (*dbmodel.PbvQuote, error) {
+	data, err := upgradePbvOptionData(option.Data, option.SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := pbvOptionParts(data)
+	if err != nil {
+		return nil, err
+	}
+	products, err := getPbvPartProducts(ctx, txdb, parts)
+	if err != nil {
+		return nil, err
+	}
+	// 부품은 SKU를 고르지 않으므로, SKU가 있는 상품은 견적으로 구매할 수 없습니다.
+	productIds := []int64{}
+	for id := range products {
+		productIds = append(productIds, id)
+	}
+	skuProductIds, err := txdb.GetProductIdsWithSkus(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+	hasSkus := map[int64]bool{}
+	for _, v := range skuProductIds {
+		hasSkus[v] = true
+	}
+	// 같은 상품을 여러 슬롯에 골랐다면 재고는 합친 개수로 확인해야 합니다.
+	needs := map[int64]int64{}
+	for _, v := range parts {
+		needs[v.ProductId] += v.Amount
+	}
+	quote := &dbmodel.PbvQuote{
+		OptionId:    option.Id,
+		Lines:       []*dbmodel.PbvQuoteLine{},
+		Purchasable: len(parts) > 0,
+	}
+	for _, v := range parts {
+		line := &dbmodel.PbvQuoteLine{
+			Slot:      v.Slot,
+			ProductId: v.ProductId,
+			Amount:    v.Amount,
+		}
+		if product, ok := products[v.ProductId]; ok {
+			line.ProductName = product.Name
+			line.Price = product.Price
+			line.Stock = product.Amount
+			line.Subtotal = product.Price * v.Amount
+			line.Available = product.Amount >= needs[v.ProductId] && !hasSkus[v.ProductId]
+		}
+		if !line.Available {
+			quote.Purchasable = false
+		}
+		quote.Total += line.Subtotal
+		quote.Lines = append(quote.Lines, line)
+	}
+	return quote, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+)
+
+func TestOldPbvOptionCanBeSavedUnchanged(t *testing.T) {
+	tests := []struct {
+		name          string
+		schemaVersion int64
+		data          dbmodel.DataJson
+	}{
+		{"v0", 0, dbmodel.DataJson{"vehicle": "PV5", "wheel": "18inch"}},
+		{"v0 long option", 0, dbmodel.DataJson{"vehicle": "PV5", "note": strings.Repeat("n", 300)}},
+		{"v0 long vehicle", 0, dbmodel.DataJson{"vehicle": strings.Repeat("v", 150), "memo": strings.Repeat("m", 1200)}},
+		{"v0 nested option", 0, dbmodel.DataJson{"vehicle": "PV5", "extras": []interface{}{strings.Repeat("e", 300)}}},
+		{"v1", 1, dbmodel.DataJson{"vehicle": "PV5", "options": map[string]interface{}{"wheel": "18inch"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newPbvOptionDB()
+			uc := newPbvOptionUC(db)
+			id, err := db.AddPbvOption(ctx, &dbmodel.PbvOption{UserId: 1, Version: 1, SchemaVersion: tt.schemaVersion, Data: tt.data})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 읽어온 데이터를 바꾸지 않고 그대로 저장할 수 있어야 합니다.
+			_, dataStr, err := uc.GetPbvOption(ctx, 1, id)
+			if err != nil {
+				t.Fatalf("GetPbvOption() error = %v", err)
+			}
+			if _, err := uc.UpdatePbvOption(ctx, 1, id, dataStr); err != nil {
+				t.Errorf("UpdatePbvOption(%s) error = %v", dataStr, err)
+			}
+		})
+	}
+}
+
+func TestPbvOptionParts(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    dbmodel.DataJson
+		want    []*pbvOptionPart
+		wantErr error
+	}{
+		{"no parts", dbmodel.DataJson{"vehicle": "PV5"}, []*pbvOptionPart{}, nil},
+		{
+			"default amount",
+			dbmodel.DataJson{"parts": []interface{}{
+				map[string]interface{}{"slot": "roof", "product_id": 3.0},
+				map[string]interface{}{"slot": "seat", "product_id": 4.0, "amount": 2.0},
+			}},
+			[]*pbvOptionPart{{Slot: "roof", ProductId: 3, Amount: 1}, {Slot: "seat", ProductId: 4, Amount: 2}},
+			nil,
+		},
+		{
+			"product id overflows",
+			dbmodel.DataJson{"parts": []interface{}{map[string]interface{}{"slot": "roof", "product_id": 1e300}}},
+			nil,
+			ErrInvalidPbvOption,
+		},
+		{
+			"product id is not integer",
+			dbmodel.DataJson{"parts": []interface{}{map[string]interface{}{"slot": "roof", "product_id": 1.5}}},
+			nil,
+			ErrInvalidPbvOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pbvOptionParts(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("pbvOptionParts() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pbvOptionParts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,47 +3,147 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/JongGeonClass/JGC-API/database"
 	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/media"
+	"github.com/JongGeonClass/JGC-API/metrics"
+	"github.com/JongGeonClass/JGC-API/schema"
+	"github.com/JongGeonClass/JGC-API/tracing"
+	"github.com/JongGeonClass/JGC-API/util"
 )
 
 // Product Usecase의 인터페이스입니다.
 type ProductUsecase interface {
-	GetProduct(ctx context.Context, productId int64) (*dbmodel.PublicProduct, error)
-	GetProducts(ctx context.Context, page, pagesize, categoryId int64) ([]*dbmodel.PublicProduct, int64, error)
-	GetCartProducts(ctx context.Context, userId int64) ([]*dbmodel.PublicCart, error)
-	AddToCart(ctx context.Context, userId, productId, amount int64) error
-	UpdateCartAmount(ctx context.Context, userId, productId, amount int64) error
-	DeleteFromCart(ctx context.Context, userId, productId int64) error
+	GetProduct(ctx context.Context, productId, userId int64) (*dbmodel.PublicProduct, error)
+	GetProducts(ctx context.Context, page, pagesize, categoryId, vehicleId int64, keyword string, userId int64) ([]*dbmodel.PublicProduct, int64, error)
+	GetCartProducts(ctx context.Context, userId int64) (*dbmodel.CartSummary, error)
+	AddToCart(ctx context.Context, userId, productId, skuId, amount int64) error
+	UpdateCartAmount(ctx context.Context, userId, productId, skuId, amount int64) error
+	DeleteFromCart(ctx context.Context, userId, productId, skuId int64) error
+	DeleteSelectedFromCart(ctx context.Context, userId int64, items []*dbmodel.CartItem) error
+	UpdateCartAmounts(ctx context.Context, userId int64, amounts []*dbmodel.CartAmount) error
+	ClearCart(ctx context.Context, userId int64) error
+	GetGuestCartProducts(ctx context.Context, guestId string) (*dbmodel.CartSummary, error)
+	AddToGuestCart(ctx context.Context, guestId string, productId, skuId, amount int64) error
+	UpdateGuestCartAmount(ctx context.Context, guestId string, productId, skuId, amount int64) error
+	DeleteFromGuestCart(ctx context.Context, guestId string, productId, skuId int64) error
+	DeleteSelectedFromGuestCart(ctx context.Context, guestId string, items []*dbmodel.CartItem) error
+	UpdateGuestCartAmounts(ctx context.Context, guestId string, amounts []*dbmodel.CartAmount) error
+	ClearGuestCart(ctx context.Context, guestId string) error
 	AddReview(ctx context.Context, userId, productId, score, parentReviewId int64, content *string) (int64, error)
 	GetReviews(ctx context.Context, productId int64) ([]*dbmodel.PublicReview, error)
+	GetReviewThreads(ctx context.Context, productId int64) ([]*dbmodel.PublicReviewThread, error)
+	GetProductStatistics(ctx context.Context, productId int64) (*dbmodel.PublicProductStatistics, error)
 	GetCategories(ctx context.Context) ([]*dbmodel.Category, error)
-	AddPbvOption(ctx context.Context, userId int64, dataStr string) (int64, error)
-	GetPbvOption(ctx context.Context, userId int64) (string, error)
-	UpdatePbvOption(ctx context.Context, userId int64, dataStr string) (int64, error)
-	DeletePbvOption(ctx context.Context, userId int64) (int64, error)
+	AddPbvOption(ctx context.Context, userId int64, name, dataStr string) (int64, error)
+	GetPbvOptions(ctx context.Context, userId int64) ([]*dbmodel.PublicPbvOption, error)
+	GetPbvOption(ctx context.Context, userId, optionId int64) (*dbmodel.PublicPbvOption, string, error)
+	UpdatePbvOption(ctx context.Context, userId, optionId int64, dataStr string) (int64, error)
+	RenamePbvOption(ctx context.Context, userId, optionId int64, name string) error
+	DuplicatePbvOption(ctx context.Context, userId, optionId int64, name string) (int64, error)
+	DeletePbvOption(ctx context.Context, userId, optionId int64) error
+	GetPbvOptionVersions(ctx context.Context, userId, optionId int64) ([]*dbmodel.PublicPbvOptionVersion, error)
+	GetPbvOptionVersion(ctx context.Context, userId, optionId, version int64) (string, error)
+	RestorePbvOption(ctx context.Context, userId, optionId, version int64) (int64, error)
+	GetPbvQuote(ctx context.Context, userId, optionId int64) (*dbmodel.PbvQuote, error)
+	AddPbvOptionToCart(ctx context.Context, userId, optionId int64) (int64, error)
+	SharePbvOption(ctx context.Context, userId, optionId int64) (string, error)
+	UnsharePbvOption(ctx context.Context, userId, optionId int64) error
+	GetSharedPbvOption(ctx context.Context, viewerId int64, token string) (*dbmodel.PublicSharedPbvOption, string, error)
+	CloneSharedPbvOption(ctx context.Context, userId int64, token, name string) (int64, error)
 	GetBrands(ctx context.Context, userId int64) ([]*dbmodel.Brand, error)
+	GetWishlist(ctx context.Context, userId int64) ([]*dbmodel.PublicWishlist, error)
+	AddToWishlist(ctx context.Context, userId, productId int64) error
+	DeleteFromWishlist(ctx context.Context, userId, productId int64) error
+	MoveWishlistToCart(ctx context.Context, userId, productId, skuId, amount int64) error
+	AddCoupon(ctx context.Context, userId int64, coupon *dbmodel.Coupon) (int64, error)
+	AddProductDiscount(ctx context.Context, userId int64, discount *dbmodel.ProductDiscount) (int64, error)
+	ApplyCoupon(ctx context.Context, userId int64, code string) (*dbmodel.PricedCart, error)
+	Checkout(ctx context.Context, userId int64, code string) (int64, error)
+	GetOrders(ctx context.Context, userId int64) ([]*dbmodel.PublicOrder, error)
+	AddProductVariant(ctx context.Context, userId, productId int64, name string, values []string) (int64, error)
+	AddProductSku(ctx context.Context, userId, productId int64, code string, valueIds []int64, priceDelta, amount int64) (int64, error)
+	UpdateProductSku(ctx context.Context, userId, productId, skuId, priceDelta, amount int64) error
+	GetVehicleModels(ctx context.Context, maker string) ([]*dbmodel.PublicVehicleModel, error)
+	AddVehicleModel(ctx context.Context, userId int64, vehicle *dbmodel.VehicleModel) (int64, error)
+	AddProductFitment(ctx context.Context, userId, productId int64, vehicleIds []int64) error
+	DeleteProductFitment(ctx context.Context, userId, productId, vehicleId int64) error
+	GetGarage(ctx context.Context, userId int64) ([]*dbmodel.PublicUserVehicle, error)
+	AddToGarage(ctx context.Context, userId, vehicleId int64, nickname string) error
+	DeleteFromGarage(ctx context.Context, userId, vehicleId int64) error
+	UploadProductImage(ctx context.Context, userId, productId int64, contentType string, data []byte) (*dbmodel.PublicProductImage, error)
+	ReorderProductImages(ctx context.Context, userId, productId int64, imageIds []int64) error
+	DeleteProductImage(ctx context.Context, userId, productId, imageId int64) error
+	UpdateProductDescription(ctx context.Context, userId, productId int64, format, content string) (int64, error)
+	GetProductDescriptionRevisions(ctx context.Context, userId, productId int64) ([]*dbmodel.PublicProductDescriptionRevision, error)
+	GetProductDescriptionRevision(ctx context.Context, userId, productId, revision int64) (*dbmodel.PublicProductDescription, error)
+	RestoreProductDescription(ctx context.Context, userId, productId, revision int64) (int64, error)
 }
 
+// 상품 통계의 최근 추이를 계산할 때 사용할 기간(일)입니다.
+const statisticsTrendDays = 30
+
+// 한 유저가 가질 수 있는 최대 pbv 옵션(프리셋) 개수입니다.
+const maxPbvOptionsPerUser = 20
+
+// 이름 없이 pbv 옵션을 만들 때 사용할 기본 이름입니다.
+const defaultPbvOptionName = "My PBV"
+
 // Product Usecase의 구현체입니다.
 type ProductUC struct {
 	userdb    database.UserDatabase
 	productdb database.ProductDatabase
+	storage   media.Storage
 }
 
 // 개별 상품 정보를 가져옵니다.
-func (uc *ProductUC) GetProduct(ctx context.Context, productId int64) (*dbmodel.PublicProduct, error) {
-	return uc.productdb.GetPublicProduct(ctx, productId)
+// 로그인한 유저라면 찜 여부와 내 차량 장착 여부를 함께 가져오며, 옵션과 SKU 조합표, 장착 가능한 차종, 이미지 갤러리, 상세 설명도 함께 가져옵니다.
+func (uc *ProductUC) GetProduct(ctx context.Context, productId, userId int64) (*dbmodel.PublicProduct, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetProduct", tracing.KindInternal)
+	defer span.End()
+	product, err := uc.productdb.GetPublicProduct(ctx, productId, userId)
+	if err != nil {
+		return nil, err
+	}
+	product.Variants, product.Skus, err = getProductVariantMatrix(ctx, uc.productdb, product)
+	if err != nil {
+		return nil, err
+	}
+	product.Vehicles, err = uc.productdb.GetProductVehicles(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if err := markFitsMyVehicle(ctx, uc.productdb, []*dbmodel.PublicProduct{product}, userId); err != nil {
+		return nil, err
+	}
+	product.Images, err = uc.getProductGallery(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	product.Description, err = getPublicProductDescription(ctx, uc.productdb, productId)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 
 // 상품 리스트를 가져옵니다.
-func (uc *ProductUC) GetProducts(ctx context.Context, page, pagesize, categoryId int64) ([]*dbmodel.PublicProduct, int64, error) {
-	products, err := uc.productdb.GetProducts(ctx, page, pagesize, categoryId)
+// 로그인한 유저라면 찜 여부와 내 차량 장착 여부를 함께 가져옵니다.
+// vehicleId가 0이 아니라면 해당 차종에 장착할 수 있는 상품만 가져옵니다.
+// keyword가 비어있지 않다면 이름이나 상세 설명에 검색어가 들어간 상품만 가져옵니다.
+func (uc *ProductUC) GetProducts(ctx context.Context, page, pagesize, categoryId, vehicleId int64, keyword string, userId int64) ([]*dbmodel.PublicProduct, int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetProducts", tracing.KindInternal)
+	defer span.End()
+	products, err := uc.productdb.GetProducts(ctx, page, pagesize, categoryId, vehicleId, keyword, userId)
 	if err != nil {
 		return nil, 0, err
 	}
-	productsCount, err := uc.productdb.GetProductsCount(ctx, categoryId)
+	if err := markFitsMyVehicle(ctx, uc.productdb, products, userId); err != nil {
+		return nil, 0, err
+	}
+	productsCount, err := uc.productdb.GetProductsCount(ctx, categoryId, vehicleId, keyword)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -56,15 +156,24 @@ func (uc *ProductUC) GetProducts(ctx context.Context, page, pagesize, categoryId
 	return products, max(productsCount-1, 0) / pagesize, nil
 }
 
-// 장바구니에 담긴 상품 리스트를 가져옵니다.
-func (uc *ProductUC) GetCartProducts(ctx context.Context, userId int64) ([]*dbmodel.PublicCart, error) {
-	return uc.productdb.GetCartProducts(ctx, userId)
+// 장바구니에 담긴 상품 리스트와 금액을 가져옵니다.
+func (uc *ProductUC) GetCartProducts(ctx context.Context, userId int64) (*dbmodel.CartSummary, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetCartProducts", tracing.KindInternal)
+	defer span.End()
+	carts, err := uc.productdb.GetCartProducts(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeCart(carts), nil
 }
 
 // 장바구니에 상품을 추가합니다.
 // 존재하지 않는 상품이라면 무시합니다.
 // 장바구니에 이미 상품이 담겨있다면, 기존의 개수에 추가로 개수를 더해줍니다.
-func (uc *ProductUC) AddToCart(ctx context.Context, userId, productId, amount int64) error {
+// SKU가 있는 상품인데 SKU를 고르지 않았거나 다른 상품의 SKU를 골랐다면 ErrInvalidSku를 반환합니다.
+func (uc *ProductUC) AddToCart(ctx context.Context, userId, productId, skuId, amount int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddToCart", tracing.KindInternal)
+	defer span.End()
 	// 존재하는 상품인지 확인합니다.
 	if exists, err := uc.productdb.CheckProductExists(ctx, productId); err != nil {
 		return err
@@ -74,88 +183,133 @@ func (uc *ProductUC) AddToCart(ctx context.Context, userId, productId, amount in
 	}
 
 	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
-		// 장바구니에 이미 상품이 담겨있는지 확인합니다.
-		if isExists, err := txdb.CheckCartHasProduct(ctx, userId, productId); err != nil {
-			return err
-		} else if !isExists {
-			// 존재하지 않는다면 추가하고 종료합니다.
-			if err := txdb.AddCart(ctx, &dbmodel.Cart{
-				UserId:    userId,
-				ProductId: productId,
-				Amount:    amount,
-			}); err != nil {
-				return err
-			}
-			return nil
-		}
-		// 존재한다면, 개수를 더해줍니다.
-		cart, err := txdb.GetCartProduct(ctx, userId, productId)
+		price, _, ok, err := getCartLineStock(ctx, txdb, productId, skuId)
 		if err != nil {
 			return err
+		} else if !ok {
+			return ErrInvalidSku
 		}
-		cart.Amount += amount
-		// 업데이트 된 개수를 반영합니다.
-		return txdb.UpdateCart(ctx, cart)
+		return addCartProduct(ctx, txdb, userId, productId, skuId, amount, price)
 	})
+	if err == nil {
+		metrics.CartAdds.Inc("user")
+	}
 	return err
 }
 
+// 장바구니에 담긴 상품(SKU)의 개수를 가져옵니다.
+// 담겨있지 않은 상품이라면 0을 반환합니다.
+func getCartAmount(ctx context.Context, txdb database.ProductDatabase, userId, productId, skuId int64) (int64, error) {
+	if isExists, err := txdb.CheckCartHasProduct(ctx, userId, productId, skuId); err != nil {
+		return 0, err
+	} else if !isExists {
+		return 0, nil
+	}
+	cart, err := txdb.GetCartProduct(ctx, userId, productId, skuId)
+	if err != nil {
+		return 0, err
+	}
+	return cart.Amount, nil
+}
+
+// 트랜잭션 안에서 장바구니에 상품(SKU)을 추가합니다.
+// 장바구니에 이미 상품이 담겨있다면, 기존의 개수에 추가로 개수를 더해줍니다.
+// 담은 가격은 price로 기록하며, 보통 getCartLineStock으로 가져온 현재 가격입니다.
+func addCartProduct(ctx context.Context, txdb database.ProductDatabase, userId, productId, skuId, amount, price int64) error {
+	// 장바구니에 이미 상품이 담겨있는지 확인합니다.
+	if isExists, err := txdb.CheckCartHasProduct(ctx, userId, productId, skuId); err != nil {
+		return err
+	} else if !isExists {
+		// 존재하지 않는다면 추가하고 종료합니다.
+		return txdb.AddCart(ctx, &dbmodel.Cart{
+			UserId:     userId,
+			ProductId:  productId,
+			SkuId:      skuId,
+			Amount:     amount,
+			PriceAtAdd: price,
+		})
+	}
+	// 존재한다면, 개수를 더해줍니다.
+	cart, err := txdb.GetCartProduct(ctx, userId, productId, skuId)
+	if err != nil {
+		return err
+	}
+	cart.Amount += amount
+	cart.PriceAtAdd = price
+	// 업데이트 된 개수를 반영합니다.
+	return txdb.UpdateCart(ctx, cart)
+}
+
 // 장바구니에 담긴 상품의 개수를 변경합니다.
-func (uc *ProductUC) UpdateCartAmount(ctx context.Context, userId, productId, amount int64) error {
+// 개수를 바꾼 사용자는 현재 가격을 확인한 것으로 보고, 담은 가격을 현재 가격으로 갱신합니다.
+func (uc *ProductUC) UpdateCartAmount(ctx context.Context, userId, productId, skuId, amount int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.UpdateCartAmount", tracing.KindInternal)
+	defer span.End()
 	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
-		// 장바구니에 이미 상품이 담겨있는지 확인합니다.
-		if isExists, err := txdb.CheckCartHasProduct(ctx, userId, productId); err != nil {
-			return err
-		} else if !isExists {
-			// 존재하지 않는다면 무시합니다.
-			return nil
-		}
-		// 존재한다면, 개수를 변경합니다..
-		cart := &dbmodel.Cart{
-			UserId:    userId,
-			ProductId: productId,
-			Amount:    amount,
-		}
-		// 업데이트 된 개수를 반영합니다.
-		return txdb.UpdateCart(ctx, cart)
+		return updateCartAmount(ctx, txdb, userId, productId, skuId, amount)
 	})
 	return err
 }
 
-// 장바구니에서 상품을 삭제합니다.
+// 트랜잭션 안에서 장바구니에 담긴 상품(SKU)의 개수를 변경합니다.
+// 장바구니에 담겨있지 않은 상품이라면 무시합니다.
+func updateCartAmount(ctx context.Context, txdb database.ProductDatabase, userId, productId, skuId, amount int64) error {
+	// 장바구니에 이미 상품이 담겨있는지 확인합니다.
+	if isExists, err := txdb.CheckCartHasProduct(ctx, userId, productId, skuId); err != nil {
+		return err
+	} else if !isExists {
+		// 존재하지 않는다면 무시합니다.
+		return nil
+	}
+	cart, err := txdb.GetCartProduct(ctx, userId, productId, skuId)
+	if err != nil {
+		return err
+	}
+	price, _, _, err := getCartLineStock(ctx, txdb, productId, skuId)
+	if err != nil {
+		return err
+	}
+	// 존재한다면, 개수를 변경합니다.
+	cart.Amount = amount
+	cart.PriceAtAdd = price
+	// 업데이트 된 개수를 반영합니다.
+	return txdb.UpdateCart(ctx, cart)
+}
+
+// 장바구니에서 상품(SKU)을 삭제합니다.
 // 만약 장바구니에 상품이 없다면 무시합니다.
-func (uc *ProductUC) DeleteFromCart(ctx context.Context, userId, productId int64) error {
-	if exists, err := uc.productdb.CheckCartHasProduct(ctx, userId, productId); err != nil {
+func (uc *ProductUC) DeleteFromCart(ctx context.Context, userId, productId, skuId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteFromCart", tracing.KindInternal)
+	defer span.End()
+	if exists, err := uc.productdb.CheckCartHasProduct(ctx, userId, productId, skuId); err != nil {
 		return err
 	} else if exists {
-		return uc.productdb.DeleteCartProduct(ctx, userId, productId)
+		return uc.productdb.DeleteCartProduct(ctx, userId, productId, skuId)
 	}
 	return nil
 }
 
 // 상품에 리뷰를 작성합니다.
 // 이후 작성한 리뷰 아이디를 반환합니다.
-// 존재하지 않는 상품이라면 -1을 반환합니다.
-// 대댓글을 달려고 할 때 존재하지 않는 부모 리뷰라면, -2를 반환합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound을 반환합니다.
+// 대댓글을 달려고 할 때 존재하지 않는 부모 리뷰라면, ErrReviewNotFound을 반환합니다.
 func (uc *ProductUC) AddReview(ctx context.Context, userId, productId, score, parentReviewId int64, content *string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddReview", tracing.KindInternal)
+	defer span.End()
 	res := int64(0)
 	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
 		// 존재하는 상품인지 확인합니다.
 		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
 			return err
 		} else if !exists {
-			// 존재하지 않는 상품이라면 -1을 반환합니다.
-			res = -1
-			return nil
+			return ErrProductNotFound
 		}
 		// 부모 리뷰 아이디가 있다면 같은 상품 내에 존재하는 리뷰인지 확인합니다.
 		if parentReviewId != 0 {
 			if exists, err := txdb.CheckReviewExists(ctx, parentReviewId); err != nil {
 				return err
 			} else if !exists {
-				// 존재하지 않는 리뷰라면 -2를 반환합니다.
-				res = -2
-				return nil
+				return ErrReviewNotFound
 			}
 		}
 
@@ -173,6 +327,16 @@ func (uc *ProductUC) AddReview(ctx context.Context, userId, productId, score, pa
 		}
 
 		// 이후 통계 테이블을 업데이트합니다.
+		// 통계 정보가 없는 상품이라면 리뷰를 롤백하지 않고 새로 만들어줍니다.
+		if exists, err := txdb.CheckProductStatisticsExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return txdb.AddProductStatistics(ctx, &dbmodel.ProductStatistics{
+				ProductId:      productId,
+				ReviewCount:    1,
+				SumReviewScore: score,
+			})
+		}
 		statistics, err := txdb.GetProductStatistics(ctx, productId)
 		if err != nil {
 			return err
@@ -181,113 +345,762 @@ func (uc *ProductUC) AddReview(ctx context.Context, userId, productId, score, pa
 		statistics.SumReviewScore += score
 		return txdb.UpdateProductStatistics(ctx, statistics)
 	})
+	if err == nil {
+		metrics.Reviews.Inc()
+	}
 	return res, err
 }
 
 // 리뷰 리스트를 가져옵니다.
 func (uc *ProductUC) GetReviews(ctx context.Context, productId int64) ([]*dbmodel.PublicReview, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetReviews", tracing.KindInternal)
+	defer span.End()
 	return uc.productdb.GetReviewList(ctx, productId)
 }
 
+// 답글을 원본 리뷰 밑에 묶은 리뷰 리스트를 가져옵니다.
+// 원본 리뷰의 순서는 GetReviews와 같으며, 원본 리뷰가 지워진 답글은 포함하지 않습니다.
+func (uc *ProductUC) GetReviewThreads(ctx context.Context, productId int64) ([]*dbmodel.PublicReviewThread, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetReviewThreads", tracing.KindInternal)
+	defer span.End()
+	reviews, err := uc.productdb.GetReviewList(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	result := []*dbmodel.PublicReviewThread{}
+	threads := make(map[int64]*dbmodel.PublicReviewThread)
+	for _, v := range reviews {
+		if v.IsParent {
+			thread := &dbmodel.PublicReviewThread{PublicReview: v, Replies: []*dbmodel.PublicReview{}}
+			threads[v.Id] = thread
+			result = append(result, thread)
+		}
+	}
+	for _, v := range reviews {
+		if thread, ok := threads[v.ParentReviewId]; ok && !v.IsParent {
+			thread.Replies = append(thread.Replies, v)
+		}
+	}
+	return result, nil
+}
+
+// 리뷰 개수와 별점 합으로 평균 별점을 계산합니다.
+// 리뷰가 없다면 0을 반환합니다.
+func averageScore(count, sum int64) float64 {
+	if count <= 0 {
+		return 0
+	}
+	return float64(sum) / float64(count)
+}
+
+// 상품의 통계 정보를 가져옵니다.
+// 별점 분포(1~5점), 판매량, 최근 기간과 그 이전 기간의 리뷰 추이를 함께 반환합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound을 반환합니다.
+func (uc *ProductUC) GetProductStatistics(ctx context.Context, productId int64) (*dbmodel.PublicProductStatistics, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetProductStatistics", tracing.KindInternal)
+	defer span.End()
+	if exists, err := uc.productdb.CheckProductExists(ctx, productId); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrProductNotFound
+	}
+	// 통계 정보가 아직 만들어지지 않은 상품이라면 빈 통계로 취급합니다.
+	statistics := &dbmodel.ProductStatistics{ProductId: productId}
+	if exists, err := uc.productdb.CheckProductStatisticsExists(ctx, productId); err != nil {
+		return nil, err
+	} else if exists {
+		if statistics, err = uc.productdb.GetProductStatistics(ctx, productId); err != nil {
+			return nil, err
+		}
+	}
+	result := &dbmodel.PublicProductStatistics{
+		ProductId:     productId,
+		ReviewCount:   statistics.ReviewCount,
+		AverageScore:  averageScore(statistics.ReviewCount, statistics.SumReviewScore),
+		SoldQuantity:  statistics.SoldQuantity,
+		FavoriteCount: statistics.FavoriteCount,
+	}
+
+	// 리뷰가 없는 별점도 0개로 채워서 항상 1~5점을 모두 반환합니다.
+	histogram, err := uc.productdb.GetReviewScoreHistogram(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	result.Histogram = make([]*dbmodel.ReviewScoreCount, 5)
+	for i := range result.Histogram {
+		result.Histogram[i] = &dbmodel.ReviewScoreCount{Score: int64(i + 1)}
+	}
+	for _, v := range histogram {
+		if v.Score >= 1 && v.Score <= 5 {
+			result.Histogram[v.Score-1].Count = v.Count
+		}
+	}
+
+	// 최근 기간과 그 이전 기간의 리뷰를 비교합니다.
+	ntime := time.Now()
+	window := time.Hour * 24 * statisticsTrendDays
+	recent, err := uc.productdb.GetReviewScoreSummaryBetween(ctx, productId, ntime.Add(-window), ntime)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := uc.productdb.GetReviewScoreSummaryBetween(ctx, productId, ntime.Add(-2*window), ntime.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+	result.Trend = &dbmodel.ProductStatisticsTrend{
+		Days:                 statisticsTrendDays,
+		RecentReviewCount:    recent.Count,
+		RecentAverageScore:   averageScore(recent.Count, recent.SumScore),
+		PreviousReviewCount:  previous.Count,
+		PreviousAverageScore: averageScore(previous.Count, previous.SumScore),
+	}
+	return result, nil
+}
+
+// 트랜잭션 안에서 상품 통계를 fn으로 수정해 저장합니다.
+// 통계 정보가 없는 상품이라면 빈 통계를 새로 만든 뒤 fn을 적용합니다.
+func updateProductStatistics(ctx context.Context, txdb database.ProductDatabase, productId int64, fn func(statistics *dbmodel.ProductStatistics)) error {
+	if exists, err := txdb.CheckProductStatisticsExists(ctx, productId); err != nil {
+		return err
+	} else if !exists {
+		statistics := &dbmodel.ProductStatistics{ProductId: productId}
+		fn(statistics)
+		return txdb.AddProductStatistics(ctx, statistics)
+	}
+	statistics, err := txdb.GetProductStatistics(ctx, productId)
+	if err != nil {
+		return err
+	}
+	fn(statistics)
+	return txdb.UpdateProductStatistics(ctx, statistics)
+}
+
 // 카테고리 리스트를 가져옵니다.
 func (uc *ProductUC) GetCategories(ctx context.Context) ([]*dbmodel.Category, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetCategories", tracing.KindInternal)
+	defer span.End()
 	return uc.productdb.GetAllCategories(ctx)
 }
 
+// pbv 옵션의 데이터를 새로운 버전으로 저장합니다.
+// PBV_OPTION의 데이터를 바꾸고, 바뀐 데이터를 PBV_OPTION_VERSION에 기록합니다.
+// 데이터는 항상 최신 스키마 버전이어야 합니다.
+// 이후 새로 기록된 버전 번호를 반환합니다.
+func savePbvOptionVersion(ctx context.Context, txdb database.ProductDatabase, option *dbmodel.PbvOption, data dbmodel.DataJson) (int64, error) {
+	option.Version++
+	option.SchemaVersion = schema.PbvOptionVersion
+	option.Data = data
+	if err := txdb.UpdatePbvOption(ctx, option); err != nil {
+		return 0, err
+	}
+	if _, err := txdb.AddPbvOptionVersion(ctx, &dbmodel.PbvOptionVersion{
+		PbvOptionId:   option.Id,
+		Version:       option.Version,
+		SchemaVersion: option.SchemaVersion,
+		Data:          data,
+	}); err != nil {
+		return 0, err
+	}
+	return option.Version, nil
+}
+
+// 새로운 pbv 옵션을 만들고 첫 번째 버전을 기록합니다.
+// 데이터는 항상 최신 스키마 버전이어야 합니다.
+// 이후 생성된 옵션 아이디를 반환합니다.
+func addPbvOption(ctx context.Context, txdb database.ProductDatabase, userId int64, name string, data dbmodel.DataJson) (int64, error) {
+	option := &dbmodel.PbvOption{
+		UserId:        userId,
+		Name:          name,
+		Version:       1,
+		SchemaVersion: schema.PbvOptionVersion,
+		Data:          data,
+	}
+	id, err := txdb.AddPbvOption(ctx, option)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := txdb.AddPbvOptionVersion(ctx, &dbmodel.PbvOptionVersion{
+		PbvOptionId:   id,
+		Version:       option.Version,
+		SchemaVersion: option.SchemaVersion,
+		Data:          data,
+	}); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// 옵션 아이디가 0이라면 유저가 가장 최근에 수정한 옵션 아이디로 바꿔줍니다.
+// 옵션이 하나뿐이던 이전 클라이언트를 위한 처리입니다.
+func resolvePbvOptionId(ctx context.Context, txdb database.ProductDatabase, userId, optionId int64) (int64, error) {
+	if optionId != 0 {
+		return optionId, nil
+	}
+	return txdb.GetLatestPbvOptionId(ctx, userId)
+}
+
 // 새로운 pbv 옵션을 추가합니다.
 // 이후 생성된 pvb 옵션 id를 반환합니다.
-// 이미 옵션을 가지고 있는 유저라면 -1을 반환합니다.
-func (uc *ProductUC) AddPbvOption(ctx context.Context, userId int64, dataStr string) (int64, error) {
-	dest := dbmodel.DataJson{}
-	if err := json.Unmarshal([]byte(dataStr), &dest); err != nil {
+// 이미 최대 개수만큼 옵션을 가지고 있는 유저라면 ErrPbvOptionLimitReached을 반환합니다.
+// 데이터가 크기 제한을 넘는다면 ErrPbvOptionTooLarge를, 스키마에 맞지 않는다면 ErrInvalidPbvOption을 반환합니다.
+// 부품으로 존재하지 않는 상품을 고른 경우에도 ErrInvalidPbvOption을 반환합니다.
+func (uc *ProductUC) AddPbvOption(ctx context.Context, userId int64, name, dataStr string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddPbvOption", tracing.KindInternal)
+	defer span.End()
+	dest, err := parsePbvOptionData(dataStr)
+	if err != nil {
 		return 0, err
 	}
+	if name == "" {
+		name = defaultPbvOptionName
+	}
 	res := int64(0)
-	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
-		// 이미 옵션을 가지고 있는 유저라면 -1을 반환합니다.
-		if exists, err := txdb.CheckPbvOptionExists(ctx, userId); err != nil {
+	err = uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 이미 최대 개수만큼 옵션을 가지고 있는 유저라면 ErrPbvOptionLimitReached을 반환합니다.
+		if count, err := txdb.GetPbvOptionsCount(ctx, userId); err != nil {
+			return err
+		} else if count >= maxPbvOptionsPerUser {
+			return ErrPbvOptionLimitReached
+		}
+		// 부품으로 고른 상품이 모두 존재하는지 확인합니다.
+		if err := checkPbvOptionParts(ctx, txdb, dest); err != nil {
 			return err
-		} else if exists {
-			res = -1
-			return nil
 		}
 		// 옵션을 추가합니다.
-		if id, err := txdb.AddPbvOption(ctx, &dbmodel.PbvOption{
-			UserId: userId,
-			Data:   dest,
-		}); err != nil {
+		id, err := addPbvOption(ctx, txdb, userId, name, dest)
+		if err != nil {
 			return err
-		} else {
-			res = id
 		}
+		res = id
 		return nil
 	})
 	return res, err
 }
 
+// 유저가 가지고 있는 pbv 옵션 리스트를 가져옵니다.
+func (uc *ProductUC) GetPbvOptions(ctx context.Context, userId int64) ([]*dbmodel.PublicPbvOption, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetPbvOptions", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.GetPbvOptions(ctx, userId)
+}
+
 // pbv 옵션을 가져옵니다.
-// 존재하지 않는 옵션을 가져오려고 할 때 ""을 반환합니다.
-func (uc *ProductUC) GetPbvOption(ctx context.Context, userId int64) (string, error) {
-	if exists, err := uc.productdb.CheckPbvOptionExists(ctx, userId); err != nil {
+// 옵션 아이디가 0이라면 가장 최근에 수정한 옵션을 가져옵니다.
+// 이전 스키마 버전으로 저장된 데이터는 최신 스키마 버전으로 변환해서 반환합니다.
+// 존재하지 않는 옵션을 가져오려고 할 때 ErrPbvOptionNotFound을 반환합니다.
+func (uc *ProductUC) GetPbvOption(ctx context.Context, userId, optionId int64) (*dbmodel.PublicPbvOption, string, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetPbvOption", tracing.KindInternal)
+	defer span.End()
+	optionId, err := resolvePbvOptionId(ctx, uc.productdb, userId, optionId)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists, err := uc.productdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+		return nil, "", err
+	} else if !exists {
+		return nil, "", ErrPbvOptionNotFound
+	}
+	option, err := uc.productdb.GetPbvOption(ctx, userId, optionId)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := upgradePbvOptionData(option.Data, option.SchemaVersion)
+	if err != nil {
+		return nil, "", err
+	}
+	pbyte, err := json.Marshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	public := &dbmodel.PublicPbvOption{
+		Id:          option.Id,
+		Name:        option.Name,
+		Version:     option.Version,
+		CreatedTime: option.CreatedTime.Format(time.RFC3339Nano),
+		UpdatedTime: option.UpdatedTime.Format(time.RFC3339Nano),
+	}
+	// 공유된 옵션이라면 공유 토큰과 조회수를 함께 보여줍니다.
+	if shared, err := uc.productdb.CheckPbvShareExists(ctx, option.Id); err != nil {
+		return nil, "", err
+	} else if shared {
+		share, err := uc.productdb.GetPbvShare(ctx, option.Id)
+		if err != nil {
+			return nil, "", err
+		}
+		public.ShareToken = share.Token
+		public.ViewCount = share.ViewCount
+	}
+	return public, string(pbyte), nil
+}
+
+// pbv 옵션을 업데이트합니다.
+// 바뀐 데이터는 새로운 버전으로 기록되며, 이후 새로운 버전 번호를 반환합니다.
+// 존재하지 않는 옵션을 업데이트하려고 할 때 ErrPbvOptionNotFound을 반환합니다.
+// 데이터가 크기 제한을 넘는다면 ErrPbvOptionTooLarge를, 스키마에 맞지 않는다면 ErrInvalidPbvOption을 반환합니다.
+// 부품으로 존재하지 않는 상품을 고른 경우에도 ErrInvalidPbvOption을 반환합니다.
+func (uc *ProductUC) UpdatePbvOption(ctx context.Context, userId, optionId int64, dataStr string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.UpdatePbvOption", tracing.KindInternal)
+	defer span.End()
+	dest, err := parsePbvOptionData(dataStr)
+	if err != nil {
+		return 0, err
+	}
+	res := int64(0)
+	err = uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		optionId, err := resolvePbvOptionId(ctx, txdb, userId, optionId)
+		if err != nil {
+			return err
+		}
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionNotFound
+		}
+		// 부품으로 고른 상품이 모두 존재하는지 확인합니다.
+		if err := checkPbvOptionParts(ctx, txdb, dest); err != nil {
+			return err
+		}
+		option, err := txdb.GetPbvOption(ctx, userId, optionId)
+		if err != nil {
+			return err
+		}
+		// 옵션을 업데이트합니다.
+		version, err := savePbvOptionVersion(ctx, txdb, option, dest)
+		if err != nil {
+			return err
+		}
+		res = version
+		return nil
+	})
+	return res, err
+}
+
+// pbv 옵션의 이름을 변경합니다.
+// 존재하지 않는 옵션의 이름을 바꾸려고 할 때 ErrPbvOptionNotFound을 반환합니다.
+func (uc *ProductUC) RenamePbvOption(ctx context.Context, userId, optionId int64, name string) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.RenamePbvOption", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionNotFound
+		}
+		option, err := txdb.GetPbvOption(ctx, userId, optionId)
+		if err != nil {
+			return err
+		}
+		option.Name = name
+		return txdb.UpdatePbvOption(ctx, option)
+	})
+}
+
+// pbv 옵션을 복제합니다.
+// 복제한 옵션은 원본의 최신 데이터를 첫 번째 버전으로 가지며, 이후 생성된 옵션 아이디를 반환합니다.
+// 이름이 비어있다면 원본 이름 뒤에 " (copy)"를 붙입니다.
+// 존재하지 않는 옵션을 복제하려고 할 때 ErrPbvOptionNotFound을 반환합니다.
+// 이미 최대 개수만큼 옵션을 가지고 있는 유저라면 ErrPbvOptionLimitReached을 반환합니다.
+func (uc *ProductUC) DuplicatePbvOption(ctx context.Context, userId, optionId int64, name string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.DuplicatePbvOption", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionNotFound
+		}
+		// 이미 최대 개수만큼 옵션을 가지고 있는 유저라면 ErrPbvOptionLimitReached을 반환합니다.
+		if count, err := txdb.GetPbvOptionsCount(ctx, userId); err != nil {
+			return err
+		} else if count >= maxPbvOptionsPerUser {
+			return ErrPbvOptionLimitReached
+		}
+		option, err := txdb.GetPbvOption(ctx, userId, optionId)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = option.Name + " (copy)"
+		}
+		data, err := upgradePbvOptionData(option.Data, option.SchemaVersion)
+		if err != nil {
+			return err
+		}
+		id, err := addPbvOption(ctx, txdb, userId, name, data)
+		if err != nil {
+			return err
+		}
+		res = id
+		return nil
+	})
+	return res, err
+}
+
+// pbv 옵션을 삭제합니다.
+// 옵션 아이디가 0이라면 가장 최근에 수정한 옵션을 삭제합니다.
+// 존재하지 않는 옵션을 삭제하려고 할 때 ErrPbvOptionNotFound을 반환합니다.
+func (uc *ProductUC) DeletePbvOption(ctx context.Context, userId, optionId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeletePbvOption", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		optionId, err := resolvePbvOptionId(ctx, txdb, userId, optionId)
+		if err != nil {
+			return err
+		}
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionNotFound
+		}
+		// 공유 정보와 버전 기록을 먼저 삭제한 뒤 옵션을 삭제합니다.
+		if err := txdb.DeletePbvShare(ctx, optionId); err != nil {
+			return err
+		}
+		if err := txdb.DeletePbvOptionVersions(ctx, optionId); err != nil {
+			return err
+		}
+		return txdb.DeletePbvOption(ctx, optionId)
+	})
+}
+
+// pbv 옵션의 버전 기록을 가져옵니다.
+// 존재하지 않는 옵션이라면 ErrPbvOptionNotFound을 반환합니다.
+func (uc *ProductUC) GetPbvOptionVersions(ctx context.Context, userId, optionId int64) ([]*dbmodel.PublicPbvOptionVersion, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetPbvOptionVersions", tracing.KindInternal)
+	defer span.End()
+	if exists, err := uc.productdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrPbvOptionNotFound
+	}
+	return uc.productdb.GetPbvOptionVersions(ctx, optionId)
+}
+
+// pbv 옵션의 특정 버전 데이터를 가져옵니다.
+// 존재하지 않는 옵션이라면 ErrPbvOptionNotFound을, 존재하지 않는 버전이라면 ErrPbvOptionVersionNotFound을 반환합니다.
+func (uc *ProductUC) GetPbvOptionVersion(ctx context.Context, userId, optionId, version int64) (string, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetPbvOptionVersion", tracing.KindInternal)
+	defer span.End()
+	if exists, err := uc.productdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
 		return "", err
 	} else if !exists {
-		return "", nil
+		return "", ErrPbvOptionNotFound
 	}
-	option, err := uc.productdb.GetPbvOption(ctx, userId)
+	if exists, err := uc.productdb.CheckPbvOptionVersionExists(ctx, optionId, version); err != nil {
+		return "", err
+	} else if !exists {
+		return "", ErrPbvOptionVersionNotFound
+	}
+	optionVersion, err := uc.productdb.GetPbvOptionVersion(ctx, optionId, version)
 	if err != nil {
 		return "", err
 	}
-	pbyte, err := json.Marshal(option.Data)
+	data, err := upgradePbvOptionData(optionVersion.Data, optionVersion.SchemaVersion)
+	if err != nil {
+		return "", err
+	}
+	pbyte, err := json.Marshal(data)
 	if err != nil {
 		return "", err
 	}
 	return string(pbyte), nil
 }
 
-// pbv 옵션을 업데이트합니다.
-// 존재하지 않는 옵션을 업데이트하려고 할 때 -1을 반환합니다.
-func (uc *ProductUC) UpdatePbvOption(ctx context.Context, userId int64, dataStr string) (int64, error) {
-	dest := dbmodel.DataJson{}
-	if err := json.Unmarshal([]byte(dataStr), &dest); err != nil {
-		return 0, err
+// pbv 옵션을 이전 버전의 데이터로 되돌립니다.
+// 기존 기록을 지우지 않고, 이전 버전의 데이터를 새로운 버전으로 기록합니다.
+// 이후 새로 기록된 버전 번호를 반환합니다.
+// 존재하지 않는 옵션이라면 ErrPbvOptionNotFound을, 존재하지 않는 버전이라면 ErrPbvOptionVersionNotFound을 반환합니다.
+func (uc *ProductUC) RestorePbvOption(ctx context.Context, userId, optionId, version int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.RestorePbvOption", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionNotFound
+		}
+		// 존재하지 않는 버전이라면 ErrPbvOptionVersionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionVersionExists(ctx, optionId, version); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionVersionNotFound
+		}
+		option, err := txdb.GetPbvOption(ctx, userId, optionId)
+		if err != nil {
+			return err
+		}
+		optionVersion, err := txdb.GetPbvOptionVersion(ctx, optionId, version)
+		if err != nil {
+			return err
+		}
+		data, err := upgradePbvOptionData(optionVersion.Data, optionVersion.SchemaVersion)
+		if err != nil {
+			return err
+		}
+		newVersion, err := savePbvOptionVersion(ctx, txdb, option, data)
+		if err != nil {
+			return err
+		}
+		res = newVersion
+		return nil
+	})
+	return res, err
+}
+
+// pbv 옵션의 현재 견적을 가져옵니다.
+// 옵션 아이디가 0이라면 가장 최근에 수정한 옵션의 견적을 가져옵니다.
+// 존재하지 않는 옵션이라면 ErrPbvOptionNotFound을 반환합니다.
+func (uc *ProductUC) GetPbvQuote(ctx context.Context, userId, optionId int64) (*dbmodel.PbvQuote, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetPbvQuote", tracing.KindInternal)
+	defer span.End()
+	optionId, err := resolvePbvOptionId(ctx, uc.productdb, userId, optionId)
+	if err != nil {
+		return nil, err
+	}
+	if exists, err := uc.productdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, ErrPbvOptionNotFound
+	}
+	option, err := uc.productdb.GetPbvOption(ctx, userId, optionId)
+	if err != nil {
+		return nil, err
 	}
+	return quotePbvOption(ctx, uc.productdb, option)
+}
+
+// pbv 옵션의 부품들을 한 번에 장바구니에 담습니다.
+// 모든 부품을 담거나, 하나도 담지 않습니다.
+// 이후 장바구니에 담은 상품 종류의 개수를 반환합니다.
+// 존재하지 않는 옵션이라면 ErrPbvOptionNotFound을 반환합니다.
+// 사라진 상품이 있거나, 이미 담긴 개수를 합쳐 재고가 모자란 상품이 있다면 ErrPbvPartsUnavailable를 반환합니다.
+func (uc *ProductUC) AddPbvOptionToCart(ctx context.Context, userId, optionId int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddPbvOptionToCart", tracing.KindInternal)
+	defer span.End()
 	res := int64(0)
 	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
-		// 옵션을 가지고 있지 않은 유저라면 -1을 반환합니다.
-		if exists, err := txdb.CheckPbvOptionExists(ctx, userId); err != nil {
+		optionId, err := resolvePbvOptionId(ctx, txdb, userId, optionId)
+		if err != nil {
+			return err
+		}
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionNotFound
+		}
+		option, err := txdb.GetPbvOption(ctx, userId, optionId)
+		if err != nil {
+			return err
+		}
+		quote, err := quotePbvOption(ctx, txdb, option)
+		if err != nil {
+			return err
+		}
+		if !quote.Purchasable {
+			return ErrPbvPartsUnavailable
+		}
+		// 같은 상품은 합쳐서 담습니다.
+		productIds := []int64{}
+		amounts := map[int64]int64{}
+		stocks := map[int64]int64{}
+		prices := map[int64]int64{}
+		for _, v := range quote.Lines {
+			if _, ok := amounts[v.ProductId]; !ok {
+				productIds = append(productIds, v.ProductId)
+			}
+			amounts[v.ProductId] += v.Amount
+			stocks[v.ProductId] = v.Stock
+			prices[v.ProductId] = v.Price
+		}
+		// 이미 담겨있는 개수까지 합쳐 재고를 넘지 않는지 확인합니다.
+		for _, productId := range productIds {
+			cartAmount, err := getCartAmount(ctx, txdb, userId, productId, 0)
+			if err != nil {
+				return err
+			}
+			if cartAmount+amounts[productId] > stocks[productId] {
+				return ErrPbvPartsUnavailable
+			}
+		}
+		for _, productId := range productIds {
+			if err := addCartProduct(ctx, txdb, userId, productId, 0, amounts[productId], prices[productId]); err != nil {
+				return err
+			}
+		}
+		res = int64(len(productIds))
+		return nil
+	})
+	return res, err
+}
+
+// pbv 옵션을 공유 링크로 공개하고 공유 토큰을 반환합니다.
+// 이미 공유된 옵션이라면 기존 토큰을 그대로 반환합니다.
+// 존재하지 않는 옵션이라면 ErrPbvOptionNotFound을 반환합니다.
+func (uc *ProductUC) SharePbvOption(ctx context.Context, userId, optionId int64) (string, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.SharePbvOption", tracing.KindInternal)
+	defer span.End()
+	res := ""
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
 			return err
 		} else if !exists {
-			res = -1
+			return ErrPbvOptionNotFound
+		}
+		// 이미 공유된 옵션이라면 기존 토큰을 반환합니다.
+		if shared, err := txdb.CheckPbvShareExists(ctx, optionId); err != nil {
+			return err
+		} else if shared {
+			share, err := txdb.GetPbvShare(ctx, optionId)
+			if err != nil {
+				return err
+			}
+			res = share.Token
 			return nil
 		}
-		// 옵션을 업데이트합니다.
-		if err := txdb.UpdatePbvOption(ctx, &dbmodel.PbvOption{
-			UserId: userId,
-			Data:   dest,
+		token := util.NewUuid()
+		if _, err := txdb.AddPbvShare(ctx, &dbmodel.PbvShare{
+			PbvOptionId: optionId,
+			Token:       token,
 		}); err != nil {
 			return err
 		}
+		res = token
 		return nil
 	})
 	return res, err
 }
 
-// pbv 옵션을 삭제합니다.
-// 존재하지 않는 옵션을 삭제하려고 할 때 -1을 반환합니다.
-func (uc *ProductUC) DeletePbvOption(ctx context.Context, userId int64) (int64, error) {
+// pbv 옵션의 공유를 취소합니다.
+// 취소한 뒤에는 기존 공유 링크로 옵션을 볼 수 없고, 다시 공유하면 새로운 토큰이 발급됩니다.
+// 존재하지 않는 옵션이라면 ErrPbvOptionNotFound을 반환합니다.
+// 공유되지 않은 옵션이라면 ErrPbvOptionNotShared을 반환합니다.
+func (uc *ProductUC) UnsharePbvOption(ctx context.Context, userId, optionId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.UnsharePbvOption", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 옵션을 가지고 있지 않은 유저라면 ErrPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvOptionExists(ctx, userId, optionId); err != nil {
+			return err
+		} else if !exists {
+			return ErrPbvOptionNotFound
+		}
+		// 공유되지 않은 옵션이라면 ErrPbvOptionNotShared을 반환합니다.
+		if shared, err := txdb.CheckPbvShareExists(ctx, optionId); err != nil {
+			return err
+		} else if !shared {
+			return ErrPbvOptionNotShared
+		}
+		return txdb.DeletePbvShare(ctx, optionId)
+	})
+}
+
+// 공유 토큰으로 공유된 pbv 옵션을 가져옵니다.
+// 옵션 주인이 아닌 사람이 볼 때만 조회수를 올립니다.
+// 이전 스키마 버전으로 저장된 데이터는 최신 스키마 버전으로 변환해서 반환합니다.
+// 존재하지 않거나 공유가 취소된 토큰이라면 ErrSharedPbvOptionNotFound을 반환합니다.
+func (uc *ProductUC) GetSharedPbvOption(ctx context.Context, viewerId int64, token string) (*dbmodel.PublicSharedPbvOption, string, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetSharedPbvOption", tracing.KindInternal)
+	defer span.End()
+	var res *dbmodel.PublicSharedPbvOption
+	var option *dbmodel.PbvOption
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckPbvShareTokenExists(ctx, token); err != nil {
+			return err
+		} else if !exists {
+			return ErrSharedPbvOptionNotFound
+		}
+		share, err := txdb.GetPbvShareByToken(ctx, token)
+		if err != nil {
+			return err
+		}
+		option, err = txdb.GetPbvOptionById(ctx, share.PbvOptionId)
+		if err != nil {
+			return err
+		}
+		if option.UserId != viewerId {
+			if err := txdb.IncreasePbvShareViewCount(ctx, token); err != nil {
+				return err
+			}
+			share.ViewCount++
+		}
+		res = &dbmodel.PublicSharedPbvOption{
+			Name:        option.Name,
+			Version:     option.Version,
+			ViewCount:   share.ViewCount,
+			UpdatedTime: option.UpdatedTime.Format(time.RFC3339Nano),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	owner, err := uc.userdb.GetUserById(ctx, option.UserId)
+	if err != nil {
+		return nil, "", err
+	}
+	res.OwnerNickname = owner.Nickname
+	data, err := upgradePbvOptionData(option.Data, option.SchemaVersion)
+	if err != nil {
+		return nil, "", err
+	}
+	pbyte, err := json.Marshal(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return res, string(pbyte), nil
+}
+
+// 공유된 pbv 옵션을 내 옵션으로 복사합니다.
+// 이후 생성된 옵션 아이디를 반환합니다.
+// 이름이 없다면 공유된 옵션의 이름을 그대로 사용합니다.
+// 존재하지 않거나 공유가 취소된 토큰이라면 ErrSharedPbvOptionNotFound을 반환합니다.
+// 이미 최대 개수만큼 옵션을 가지고 있는 유저라면 ErrPbvOptionLimitReached을 반환합니다.
+func (uc *ProductUC) CloneSharedPbvOption(ctx context.Context, userId int64, token, name string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.CloneSharedPbvOption", tracing.KindInternal)
+	defer span.End()
 	res := int64(0)
 	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
-		// 옵션을 가지고 있지 않은 유저라면 -1을 반환합니다.
-		if exists, err := txdb.CheckPbvOptionExists(ctx, userId); err != nil {
+		// 존재하지 않는 토큰이라면 ErrSharedPbvOptionNotFound을 반환합니다.
+		if exists, err := txdb.CheckPbvShareTokenExists(ctx, token); err != nil {
 			return err
 		} else if !exists {
-			res = -1
-			return nil
+			return ErrSharedPbvOptionNotFound
 		}
-		// 옵션을 삭제합니다.
-		if err := txdb.DeletePbvOption(ctx, userId); err != nil {
+		// 이미 최대 개수만큼 옵션을 가지고 있는 유저라면 ErrPbvOptionLimitReached을 반환합니다.
+		if count, err := txdb.GetPbvOptionsCount(ctx, userId); err != nil {
+			return err
+		} else if count >= maxPbvOptionsPerUser {
+			return ErrPbvOptionLimitReached
+		}
+		share, err := txdb.GetPbvShareByToken(ctx, token)
+		if err != nil {
+			return err
+		}
+		option, err := txdb.GetPbvOptionById(ctx, share.PbvOptionId)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = option.Name
+		}
+		data, err := upgradePbvOptionData(option.Data, option.SchemaVersion)
+		if err != nil {
+			return err
+		}
+		id, err := addPbvOption(ctx, txdb, userId, name, data)
+		if err != nil {
 			return err
 		}
+		res = id
 		return nil
 	})
 	return res, err
@@ -295,6 +1108,8 @@ func (uc *ProductUC) DeletePbvOption(ctx context.Context, userId int64) (int64,
 
 // 유저가 운영하고 있는 브랜드 목록을 반환합니다.
 func (uc *ProductUC) GetBrands(ctx context.Context, userId int64) ([]*dbmodel.Brand, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetBrands", tracing.KindInternal)
+	defer span.End()
 	return uc.productdb.GetBrandsByUser(ctx, userId)
 }
 
@@ -302,6 +1117,7 @@ func (uc *ProductUC) GetBrands(ctx context.Context, userId int64) ([]*dbmodel.Br
 func NewProduct(
 	userdb database.UserDatabase,
 	productdb database.ProductDatabase,
+	storage media.Storage,
 ) ProductUsecase {
-	return &ProductUC{userdb, productdb}
+	return &ProductUC{userdb, productdb, storage}
 }
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+)
+
+// pbv 옵션과 버전 기록을 메모리에 저장하는 가짜 디비입니다.
+// 상품은 productIds에 있는 아이디만 존재하는 것으로 취급합니다.
+type pbvOptionDB struct {
+	database.ProductDatabase
+	productIds []int64
+	lastId     int64
+	latest     map[int64]int64
+	options    map[int64]*dbmodel.PbvOption
+	versions   map[int64][]*dbmodel.PbvOptionVersion
+}
+
+func newPbvOptionDB(productIds ...int64) *pbvOptionDB {
+	return &pbvOptionDB{
+		productIds: productIds,
+		latest:     map[int64]int64{},
+		options:    map[int64]*dbmodel.PbvOption{},
+		versions:   map[int64][]*dbmodel.PbvOptionVersion{},
+	}
+}
+
+func (d *pbvOptionDB) ExecTx(ctx context.Context, fn func(txdb database.ProductDatabase) error) error {
+	return fn(d)
+}
+
+func (d *pbvOptionDB) GetProductsByIds(ctx context.Context, productIds []int64) ([]*dbmodel.Product, error) {
+	result := []*dbmodel.Product{}
+	for _, id := range productIds {
+		for _, v := range d.productIds {
+			if id == v {
+				result = append(result, &dbmodel.Product{Id: id})
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (d *pbvOptionDB) AddPbvOption(ctx context.Context, option *dbmodel.PbvOption) (int64, error) {
+	d.lastId++
+	saved := *option
+	saved.Id = d.lastId
+	d.options[saved.Id] = &saved
+	d.latest[saved.UserId] = saved.Id
+	return saved.Id, nil
+}
+
+func (d *pbvOptionDB) CheckPbvOptionExists(ctx context.Context, userId, optionId int64) (bool, error) {
+	option, ok := d.options[optionId]
+	return ok && option.UserId == userId, nil
+}
+
+func (d *pbvOptionDB) GetPbvOptionsCount(ctx context.Context, userId int64) (int64, error) {
+	count := int64(0)
+	for _, v := range d.options {
+		if v.UserId == userId {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (d *pbvOptionDB) GetLatestPbvOptionId(ctx context.Context, userId int64) (int64, error) {
+	return d.latest[userId], nil
+}
+
+func (d *pbvOptionDB) GetPbvOption(ctx context.Context, userId, optionId int64) (*dbmodel.PbvOption, error) {
+	option := *d.options[optionId]
+	return &option, nil
+}
+
+func (d *pbvOptionDB) UpdatePbvOption(ctx context.Context, option *dbmodel.PbvOption) error {
+	saved := *option
+	d.options[saved.Id] = &saved
+	d.latest[saved.UserId] = saved.Id
+	return nil
+}
+
+func (d *pbvOptionDB) DeletePbvOption(ctx context.Context, optionId int64) error {
+	delete(d.options, optionId)
+	return nil
+}
+
+func (d *pbvOptionDB) AddPbvOptionVersion(ctx context.Context, version *dbmodel.PbvOptionVersion) (int64, error) {
+	saved := *version
+	d.versions[saved.PbvOptionId] = append(d.versions[saved.PbvOptionId], &saved)
+	return int64(len(d.versions[saved.PbvOptionId])), nil
+}
+
+func (d *pbvOptionDB) CheckPbvOptionVersionExists(ctx context.Context, optionId, version int64) (bool, error) {
+	for _, v := range d.versions[optionId] {
+		if v.Version == version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (d *pbvOptionDB) GetPbvOptionVersion(ctx context.Context, optionId, version int64) (*dbmodel.PbvOptionVersion, error) {
+	for _, v := range d.versions[optionId] {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, errors.New("no version")
+}
+
+func (d *pbvOptionDB) GetPbvOptionVersions(ctx context.Context, optionId int64) ([]*dbmodel.PublicPbvOptionVersion, error) {
+	result := []*dbmodel.PublicPbvOptionVersion{}
+	for i := len(d.versions[optionId]) - 1; i >= 0; i-- {
+		result = append(result, &dbmodel.PublicPbvOptionVersion{Version: d.versions[optionId][i].Version})
+	}
+	return result, nil
+}
+
+func (d *pbvOptionDB) DeletePbvOptionVersions(ctx context.Context, optionId int64) error {
+	delete(d.versions, optionId)
+	return nil
+}
+
+func (d *pbvOptionDB) CheckPbvShareExists(ctx context.Context, optionId int64) (bool, error) {
+	return false, nil
+}
+
+func (d *pbvOptionDB) DeletePbvShare(ctx context.Context, optionId int64) error {
+	return nil
+}
+
+func newPbvOptionUC(db *pbvOptionDB) *ProductUC {
+	return NewProduct(nil, db, nil).(*ProductUC)
+}
+
+func TestAddPbvOption(t *testing.T) {
+	ctx := context.Background()
+	db := newPbvOptionDB(1)
+	uc := newPbvOptionUC(db)
+
+	tests := []struct {
+		name     string
+		userId   int64
+		preset   string
+		data     string
+		wantName string
+		wantErr  error
+	}{
+		{"default name", 1, "", `{"vehicle": "PV5", "parts": []}`, defaultPbvOptionName, nil},
+		{"named", 1, "camping", `{"vehicle": "PV5", "parts": [{"slot": "roof", "product_id": 1}]}`, "camping", nil},
+		{"invalid data", 1, "bad", `{"parts": []}`, "", ErrInvalidPbvOption},
+		{"unknown product", 1, "bad", `{"vehicle": "PV5", "parts": [{"slot": "roof", "product_id": 2}]}`, "", ErrInvalidPbvOption},
+		{"other user", 2, "mine", `{"vehicle": "PV7", "parts": []}`, "mine", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := uc.AddPbvOption(ctx, tt.userId, tt.preset, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddPbvOption() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			option := db.options[id]
+			if option.Name != tt.wantName || option.UserId != tt.userId || option.Version != 1 {
+				t.Errorf("AddPbvOption() saved %+v, want name %q, user %d, version 1", option, tt.wantName, tt.userId)
+			}
+			if len(db.versions[id]) != 1 {
+				t.Errorf("AddPbvOption() versions = %d, want 1", len(db.versions[id]))
+			}
+		})
+	}
+}
+
+func TestAddPbvOptionLimit(t *testing.T) {
+	ctx := context.Background()
+	uc := newPbvOptionUC(newPbvOptionDB())
+	for i := 0; i < maxPbvOptionsPerUser; i++ {
+		if _, err := uc.AddPbvOption(ctx, 1, "", `{"vehicle": "PV5", "parts": []}`); err != nil {
+			t.Fatalf("AddPbvOption() #%d error = %v", i+1, err)
+		}
+	}
+	if _, err := uc.AddPbvOption(ctx, 1, "", `{"vehicle": "PV5", "parts": []}`); !errors.Is(err, ErrPbvOptionLimitReached) {
+		t.Errorf("AddPbvOption() over limit error = %v, want %v", err, ErrPbvOptionLimitReached)
+	}
+	if _, err := uc.DuplicatePbvOption(ctx, 1, 1, ""); !errors.Is(err, ErrPbvOptionLimitReached) {
+		t.Errorf("DuplicatePbvOption() over limit error = %v, want %v", err, ErrPbvOptionLimitReached)
+	}
+}
+
+func TestPbvOptionVersions(t *testing.T) {
+	ctx := context.Background()
+	db := newPbvOptionDB()
+	uc := newPbvOptionUC(db)
+	id, err := uc.AddPbvOption(ctx, 1, "camping", `{"vehicle": "PV5", "parts": []}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{`{"vehicle": "PV5", "color": "red", "parts": []}`, `{"vehicle": "PV7", "parts": []}`} {
+		if _, err := uc.UpdatePbvOption(ctx, 1, id, v); err != nil {
+			t.Fatalf("UpdatePbvOption() error = %v", err)
+		}
+	}
+
+	versions, err := uc.GetPbvOptionVersions(ctx, 1, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := []int64{versions[0].Version, versions[1].Version, versions[2].Version}; len(versions) != 3 || !reflect.DeepEqual(got, []int64{3, 2, 1}) {
+		t.Fatalf("GetPbvOptionVersions() = %v, want [3 2 1]", got)
+	}
+
+	tests := []struct {
+		name    string
+		userId  int64
+		version int64
+		want    string
+		wantErr error
+	}{
+		{"first", 1, 1, `{"parts":[],"vehicle":"PV5"}`, nil},
+		{"second", 1, 2, `{"color":"red","parts":[],"vehicle":"PV5"}`, nil},
+		{"latest", 1, 3, `{"parts":[],"vehicle":"PV7"}`, nil},
+		{"unknown version", 1, 4, "", ErrPbvOptionVersionNotFound},
+		{"other user", 2, 1, "", ErrPbvOptionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.GetPbvOptionVersion(ctx, tt.userId, id, tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetPbvOptionVersion() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetPbvOptionVersion() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestorePbvOption(t *testing.T) {
+	ctx := context.Background()
+	db := newPbvOptionDB()
+	uc := newPbvOptionUC(db)
+	id, err := uc.AddPbvOption(ctx, 1, "camping", `{"vehicle": "PV5", "parts": []}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.UpdatePbvOption(ctx, 1, id, `{"vehicle": "PV7", "parts": []}`); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		userId      int64
+		version     int64
+		wantVersion int64
+		wantVehicle string
+		wantErr     error
+	}{
+		{"restore first", 1, 1, 3, "PV5", nil},
+		{"restore restored", 1, 3, 4, "PV5", nil},
+		{"restore second", 1, 2, 5, "PV7", nil},
+		{"unknown version", 1, 9, 0, "PV7", ErrPbvOptionVersionNotFound},
+		{"other user", 2, 1, 0, "PV7", ErrPbvOptionNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.RestorePbvOption(ctx, tt.userId, id, tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RestorePbvOption() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.wantVersion {
+				t.Errorf("RestorePbvOption() = %d, want %d", got, tt.wantVersion)
+			}
+			if vehicle := db.options[id].Data["vehicle"]; vehicle != tt.wantVehicle {
+				t.Errorf("vehicle after restore = %v, want %v", vehicle, tt.wantVehicle)
+			}
+		})
+	}
+	// 되돌리더라도 이전 기록은 지우지 않습니다.
+	if len(db.versions[id]) != 5 {
+		t.Errorf("versions = %d, want 5", len(db.versions[id]))
+	}
+}
+
+func TestRestorePbvOptionUpgradesOldVersion(t *testing.T) {
+	ctx := context.Background()
+	db := newPbvOptionDB()
+	uc := newPbvOptionUC(db)
+	id, err := uc.AddPbvOption(ctx, 1, "camping", `{"vehicle": "PV5", "parts": []}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 스키마가 없던 시절에 저장된 버전입니다.
+	db.versions[id][0].SchemaVersion = 0
+	db.versions[id][0].Data = dbmodel.DataJson{"vehicle": "PV5", "wheel": "18inch"}
+
+	if _, err := uc.RestorePbvOption(ctx, 1, id, 1); err != nil {
+		t.Fatalf("RestorePbvOption() error = %v", err)
+	}
+	want := dbmodel.DataJson{"vehicle": "PV5", "parts": []interface{}{}, "options": map[string]interface{}{"wheel": "18inch"}}
+	if got := db.options[id]; !reflect.DeepEqual(got.Data, want) || got.SchemaVersion != 2 {
+		t.Errorf("restored option = %v (schema v%d), want %v (schema v2)", got.Data, got.SchemaVersion, want)
+	}
+}
+
+func TestRenameDuplicateDeletePbvOption(t *testing.T) {
+	ctx := context.Background()
+	db := newPbvOptionDB()
+	uc := newPbvOptionUC(db)
+	id, err := uc.AddPbvOption(ctx, 1, "camping", `{"vehicle": "PV5", "parts": []}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.UpdatePbvOption(ctx, 1, id, `{"vehicle": "PV7", "parts": []}`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := uc.RenamePbvOption(ctx, 1, id, "family"); err != nil || db.options[id].Name != "family" {
+		t.Errorf("RenamePbvOption() error = %v, name = %q", err, db.options[id].Name)
+	}
+	if err := uc.RenamePbvOption(ctx, 2, id, "stolen"); !errors.Is(err, ErrPbvOptionNotFound) {
+		t.Errorf("RenamePbvOption() by other user error = %v, want %v", err, ErrPbvOptionNotFound)
+	}
+
+	// 복제한 옵션은 원본의 최신 데이터를 첫 번째 버전으로 가집니다.
+	copyId, err := uc.DuplicatePbvOption(ctx, 1, id, "")
+	if err != nil {
+		t.Fatalf("DuplicatePbvOption() error = %v", err)
+	}
+	if copied := db.options[copyId]; copied.Name != "family (copy)" || copied.Version != 1 || copied.Data["vehicle"] != "PV7" {
+		t.Errorf("DuplicatePbvOption() = %+v", copied)
+	}
+	if len(db.versions[copyId]) != 1 {
+		t.Errorf("duplicated versions = %d, want 1", len(db.versions[copyId]))
+	}
+
+	// 옵션 아이디가 0이라면 가장 최근에 수정한 옵션을 삭제합니다.
+	if err := uc.DeletePbvOption(ctx, 1, 0); err != nil {
+		t.Fatalf("DeletePbvOption() error = %v", err)
+	}
+	if _, ok := db.options[copyId]; ok {
+		t.Errorf("DeletePbvOption(0) must delete the latest option %d", copyId)
+	}
+	if _, ok := db.versions[copyId]; ok {
+		t.Errorf("DeletePbvOption(0) must delete versions of %d", copyId)
+	}
+	if _, ok := db.options[id]; !ok {
+		t.Errorf("DeletePbvOption(0) must keep option %d", id)
+	}
+	if err := uc.DeletePbvOption(ctx, 1, copyId); !errors.Is(err, ErrPbvOptionNotFound) {
+		t.Errorf("DeletePbvOption() twice error = %v, want %v", err, ErrPbvOptionNotFound)
+	}
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"os"
+	"testing"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/schema"
+)
+
+// 환경변수 파일 없이 기본값으로 설정을 불러옵니다.
+func TestMain(m *testing.M) {
+	config.Init(os.DevNull)
+	if err := schema.Init(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
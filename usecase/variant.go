@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// 유저가 상품을 관리할 수 있는지 확인합니다.
+// 관리자이거나 상품 브랜드의 주인이라면 관리할 수 있습니다. 상품은 존재해야 합니다.
+func checkProductManager(ctx context.Context, txdb database.ProductDatabase, userId, productId int64) (bool, error) {
+	if config.Get().IsAdmin(userId) {
+		return true, nil
+	}
+	product, err := txdb.GetProduct(ctx, productId)
+	if err != nil {
+		return false, err
+	}
+	return txdb.CheckBrandOwner(ctx, userId, product.BrandId)
+}
+
+// 장바구니에 담을 상품(SKU)의 현재 가격과 재고를 가져옵니다.
+// SKU가 있는 상품은 그 상품의 SKU를 골라야 하고, SKU가 없는 상품은 skuId가 0이어야 합니다.
+// 잘못 고른 SKU라면 ok로 false를 반환합니다. 상품은 존재해야 합니다.
+func getCartLineStock(ctx context.Context, txdb database.ProductDatabase, productId, skuId int64) (price, stock int64, ok bool, err error) {
+	product, err := txdb.GetProduct(ctx, productId)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if skuId == 0 {
+		count, err := txdb.GetProductSkuCount(ctx, productId)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		return product.Price, product.Amount, count == 0, nil
+	}
+	if exists, err := txdb.CheckProductSkuExists(ctx, productId, skuId); err != nil {
+		return 0, 0, false, err
+	} else if !exists {
+		return 0, 0, false, nil
+	}
+	sku, err := txdb.GetProductSku(ctx, skuId)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return product.Price + sku.PriceDelta, sku.Amount, true, nil
+}
+
+// 상품에 새로운 옵션과 고를 수 있는 값들을 추가합니다.
+// 이미 SKU가 있는 상품에 옵션을 추가하면 기존 SKU의 조합이 맞지 않게 되므로 추가할 수 없습니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied, 같은 이름의 옵션이 있다면 ErrVariantNameTaken,
+// 이미 SKU가 있는 상품이라면 ErrProductHasSkus을 반환합니다.
+// 성공하면 추가된 옵션 아이디를 반환합니다.
+func (uc *ProductUC) AddProductVariant(ctx context.Context, userId, productId int64, name string, values []string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddProductVariant", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		if exists, err := txdb.CheckProductVariantExists(ctx, productId, name); err != nil {
+			return err
+		} else if exists {
+			return ErrVariantNameTaken
+		}
+		if count, err := txdb.GetProductSkuCount(ctx, productId); err != nil {
+			return err
+		} else if count > 0 {
+			return ErrProductHasSkus
+		}
+		variants, err := txdb.GetProductVariants(ctx, productId)
+		if err != nil {
+			return err
+		}
+		variantId, err := txdb.AddProductVariant(ctx, &dbmodel.ProductVariant{
+			ProductId: productId,
+			Name:      name,
+			Position:  int64(len(variants)),
+		})
+		if err != nil {
+			return err
+		}
+		for i, v := range values {
+			if _, err := txdb.AddProductVariantValue(ctx, &dbmodel.ProductVariantValue{
+				ProductId: productId,
+				VariantId: variantId,
+				Value:     v,
+				Position:  int64(i),
+			}); err != nil {
+				return err
+			}
+		}
+		res = variantId
+		return nil
+	})
+	return res, err
+}
+
+// 옵션 값 아이디 조합을 비교할 수 있도록 정렬된 문자열로 바꿔줍니다.
+func skuValueKey(valueIds []int64) string {
+	sorted := append([]int64{}, valueIds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	keys := make([]string, 0, len(sorted))
+	for _, v := range sorted {
+		keys = append(keys, strconv.FormatInt(v, 10))
+	}
+	return strings.Join(keys, ",")
+}
+
+// 상품에 새로운 SKU를 추가합니다.
+// valueIds는 상품의 옵션마다 하나씩 고른 옵션 값 아이디여야 합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied, 이미 존재하는 SKU 코드라면 ErrSkuCodeTaken,
+// 옵션 값 조합이 올바르지 않거나 가격이 음수가 된다면 ErrInvalidSkuValues, 같은 조합의 SKU가 이미 있다면 ErrSkuCombinationTaken을 반환합니다.
+// 성공하면 추가된 SKU 아이디를 반환합니다.
+func (uc *ProductUC) AddProductSku(ctx context.Context, userId, productId int64, code string, valueIds []int64, priceDelta, amount int64) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddProductSku", tracing.KindInternal)
+	defer span.End()
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		if exists, err := txdb.CheckProductSkuCodeExists(ctx, code); err != nil {
+			return err
+		} else if exists {
+			return ErrSkuCodeTaken
+		}
+		product, err := txdb.GetProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+		if product.Price+priceDelta < 0 {
+			return ErrInvalidSkuValues
+		}
+		// 옵션마다 정확히 하나의 값을 골랐는지 확인합니다.
+		variants, err := txdb.GetProductVariants(ctx, productId)
+		if err != nil {
+			return err
+		}
+		values, err := txdb.GetProductVariantValues(ctx, productId)
+		if err != nil {
+			return err
+		}
+		valueVariants := map[int64]int64{}
+		for _, v := range values {
+			valueVariants[v.Id] = v.VariantId
+		}
+		picked := map[int64]bool{}
+		for _, v := range valueIds {
+			variantId, ok := valueVariants[v]
+			if !ok || picked[variantId] {
+				return ErrInvalidSkuValues
+			}
+			picked[variantId] = true
+		}
+		if len(variants) == 0 || len(picked) != len(variants) {
+			return ErrInvalidSkuValues
+		}
+		// 같은 조합의 SKU가 이미 있는지 확인합니다.
+		skuValues, err := txdb.GetProductSkuValues(ctx, productId)
+		if err != nil {
+			return err
+		}
+		skuValueIds := map[int64][]int64{}
+		for _, v := range skuValues {
+			skuValueIds[v.SkuId] = append(skuValueIds[v.SkuId], v.ValueId)
+		}
+		key := skuValueKey(valueIds)
+		for _, v := range skuValueIds {
+			if skuValueKey(v) == key {
+				return ErrSkuCombinationTaken
+			}
+		}
+		skuId, err := txdb.AddProductSku(ctx, &dbmodel.ProductSku{
+			ProductId:  productId,
+			Code:       code,
+			PriceDelta: priceDelta,
+			Amount:     amount,
+		})
+		if err != nil {
+			return err
+		}
+		for _, v := range valueIds {
+			if err := txdb.AddProductSkuValue(ctx, &dbmodel.ProductSkuValue{
+				SkuId:     skuId,
+				ValueId:   v,
+				ProductId: productId,
+			}); err != nil {
+				return err
+			}
+		}
+		res = skuId
+		return nil
+	})
+	return res, err
+}
+
+// SKU의 추가 금액과 재고를 변경합니다.
+// 존재하지 않는 SKU라면 ErrSkuNotFound, 권한이 없다면 ErrPermissionDenied, 가격이 음수가 된다면 ErrNegativeSkuPrice를 반환합니다.
+func (uc *ProductUC) UpdateProductSku(ctx context.Context, userId, productId, skuId, priceDelta, amount int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.UpdateProductSku", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductSkuExists(ctx, productId, skuId); err != nil {
+			return err
+		} else if !exists {
+			return ErrSkuNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		product, err := txdb.GetProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+		if product.Price+priceDelta < 0 {
+			return ErrNegativeSkuPrice
+		}
+		sku, err := txdb.GetProductSku(ctx, skuId)
+		if err != nil {
+			return err
+		}
+		sku.PriceDelta = priceDelta
+		sku.Amount = amount
+		return txdb.UpdateProductSku(ctx, sku)
+	})
+	return err
+}
+
+// 상품의 옵션과 SKU 조합표를 만들어줍니다.
+// 옵션이 없는 상품이라면 빈 리스트를 반환합니다.
+func getProductVariantMatrix(ctx context.Context, productdb database.ProductDatabase, product *dbmodel.PublicProduct) ([]*dbmodel.PublicProductVariant, []*dbmodel.PublicProductSku, error) {
+	variants, err := productdb.GetProductVariants(ctx, product.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := productdb.GetProductVariantValues(ctx, product.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	skus, err := productdb.GetProductSkus(ctx, product.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	skuValues, err := productdb.GetProductSkuValues(ctx, product.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicVariants := []*dbmodel.PublicProductVariant{}
+	variantMap := map[int64]*dbmodel.PublicProductVariant{}
+	for _, v := range variants {
+		variant := &dbmodel.PublicProductVariant{
+			Id:     v.Id,
+			Name:   v.Name,
+			Values: []*dbmodel.PublicProductVariantValue{},
+		}
+		variantMap[v.Id] = variant
+		publicVariants = append(publicVariants, variant)
+	}
+	for _, v := range values {
+		if variant, ok := variantMap[v.VariantId]; ok {
+			variant.Values = append(variant.Values, &dbmodel.PublicProductVariantValue{
+				Id:    v.Id,
+				Value: v.Value,
+			})
+		}
+	}
+
+	skuValueIds := map[int64][]int64{}
+	for _, v := range skuValues {
+		skuValueIds[v.SkuId] = append(skuValueIds[v.SkuId], v.ValueId)
+	}
+	publicSkus := []*dbmodel.PublicProductSku{}
+	for _, v := range skus {
+		valueIds := skuValueIds[v.Id]
+		if valueIds == nil {
+			valueIds = []int64{}
+		}
+		publicSkus = append(publicSkus, &dbmodel.PublicProductSku{
+			Id:         v.Id,
+			Code:       v.Code,
+			ValueIds:   valueIds,
+			PriceDelta: v.PriceDelta,
+			Price:      product.Price + v.PriceDelta,
+			Stock:      v.Amount,
+		})
+	}
+	return publicVariants, publicSkus, nil
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/JongGeonClass/JGC-API/config"
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// 한 유저가 내 차고에 등록할 수 있는 최대 차량 개수입니다.
+const maxVehiclesPerUser = 10
+
+// 차종 리스트를 가져옵니다.
+// maker가 빈 문자열이 아니라면 해당 제조사의 차종만 가져옵니다.
+func (uc *ProductUC) GetVehicleModels(ctx context.Context, maker string) ([]*dbmodel.PublicVehicleModel, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetVehicleModels", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.GetVehicleModels(ctx, maker)
+}
+
+// 새로운 차종을 추가합니다.
+// 관리자만 추가할 수 있습니다.
+// 권한이 없다면 ErrPermissionDenied, 같은 제조사, 모델, 시작 연식의 차종이 이미 있다면 ErrVehicleModelTaken을 반환합니다.
+// 성공하면 추가된 차종 아이디를 반환합니다.
+func (uc *ProductUC) AddVehicleModel(ctx context.Context, userId int64, vehicle *dbmodel.VehicleModel) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddVehicleModel", tracing.KindInternal)
+	defer span.End()
+	if !config.Get().IsAdmin(userId) {
+		return 0, ErrPermissionDenied
+	}
+	res := int64(0)
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckVehicleModelNameExists(ctx, vehicle.Maker, vehicle.Model, vehicle.YearFrom); err != nil {
+			return err
+		} else if exists {
+			return ErrVehicleModelTaken
+		}
+		vehicleId, err := txdb.AddVehicleModel(ctx, vehicle)
+		if err != nil {
+			return err
+		}
+		res = vehicleId
+		return nil
+	})
+	return res, err
+}
+
+// 상품에 장착할 수 있는 차종들을 추가합니다.
+// 관리자나 상품 브랜드의 주인만 추가할 수 있으며, 이미 추가된 차종은 무시합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied, 존재하지 않는 차종이 있다면 ErrVehicleNotFound을 반환합니다.
+func (uc *ProductUC) AddProductFitment(ctx context.Context, userId, productId int64, vehicleIds []int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddProductFitment", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		// 하나라도 없는 차종이 있다면 아무것도 추가하지 않도록 먼저 모두 확인합니다.
+		for _, v := range vehicleIds {
+			if exists, err := txdb.CheckVehicleModelExists(ctx, v); err != nil {
+				return err
+			} else if !exists {
+				return ErrVehicleNotFound
+			}
+		}
+		for _, v := range vehicleIds {
+			if exists, err := txdb.CheckProductVehicleExists(ctx, productId, v); err != nil {
+				return err
+			} else if exists {
+				continue
+			}
+			if err := txdb.AddProductVehicle(ctx, &dbmodel.ProductVehicleMap{
+				ProductId: productId,
+				VehicleId: v,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// 상품에서 장착할 수 있는 차종을 삭제합니다.
+// 등록되지 않은 차종이라면 무시합니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound, 권한이 없다면 ErrPermissionDenied을 반환합니다.
+func (uc *ProductUC) DeleteProductFitment(ctx context.Context, userId, productId, vehicleId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteProductFitment", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		if ok, err := checkProductManager(ctx, txdb, userId, productId); err != nil {
+			return err
+		} else if !ok {
+			return ErrPermissionDenied
+		}
+		return txdb.DeleteProductVehicle(ctx, productId, vehicleId)
+	})
+	return err
+}
+
+// 유저가 내 차고에 등록한 차량 리스트를 가져옵니다.
+func (uc *ProductUC) GetGarage(ctx context.Context, userId int64) ([]*dbmodel.PublicUserVehicle, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetGarage", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.GetUserVehicles(ctx, userId)
+}
+
+// 내 차고에 차량을 등록합니다.
+// 존재하지 않는 차종이라면 ErrVehicleNotFound, 이미 등록한 차종이라면 ErrVehicleAlreadyInGarage, 더 이상 등록할 수 없다면 ErrGarageFull을 반환합니다.
+func (uc *ProductUC) AddToGarage(ctx context.Context, userId, vehicleId int64, nickname string) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddToGarage", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		if exists, err := txdb.CheckVehicleModelExists(ctx, vehicleId); err != nil {
+			return err
+		} else if !exists {
+			return ErrVehicleNotFound
+		}
+		if exists, err := txdb.CheckUserVehicleExists(ctx, userId, vehicleId); err != nil {
+			return err
+		} else if exists {
+			return ErrVehicleAlreadyInGarage
+		}
+		if count, err := txdb.GetUserVehicleCount(ctx, userId); err != nil {
+			return err
+		} else if count >= maxVehiclesPerUser {
+			return ErrGarageFull
+		}
+		return txdb.AddUserVehicle(ctx, &dbmodel.UserVehicle{
+			UserId:    userId,
+			VehicleId: vehicleId,
+			Nickname:  nickname,
+		})
+	})
+	return err
+}
+
+// 내 차고에서 차량을 삭제합니다.
+// 등록하지 않은 차종이라면 무시합니다.
+func (uc *ProductUC) DeleteFromGarage(ctx context.Context, userId, vehicleId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteFromGarage", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.DeleteUserVehicle(ctx, userId, vehicleId)
+}
+
+// 상품 리스트에 유저의 내 차고에 있는 차량에 장착할 수 있는지를 표시합니다.
+// 게스트는 등록한 차량이 없으므로 모두 false가 됩니다.
+func markFitsMyVehicle(ctx context.Context, productdb database.ProductDatabase, products []*dbmodel.PublicProduct, userId int64) error {
+	productIds := make([]int64, 0, len(products))
+	for _, v := range products {
+		productIds = append(productIds, v.Id)
+	}
+	fittingIds, err := productdb.GetProductIdsFittingUser(ctx, productIds, userId)
+	if err != nil {
+		return err
+	}
+	fitting := map[int64]bool{}
+	for _, v := range fittingIds {
+		fitting[v] = true
+	}
+	for _, v := range products {
+		v.FitsMyVehicle = fitting[v.Id]
+	}
+	return nil
+}
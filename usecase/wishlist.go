@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/JongGeonClass/JGC-API/database"
+	"github.com/JongGeonClass/JGC-API/dbmodel"
+	"github.com/JongGeonClass/JGC-API/metrics"
+	"github.com/JongGeonClass/JGC-API/tracing"
+)
+
+// 유저가 찜한 상품 리스트를 가져옵니다.
+func (uc *ProductUC) GetWishlist(ctx context.Context, userId int64) ([]*dbmodel.PublicWishlist, error) {
+	ctx, span := tracing.Start(ctx, "ProductUC.GetWishlist", tracing.KindInternal)
+	defer span.End()
+	return uc.productdb.GetWishlist(ctx, userId)
+}
+
+// 상품을 찜 목록에 추가하고 상품의 찜 개수를 올립니다.
+// 이미 찜한 상품이라면 아무것도 하지 않습니다.
+// 존재하지 않는 상품이라면 ErrProductNotFound을 반환합니다.
+func (uc *ProductUC) AddToWishlist(ctx context.Context, userId, productId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.AddToWishlist", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 존재하지 않는 상품이라면 ErrProductNotFound을 반환합니다.
+		if exists, err := txdb.CheckProductExists(ctx, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrProductNotFound
+		}
+		// 이미 찜한 상품이라면 무시합니다.
+		if exists, err := txdb.CheckWishlistHasProduct(ctx, userId, productId); err != nil {
+			return err
+		} else if exists {
+			return nil
+		}
+		if err := txdb.AddWishlist(ctx, &dbmodel.Wishlist{
+			UserId:    userId,
+			ProductId: productId,
+		}); err != nil {
+			return err
+		}
+		return addFavoriteCount(ctx, txdb, productId, 1)
+	})
+	return err
+}
+
+// 상품을 찜 목록에서 삭제하고 상품의 찜 개수를 내립니다.
+// 찜하지 않은 상품이라면 무시합니다.
+func (uc *ProductUC) DeleteFromWishlist(ctx context.Context, userId, productId int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.DeleteFromWishlist", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		_, err := deleteWishlistProduct(ctx, txdb, userId, productId)
+		return err
+	})
+	return err
+}
+
+// 찜한 상품을 장바구니로 옮깁니다.
+// 장바구니에 담는 것과 찜 목록에서 빼는 것은 함께 성공하거나 함께 실패합니다.
+// 찜하지 않은 상품이라면 ErrNotInWishlist, 잘못 고른 SKU라면 ErrInvalidSku를 반환합니다.
+func (uc *ProductUC) MoveWishlistToCart(ctx context.Context, userId, productId, skuId, amount int64) error {
+	ctx, span := tracing.Start(ctx, "ProductUC.MoveWishlistToCart", tracing.KindInternal)
+	defer span.End()
+	err := uc.productdb.ExecTx(ctx, func(txdb database.ProductDatabase) error {
+		// 찜하지 않은 상품이라면 ErrNotInWishlist을 반환합니다.
+		if exists, err := txdb.CheckWishlistHasProduct(ctx, userId, productId); err != nil {
+			return err
+		} else if !exists {
+			return ErrNotInWishlist
+		}
+		// 잘못 고른 SKU라면 ErrInvalidSku를 반환합니다.
+		price, _, ok, err := getCartLineStock(ctx, txdb, productId, skuId)
+		if err != nil {
+			return err
+		} else if !ok {
+			return ErrInvalidSku
+		}
+		if _, err := deleteWishlistProduct(ctx, txdb, userId, productId); err != nil {
+			return err
+		}
+		return addCartProduct(ctx, txdb, userId, productId, skuId, amount, price)
+	})
+	if err == nil {
+		metrics.CartAdds.Inc("user")
+	}
+	return err
+}
+
+// 트랜잭션 안에서 찜 목록의 상품을 삭제하고 상품의 찜 개수를 내립니다.
+// 찜하지 않은 상품이라면 false를 반환합니다.
+func deleteWishlistProduct(ctx context.Context, txdb database.ProductDatabase, userId, productId int64) (bool, error) {
+	if exists, err := txdb.CheckWishlistHasProduct(ctx, userId, productId); err != nil {
+		return false, err
+	} else if !exists {
+		return false, nil
+	}
+	if err := txdb.DeleteWishlistProduct(ctx, userId, productId); err != nil {
+		return false, err
+	}
+	if err := addFavoriteCount(ctx, txdb, productId, -1); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// 상품 통계의 찜 개수에 delta를 더합니다.
+// 찜 개수가 0보다 작아지지 않도록 합니다.
+func addFavoriteCount(ctx context.Context, txdb database.ProductDatabase, productId, delta int64) error {
+	return updateProductStatistics(ctx, txdb, productId, func(statistics *dbmodel.ProductStatistics) {
+		statistics.FavoriteCount += delta
+		if statistics.FavoriteCount < 0 {
+			statistics.FavoriteCount = 0
+		}
+	})
+}
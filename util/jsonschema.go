@@ -0,0 +1,270 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// JSON Schema(draft-07)의 일부 키워드만 지원하는 검증기입니다.
+// 지원하는 키워드는 아래와 같으며, 그 외의 키워드는 무시합니다.
+// type, enum, properties, required, additionalProperties, maxProperties,
+// items, minItems, maxItems, minLength, maxLength, pattern, minimum, maximum
+type JsonSchema struct {
+	Type                 jsonSchemaTypes        `json:"type"`
+	Enum                 []interface{}          `json:"enum"`
+	Properties           map[string]*JsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *jsonSchemaOrBool      `json:"additionalProperties"`
+	MaxProperties        *int                   `json:"maxProperties"`
+	Items                *JsonSchema            `json:"items"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+
+	pattern *regexp.Regexp
+}
+
+// 스키마 검증에 실패한 필드의 경로와 이유입니다.
+// 경로는 JSON Pointer(RFC 6901) 형식이며, 최상위 값은 ""입니다.
+type JsonSchemaError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e *JsonSchemaError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// type 키워드는 문자열 하나 혹은 문자열 배열로 올 수 있습니다.
+type jsonSchemaTypes []string
+
+func (t *jsonSchemaTypes) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = []string{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// additionalProperties 키워드는 bool 혹은 스키마로 올 수 있습니다.
+type jsonSchemaOrBool struct {
+	Allowed bool
+	Schema  *JsonSchema
+}
+
+func (s *jsonSchemaOrBool) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &s.Allowed); err == nil {
+		return nil
+	}
+	s.Allowed = true
+	s.Schema = &JsonSchema{}
+	return json.Unmarshal(b, s.Schema)
+}
+
+// JSON 문서를 파싱해 스키마를 만듭니다.
+// pattern 키워드의 정규식도 이때 미리 컴파일합니다.
+func ParseJsonSchema(b []byte) (*JsonSchema, error) {
+	schema := &JsonSchema{}
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, err
+	}
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func (s *JsonSchema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = re
+	}
+	for _, v := range s.Properties {
+		if err := v.compile(); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		if err := s.AdditionalProperties.Schema.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encoding/json으로 디코딩한 값을 스키마로 검증합니다.
+// 검증에 실패한 모든 필드를 반환하며, 통과했다면 빈 슬라이스를 반환합니다.
+func (s *JsonSchema) Validate(v interface{}) []*JsonSchemaError {
+	errs := []*JsonSchemaError{}
+	s.validate(v, "", &errs)
+	return errs
+}
+
+// JSON Pointer 경로에 토큰을 이어 붙입니다.
+func jsonPointerJoin(path, token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	return path + "/" + token
+}
+
+// 값의 JSON 타입 이름을 반환합니다.
+func jsonTypeOf(v interface{}) string {
+	switch w := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if w == math.Trunc(w) {
+			return "integer"
+		}
+		return "number"
+	case json.Number:
+		if _, err := w.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+// 값을 float64로 변환합니다.
+func jsonNumber(v interface{}) (float64, bool) {
+	switch w := v.(type) {
+	case float64:
+		return w, true
+	case json.Number:
+		f, err := w.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func (s *JsonSchema) validate(v interface{}, path string, errs *[]*JsonSchemaError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, &JsonSchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	typ := jsonTypeOf(v)
+	if len(s.Type) > 0 {
+		matched := false
+		for _, t := range s.Type {
+			if t == typ || (t == "number" && typ == "integer") {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("must be %s", strings.Join(s.Type, " or "))
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		matched := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(v) && jsonTypeOf(e) == typ {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("must be one of %v", s.Enum)
+		}
+	}
+
+	switch w := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(w)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(w) {
+			fail("must match pattern %s", s.Pattern)
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(w) < *s.MinItems {
+			fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(w) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range w {
+				s.Items.validate(item, jsonPointerJoin(path, strconv.Itoa(i)), errs)
+			}
+		}
+	case map[string]interface{}:
+		if s.MaxProperties != nil && len(w) > *s.MaxProperties {
+			fail("must have at most %d properties", *s.MaxProperties)
+		}
+		for _, key := range s.Required {
+			if _, ok := w[key]; !ok {
+				*errs = append(*errs, &JsonSchemaError{Path: jsonPointerJoin(path, key), Message: "is required"})
+			}
+		}
+		// 에러 순서가 항상 같도록 키를 정렬해서 검사합니다.
+		keys := make([]string, 0, len(w))
+		for key := range w {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			item := w[key]
+			if prop, ok := s.Properties[key]; ok {
+				prop.validate(item, jsonPointerJoin(path, key), errs)
+				continue
+			}
+			if s.AdditionalProperties == nil {
+				continue
+			}
+			if !s.AdditionalProperties.Allowed {
+				*errs = append(*errs, &JsonSchemaError{Path: jsonPointerJoin(path, key), Message: "is not allowed"})
+			} else if s.AdditionalProperties.Schema != nil {
+				s.AdditionalProperties.Schema.validate(item, jsonPointerJoin(path, key), errs)
+			}
+		}
+	default:
+		if f, ok := jsonNumber(v); ok {
+			if s.Minimum != nil && f < *s.Minimum {
+				fail("must be greater than or equal to %v", *s.Minimum)
+			}
+			if s.Maximum != nil && f > *s.Maximum {
+				fail("must be less than or equal to %v", *s.Maximum)
+			}
+		}
+	}
+}
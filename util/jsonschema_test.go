@@ -0,0 +1,105 @@
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testJsonSchema = `{
+  "type": "object",
+  "required": ["name", "tags"],
+  "properties": {
+    "name": {"type": "string", "minLength": 1, "maxLength": 5, "pattern": "^[a-z]+$"},
+    "kind": {"enum": ["a", "b", 1]},
+    "count": {"type": "integer", "minimum": 1, "maximum": 10},
+    "ratio": {"type": "number"},
+    "tags": {
+      "type": "array",
+      "minItems": 1,
+      "maxItems": 2,
+      "items": {"type": "string"}
+    },
+    "meta": {
+      "type": "object",
+      "maxProperties": 2,
+      "additionalProperties": {"type": ["string", "boolean"]}
+    }
+  },
+  "additionalProperties": false
+}`
+
+func TestJsonSchemaValidate(t *testing.T) {
+	schema, err := ParseJsonSchema([]byte(testJsonSchema))
+	if err != nil {
+		t.Fatalf("ParseJsonSchema() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		data string
+		want []*JsonSchemaError
+	}{
+		{"valid", `{"name": "abc", "tags": ["x"], "count": 3, "ratio": 0.5, "kind": 1, "meta": {"a": "b", "c": true}}`, nil},
+		{"not object", `[]`, []*JsonSchemaError{{"", "must be object"}}},
+		{"required", `{}`, []*JsonSchemaError{{"/name", "is required"}, {"/tags", "is required"}}},
+		{"additional property", `{"name": "a", "tags": ["x"], "extra": 1}`, []*JsonSchemaError{{"/extra", "is not allowed"}}},
+		{"string too short", `{"name": "", "tags": ["x"]}`, []*JsonSchemaError{
+			{"/name", "must be at least 1 characters"},
+			{"/name", "must match pattern ^[a-z]+$"},
+		}},
+		{"string length counts runes", `{"name": "가나다라마바", "tags": ["x"]}`, []*JsonSchemaError{
+			{"/name", "must be at most 5 characters"},
+			{"/name", "must match pattern ^[a-z]+$"},
+		}},
+		{"enum", `{"name": "a", "tags": ["x"], "kind": "1"}`, []*JsonSchemaError{{"/kind", "must be one of [a b 1]"}}},
+		{"integer", `{"name": "a", "tags": ["x"], "count": 1.5}`, []*JsonSchemaError{{"/count", "must be integer"}}},
+		{"minimum", `{"name": "a", "tags": ["x"], "count": 0}`, []*JsonSchemaError{{"/count", "must be greater than or equal to 1"}}},
+		{"maximum", `{"name": "a", "tags": ["x"], "count": 11}`, []*JsonSchemaError{{"/count", "must be less than or equal to 10"}}},
+		{"number accepts integer", `{"name": "a", "tags": ["x"], "ratio": 1}`, nil},
+		{"too few items", `{"name": "a", "tags": []}`, []*JsonSchemaError{{"/tags", "must have at least 1 items"}}},
+		{"too many items", `{"name": "a", "tags": ["x", "y", 1]}`, []*JsonSchemaError{
+			{"/tags", "must have at most 2 items"},
+			{"/tags/2", "must be string"},
+		}},
+		{"additional properties schema", `{"name": "a", "tags": ["x"], "meta": {"a/b": 1}}`, []*JsonSchemaError{{"/meta/a~1b", "must be string or boolean"}}},
+		{"max properties", `{"name": "a", "tags": ["x"], "meta": {"a": "1", "b": "2", "c": "3"}}`, []*JsonSchemaError{{"/meta", "must have at most 2 properties"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data interface{}
+			if err := json.Unmarshal([]byte(tt.data), &data); err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			if want == nil {
+				want = []*JsonSchemaError{}
+			}
+			if got := schema.Validate(data); !reflect.DeepEqual(got, want) {
+				t.Errorf("Validate(%s) = %v, want %v", tt.data, got, want)
+			}
+		})
+	}
+}
+
+func TestParseJsonSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{"type list", `{"type": ["string", "null"]}`, false},
+		{"additional properties bool", `{"additionalProperties": true}`, false},
+		{"not json", `{`, true},
+		{"bad type", `{"type": 1}`, true},
+		{"bad pattern", `{"pattern": "("}`, true},
+		{"bad nested pattern", `{"properties": {"a": {"items": {"pattern": "["}}}}`, true},
+		{"bad additional properties pattern", `{"additionalProperties": {"pattern": "("}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseJsonSchema([]byte(tt.schema)); (err != nil) != tt.wantErr {
+				t.Errorf("ParseJsonSchema(%s) error = %v, wantErr %v", tt.schema, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// 마크다운의 일부 문법만 지원하는 HTML 렌더러입니다.
+// 지원하는 문법은 제목(#), 문단, 목록(-, *, +, 1.), 인용(>), 구분선(---), 코드 블록(```)과
+// 문장 안의 굵게(**), 기울임(*), 코드(`), 링크([text](url))입니다.
+// 입력에 들어있는 HTML은 모두 이스케이프하고 렌더러가 만든 태그만 출력하므로, 결과를 그대로 페이지에 넣어도 안전합니다.
+// 링크는 http, https, mailto와 사이트 내부 경로(/)만 허용합니다.
+
+var (
+	mdHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRule        = regexp.MustCompile(`^\s*(?:-{3,}|\*{3,}|_{3,})\s*$`)
+	mdQuote       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdUnordered   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrdered     = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	mdFence       = regexp.MustCompile("^\\s*```")
+	mdCodeSpan    = regexp.MustCompile("`([^`]+)`")
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBold        = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	mdItalic      = regexp.MustCompile(`\*(.+?)\*`)
+	mdPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+	mdMarks       = regexp.MustCompile("[*`]")
+)
+
+// 링크로 허용할 주소인지 확인합니다.
+func IsSafeUrl(url string) bool {
+	lower := strings.ToLower(strings.TrimSpace(url))
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "mailto:"):
+		return true
+	case strings.HasPrefix(lower, "/") && !strings.HasPrefix(lower, "//"):
+		return true
+	}
+	return false
+}
+
+// 문장 안의 마크다운 문법을 HTML로 바꿔줍니다.
+func renderMarkdownInline(text string) string {
+	// 코드 안의 문법은 바꾸지 않도록 먼저 자리표시자로 빼둡니다.
+	// 자리표시자는 NUL 문자로 감싸므로, 입력에 들어있는 NUL 문자는 먼저 지웁니다.
+	text = strings.ReplaceAll(text, "\x00", "")
+	codes := []string{}
+	text = mdCodeSpan.ReplaceAllStringFunc(text, func(m string) string {
+		codes = append(codes, "<code>"+html.EscapeString(mdCodeSpan.FindStringSubmatch(m)[1])+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(codes)-1)
+	})
+	text = html.EscapeString(text)
+	text = mdLink.ReplaceAllStringFunc(text, func(m string) string {
+		sub := mdLink.FindStringSubmatch(m)
+		if !IsSafeUrl(html.UnescapeString(sub[2])) {
+			return sub[1]
+		}
+		return `<a href="` + sub[2] + `" rel="nofollow noopener noreferrer">` + sub[1] + `</a>`
+	})
+	text = mdBold.ReplaceAllString(text, "<strong>$1</strong>")
+	text = mdItalic.ReplaceAllString(text, "<em>$1</em>")
+	return mdPlaceholder.ReplaceAllStringFunc(text, func(m string) string {
+		i := -1
+		fmt.Sscanf(mdPlaceholder.FindStringSubmatch(m)[1], "%d", &i)
+		if i < 0 || i >= len(codes) {
+			return ""
+		}
+		return codes[i]
+	})
+}
+
+// 문장 안의 마크다운 문법을 지우고 글자만 남깁니다.
+func markdownInlineText(text string) string {
+	text = mdLink.ReplaceAllString(text, "$1")
+	return mdMarks.ReplaceAllString(text, "")
+}
+
+// 마크다운 문서를 블록 단위로 읽으면서 블록마다 emit을 호출합니다.
+// kind는 heading1~6, paragraph, ul, ol, quote, code, rule 중 하나이며,
+// lines는 블록 문법 기호를 뗀 줄 목록입니다.
+func walkMarkdown(src string, emit func(kind string, lines []string)) {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	kind := ""
+	block := []string{}
+	flush := func() {
+		if kind != "" {
+			emit(kind, block)
+		}
+		kind = ""
+		block = []string{}
+	}
+	add := func(k, line string) {
+		if kind != k {
+			flush()
+			kind = k
+		}
+		block = append(block, line)
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if mdFence.MatchString(line) {
+			flush()
+			code := []string{}
+			for i++; i < len(lines) && !mdFence.MatchString(lines[i]); i++ {
+				code = append(code, lines[i])
+			}
+			emit("code", code)
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			flush()
+		} else if m := mdHeading.FindStringSubmatch(line); m != nil {
+			flush()
+			emit(fmt.Sprintf("heading%d", len(m[1])), []string{m[2]})
+		} else if mdRule.MatchString(line) {
+			flush()
+			emit("rule", nil)
+		} else if m := mdQuote.FindStringSubmatch(line); m != nil {
+			add("quote", m[1])
+		} else if m := mdUnordered.FindStringSubmatch(line); m != nil {
+			add("ul", m[1])
+		} else if m := mdOrdered.FindStringSubmatch(line); m != nil {
+			add("ol", m[1])
+		} else if kind == "ul" || kind == "ol" || kind == "quote" {
+			// 목록이나 인용 바로 다음 줄은 앞 항목에 이어지는 내용으로 봅니다.
+			block[len(block)-1] += " " + strings.TrimSpace(line)
+		} else {
+			add("paragraph", strings.TrimSpace(line))
+		}
+	}
+	flush()
+}
+
+// 마크다운 문서를 안전한 HTML로 바꿔줍니다.
+func RenderMarkdown(src string) string {
+	sb := &strings.Builder{}
+	walkMarkdown(src, func(kind string, lines []string) {
+		switch kind {
+		case "paragraph":
+			sb.WriteString("<p>" + renderMarkdownInline(strings.Join(lines, " ")) + "</p>\n")
+		case "quote":
+			sb.WriteString("<blockquote><p>" + renderMarkdownInline(strings.Join(lines, " ")) + "</p></blockquote>\n")
+		case "ul", "ol":
+			sb.WriteString("<" + kind + ">")
+			for _, v := range lines {
+				sb.WriteString("<li>" + renderMarkdownInline(v) + "</li>")
+			}
+			sb.WriteString("</" + kind + ">\n")
+		case "code":
+			sb.WriteString("<pre><code>" + html.EscapeString(strings.Join(lines, "\n")) + "</code></pre>\n")
+		case "rule":
+			sb.WriteString("<hr>\n")
+		default: // heading1 ~ heading6
+			level := strings.TrimPrefix(kind, "heading")
+			sb.WriteString("<h" + level + ">" + renderMarkdownInline(lines[0]) + "</h" + level + ">\n")
+		}
+	})
+	return sb.String()
+}
+
+// 마크다운 문서에서 문법을 지우고 검색에 사용할 글자만 남깁니다.
+// 블록은 줄바꿈으로 구분합니다.
+func MarkdownPlainText(src string) string {
+	texts := []string{}
+	walkMarkdown(src, func(kind string, lines []string) {
+		if kind == "code" {
+			texts = append(texts, strings.Join(lines, " "))
+			return
+		}
+		for _, v := range lines {
+			texts = append(texts, markdownInlineText(v))
+		}
+	})
+	return strings.Join(texts, "\n")
+}
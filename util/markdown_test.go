@@ -0,0 +1,110 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"paragraph", "hello world", "<p>hello world</p>\n"},
+		{"heading", "## Title ##", "<h2>Title</h2>\n"},
+		{"unordered list", "- a\n- b", "<ul><li>a</li><li>b</li></ul>\n"},
+		{"ordered list", "1. a\n2. b", "<ol><li>a</li><li>b</li></ol>\n"},
+		{"quote", "> said", "<blockquote><p>said</p></blockquote>\n"},
+		{"rule", "---", "<hr>\n"},
+		{"code block", "```\n<b>*x*</b>\n```", "<pre><code>&lt;b&gt;*x*&lt;/b&gt;</code></pre>\n"},
+		{"bold and italic", "**a** *b*", "<p><strong>a</strong> <em>b</em></p>\n"},
+		{"code span keeps marks", "`**a**`", "<p><code>**a**</code></p>\n"},
+		{"safe link", "[jgc](https://jgc.com)", `<p><a href="https://jgc.com" rel="nofollow noopener noreferrer">jgc</a></p>` + "\n"},
+		{"internal link", "[me](/mypage)", `<p><a href="/mypage" rel="nofollow noopener noreferrer">me</a></p>` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.src); got != tt.want {
+				t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownEscapesHtml(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		deny []string
+	}{
+		{"script tag", "<script>alert(1)</script>", []string{"<script"}},
+		{"img onerror", `<img src=x onerror="alert(1)">`, []string{"<img"}},
+		{"javascript link", "[x](javascript:alert(1))", []string{"<a", "javascript:"}},
+		{"data link", "[x](data:text/html,hi)", []string{"<a"}},
+		{"protocol relative link", "[x](//evil.com)", []string{"<a"}},
+		{"quote in link", `[x](https://a.com/"onmouseover="alert(1))`, []string{`"onmouseover`}},
+		{"html in code span", "`<script>`", []string{"<script"}},
+		{"html in heading", "# <iframe>", []string{"<iframe"}},
+		{"html in list", "- <svg onload=alert(1)>", []string{"<svg"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderMarkdown(tt.src)
+			for _, v := range tt.deny {
+				if strings.Contains(got, v) {
+					t.Errorf("RenderMarkdown(%q) = %q, must not contain %q", tt.src, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownPlaceholder(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"placeholder without code span", "hello \x007\x00 world", "<p>hello 7 world</p>\n"},
+		{"placeholder next to code span", "`a` \x000\x00 \x001\x00", "<p><code>a</code> 0 1</p>\n"},
+		{"nul only", "\x00\x00", "<p></p>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.src); got != tt.want {
+				t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownPlainText(t *testing.T) {
+	src := "# Title\n\n**bold** and [link](https://a.com)\n\n```\ncode\n```"
+	want := "Title\nbold and link\ncode"
+	if got := MarkdownPlainText(src); got != want {
+		t.Errorf("MarkdownPlainText(%q) = %q, want %q", src, got, want)
+	}
+}
+
+func TestIsSafeUrl(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://a.com", true},
+		{"HTTP://a.com", true},
+		{"mailto:a@a.com", true},
+		{"/product/1", true},
+		{"//a.com", false},
+		{"javascript:alert(1)", false},
+		{" JavaScript:alert(1)", false},
+		{"data:text/html,hi", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsSafeUrl(tt.url); got != tt.want {
+			t.Errorf("IsSafeUrl(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
@@ -6,3 +6,12 @@ import "github.com/google/uuid"
 func NewUuid() string {
 	return uuid.New().String()
 }
+
+// 문자열이 올바른 uuid 형식인지 확인합니다.
+func IsUuid(str string) bool {
+	if len(str) != 36 {
+		return false
+	}
+	_, err := uuid.Parse(str)
+	return err == nil
+}
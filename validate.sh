@@ -0,0 +1,14 @@
+#!/bin/bash
+
+# 새로 띄운 서버가 요청을 받을 준비가 될 때까지 /readyz를 확인합니다.
+# 제한 시간 안에 준비되지 않으면 배포를 실패로 처리합니다.
+PORT=8806
+for i in $(seq 1 60); do
+    if curl -sf "http://localhost:$PORT/readyz" > /dev/null; then
+        curl -s "http://localhost:$PORT/version"
+        exit 0
+    fi
+    sleep 5
+done
+curl -s "http://localhost:$PORT/readyz"
+exit 1
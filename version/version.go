@@ -0,0 +1,12 @@
+package version
+
+// 빌드 정보입니다.
+// 빌드할 때 아래와 같이 ldflags로 채워지며, 채우지 않고 빌드했다면 unknown입니다.
+// go build -ldflags "-X github.com/JongGeonClass/JGC-API/version.Commit=$(git rev-parse --short HEAD)"
+var (
+	// 빌드한 커밋 해시입니다.
+	Commit = "unknown"
+
+	// 빌드한 시각입니다. (UTC, RFC3339)
+	BuildTime = "unknown"
+)